@@ -11,6 +11,7 @@ import (
 	"github.com/banking-superapp/wealth-service/config"
 	"github.com/banking-superapp/wealth-service/handler"
 	"github.com/banking-superapp/wealth-service/repository"
+	"github.com/banking-superapp/wealth-service/scheduler"
 	"github.com/banking-superapp/wealth-service/service"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -36,9 +37,115 @@ func main() {
 	sipRepo := repository.NewSIPRepo(db)
 	portRepo := repository.NewPortfolioRepo(db)
 	riskRepo := repository.NewRiskProfileRepo(db)
+	factRepo := repository.NewFactsheetRepo(db)
+	txnRepo := repository.NewTransactionRepo(db)
+	idcwRepo := repository.NewIDCWRepo(db)
+	actionRepo := repository.NewCorporateActionRepo(db)
+	navRepo := repository.NewNAVRepo(db)
+	goalRepo := repository.NewGoalRepo(db)
+	modelRepo := repository.NewModelPortfolioRepo(db)
+	watchRepo := repository.NewWatchlistRepo(db)
+	notifRepo := repository.NewNotificationRepo(db)
+	nfoRepo := repository.NewNFORepo(db)
+	nfoOrderRepo := repository.NewNFOOrderRepo(db)
+	goldPriceRepo := repository.NewGoldPriceRepo(db)
+	npsRepo := repository.NewNPSSchemeRepo(db)
+	secRepo := repository.NewSecurityRepo(db)
+	equityActionRepo := repository.NewEquityActionRepo(db)
+	netWorthRepo := repository.NewNetWorthRepo(db)
+	netWorthSnapRepo := repository.NewNetWorthSnapshotRepo(db)
+	portSnapRepo := repository.NewPortfolioSnapshotRepo(db)
+	benchRepo := repository.NewBenchmarkRepo(db)
+	reviewRepo := repository.NewSchemeReviewRepo(db)
+	taxSavingRepo := repository.NewTaxSavingRepo(db)
+	profileRepo := repository.NewInvestorProfileRepo(db)
+	digestRepo := repository.NewDigestRepo(db)
+	familyRepo := repository.NewFamilyRepo(db)
+	membershipRepo := repository.NewFamilyMembershipRepo(db)
+	consentRepo := repository.NewFamilyConsentRepo(db)
+	accessLogRepo := repository.NewFamilyAccessLogRepo(db)
+	txr := repository.NewTransactor(mongoClient)
 
-	wealthSvc := service.NewWealthService(mfRepo, sipRepo, portRepo, riskRepo)
+	wealthSvc := service.NewWealthService(mfRepo, sipRepo, portRepo, riskRepo, factRepo, txnRepo)
 	wealthHandler := handler.NewWealthHandler(wealthSvc)
+	idcwSvc := service.NewIDCWService(mfRepo, portRepo, txnRepo, idcwRepo, txr)
+	idcwHandler := handler.NewIDCWHandler(idcwSvc)
+	actionSvc := service.NewCorporateActionService(mfRepo, sipRepo, portRepo, txnRepo, actionRepo, goalRepo, watchRepo, txr)
+	actionHandler := handler.NewCorporateActionHandler(actionSvc)
+	nfoSvc := service.NewNFOService(nfoRepo, nfoOrderRepo, mfRepo, portRepo, txnRepo, txr)
+	nfoHandler := handler.NewNFOHandler(nfoSvc)
+	fdSvc := service.NewFixedDepositService(portRepo, notifRepo, txr)
+	fdHandler := handler.NewFixedDepositHandler(fdSvc)
+	goldHandler := handler.NewGoldHandler(service.NewGoldService(goldPriceRepo, portRepo, txr))
+	npsHandler := handler.NewNPSHandler(service.NewNPSService(npsRepo, navRepo, portRepo, txnRepo, txr))
+	equitySvc := service.NewEquityService(secRepo, navRepo, portRepo, txnRepo, equityActionRepo, txr)
+	equityHandler := handler.NewEquityHandler(equitySvc)
+	netWorthSvc := service.NewNetWorthService(netWorthRepo, netWorthSnapRepo, portRepo)
+	netWorthHandler := handler.NewNetWorthHandler(netWorthSvc)
+	valuationSvc := service.NewValuationService(portRepo, portSnapRepo, mfRepo, goldPriceRepo, npsRepo, secRepo, txr)
+	valuationHandler := handler.NewValuationHandler(valuationSvc)
+	watchSvc := service.NewWatchlistService(watchRepo, mfRepo, navRepo, notifRepo, txr)
+	watchHandler := handler.NewWatchlistHandler(watchSvc)
+	navHandler := handler.NewNAVHandler(service.NewNAVService(mfRepo, navRepo, watchSvc))
+	compareHandler := handler.NewCompareHandler(service.NewCompareService(mfRepo, navRepo, factRepo))
+	benchmarkHandler := handler.NewBenchmarkHandler(service.NewBenchmarkService(benchRepo, navRepo, mfRepo, factRepo, portRepo, txnRepo, sipRepo))
+	reviewSvc := service.NewReviewService(reviewRepo, mfRepo, navRepo, benchRepo, factRepo, portRepo, notifRepo)
+	reviewHandler := handler.NewReviewHandler(reviewSvc)
+	taxSavingHandler := handler.NewTaxSavingHandler(service.NewTaxSavingService(taxSavingRepo, txnRepo, sipRepo, mfRepo))
+	insightSvc := service.NewInsightService(digestRepo, portRepo, portSnapRepo, txnRepo, sipRepo, navRepo, notifRepo, wealthSvc, txr)
+	insightHandler := handler.NewInsightHandler(insightSvc)
+	statementHandler := handler.NewStatementHandler(service.NewStatementService(profileRepo, txnRepo, portRepo, navRepo, equitySvc))
+	backtestHandler := handler.NewBacktestHandler(service.NewBacktestService(mfRepo, navRepo))
+	goalSvc := service.NewGoalService(goalRepo, sipRepo, portRepo, riskRepo, mfRepo)
+	goalHandler := handler.NewGoalHandler(goalSvc)
+	familyHandler := handler.NewFamilyHandler(service.NewFamilyService(familyRepo, membershipRepo, consentRepo, accessLogRepo, wealthSvc, goalSvc, txr))
+	advisoryHandler := handler.NewAdvisoryHandler(service.NewAdvisoryService(modelRepo, riskRepo, mfRepo, sipRepo, txr))
+	calcHandler := handler.NewCalculatorHandler(service.NewCalculatorService())
+	projectionHandler := handler.NewProjectionHandler(service.NewProjectionService(portRepo, sipRepo, mfRepo, goalRepo, navRepo))
+
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	// NAVs are published on Indian time, which has no daylight saving.
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+	scheduler.Every(jobCtx, "idcw-processing", time.Hour, func(ctx context.Context) error {
+		_, err := idcwSvc.ProcessDue(ctx, time.Now())
+		return err
+	})
+	scheduler.Every(jobCtx, "corporate-actions", time.Hour, func(ctx context.Context) error {
+		_, err := actionSvc.ProcessDue(ctx, time.Now())
+		return err
+	})
+	scheduler.Every(jobCtx, "equity-corporate-actions", time.Hour, func(ctx context.Context) error {
+		_, err := equitySvc.ProcessDueActions(ctx, time.Now())
+		return err
+	})
+	scheduler.Every(jobCtx, "fd-maturity-reminders", time.Hour, func(ctx context.Context) error {
+		_, err := fdSvc.SendMaturityReminders(ctx, time.Now())
+		return err
+	})
+	scheduler.Every(jobCtx, "nfo-allotment", time.Hour, func(ctx context.Context) error {
+		_, err := nfoSvc.AllotDue(ctx, time.Now())
+		return err
+	})
+	// Snapshots run after the nightly valuation but before midnight, so each
+	// month's last snapshot carries its last day's NAVs.
+	scheduler.Daily(jobCtx, "net-worth-snapshots", 23*time.Hour+55*time.Minute, ist, func(ctx context.Context) error {
+		_, err := netWorthSvc.SnapshotAll(ctx, time.Now().In(ist))
+		return err
+	})
+	// Reviews and digests run in the morning, after the nightly valuation.
+	scheduler.Daily(jobCtx, "scheme-review", 6*time.Hour, ist, func(ctx context.Context) error {
+		_, err := reviewSvc.ReviewAll(ctx, time.Now())
+		return err
+	})
+	scheduler.Daily(jobCtx, "monthly-insights", 7*time.Hour, ist, func(ctx context.Context) error {
+		_, err := insightSvc.GenerateAll(ctx, time.Now())
+		return err
+	})
+	scheduler.Daily(jobCtx, "portfolio-valuation", cfg.ValuationTime, ist, func(ctx context.Context) error {
+		_, err := valuationSvc.RevalueAll(ctx, time.Now())
+		return err
+	})
 
 	app := fiber.New(fiber.Config{
 		AppName:      cfg.ServiceName,
@@ -57,11 +164,109 @@ func main() {
 	v1 := app.Group("/v1")
 	wealth := v1.Group("/wealth")
 	wealth.Get("/mf/catalogue", wealthHandler.GetCatalogue)
+	wealth.Get("/mf/funds/:fundCode", wealthHandler.GetFund)
+	wealth.Get("/mf/schemes/:code", wealthHandler.GetSchemeDetail)
+	wealth.Get("/mf/schemes/:code/nav", navHandler.GetHistory)
+	wealth.Get("/mf/schemes/:code/benchmark", benchmarkHandler.CompareScheme)
+	wealth.Get("/benchmarks", benchmarkHandler.List)
+	wealth.Get("/mf/compare", compareHandler.Compare)
+	wealth.Post("/mf/backtest", backtestHandler.Backtest)
+	wealth.Get("/mf/nfos", nfoHandler.ListOpen)
+	wealth.Post("/mf/nfos/:id/orders", nfoHandler.Apply)
+	wealth.Get("/mf/nfo-orders", nfoHandler.ListOrders)
+	wealth.Delete("/mf/nfo-orders/:id", nfoHandler.CancelOrder)
+	wealth.Post("/watchlist", watchHandler.Add)
+	wealth.Get("/watchlist", watchHandler.List)
+	wealth.Delete("/watchlist/:code", watchHandler.Remove)
 	wealth.Post("/mf/sip/create", wealthHandler.CreateSIP)
 	wealth.Get("/portfolio", wealthHandler.GetPortfolio)
 	wealth.Get("/portfolio/analytics", wealthHandler.GetPortfolioAnalytics)
+	wealth.Get("/portfolio/overlap", wealthHandler.GetPortfolioOverlap)
+	wealth.Get("/portfolio/rebalance", wealthHandler.GetRebalancePlan)
+	wealth.Get("/portfolio/history", valuationHandler.History)
+	wealth.Get("/portfolio/benchmark", benchmarkHandler.ComparePortfolio)
+	wealth.Get("/portfolio/review", reviewHandler.PortfolioReview)
+	wealth.Get("/tax/80c", taxSavingHandler.Tracker)
+	wealth.Put("/tax/80c/declarations", taxSavingHandler.SaveDeclaration)
+	wealth.Get("/statements", statementHandler.Generate)
+	wealth.Get("/insights/latest", insightHandler.Latest)
+	wealth.Post("/family", familyHandler.Create)
+	wealth.Get("/family", familyHandler.Get)
+	wealth.Post("/family/members", familyHandler.Invite)
+	wealth.Delete("/family/members/:memberId", familyHandler.RemoveMember)
+	wealth.Get("/family/members/:memberId/portfolio", familyHandler.MemberPortfolio)
+	wealth.Post("/family/members/:memberId/sips", familyHandler.CreateMemberSIP)
+	wealth.Post("/family/invitations/:id/accept", familyHandler.Accept)
+	wealth.Post("/family/consents", familyHandler.GrantConsent)
+	wealth.Delete("/family/consents/:id", familyHandler.RevokeConsent)
+	wealth.Get("/family/portfolio", familyHandler.Portfolio)
+	wealth.Get("/family/access-log", familyHandler.AccessLog)
+	wealth.Post("/fds", fdHandler.Add)
+	wealth.Get("/fds", fdHandler.List)
+	wealth.Delete("/fds/:id", fdHandler.Remove)
+	wealth.Get("/gold/price", goldHandler.LatestPrice)
+	wealth.Post("/gold", goldHandler.AddHolding)
+	wealth.Get("/gold", goldHandler.ListHoldings)
+	wealth.Delete("/gold/:id", goldHandler.RemoveHolding)
+	wealth.Get("/nps/schemes", npsHandler.ListSchemes)
+	wealth.Post("/nps/accounts", npsHandler.OpenAccount)
+	wealth.Get("/nps/accounts", npsHandler.ListAccounts)
+	wealth.Post("/nps/accounts/:id/contributions", npsHandler.Contribute)
+	wealth.Post("/equities/trades", equityHandler.Trade)
+	wealth.Get("/equities", equityHandler.ListHoldings)
+	wealth.Get("/equities/capital-gains", equityHandler.CapitalGains)
+	wealth.Get("/net-worth", netWorthHandler.Get)
+	wealth.Get("/net-worth/history", netWorthHandler.History)
+	wealth.Post("/net-worth/items", netWorthHandler.AddItem)
+	wealth.Get("/net-worth/items", netWorthHandler.ListItems)
+	wealth.Put("/net-worth/items/:id", netWorthHandler.UpdateItem)
+	wealth.Delete("/net-worth/items/:id", netWorthHandler.RemoveItem)
+	wealth.Get("/transactions", wealthHandler.GetTransactions)
 	wealth.Post("/risk-profile", wealthHandler.AssessRiskProfile)
 	wealth.Get("/risk-profile", wealthHandler.GetRiskProfile)
+	wealth.Get("/recommendations", advisoryHandler.Recommend)
+	wealth.Post("/recommendations/invest", advisoryHandler.Invest)
+	wealth.Post("/goals", goalHandler.Create)
+	wealth.Get("/goals", goalHandler.List)
+	wealth.Get("/goals/:id", goalHandler.Get)
+	wealth.Put("/goals/:id", goalHandler.Update)
+	wealth.Put("/goals/:id/links", goalHandler.Link)
+	wealth.Delete("/goals/:id", goalHandler.Delete)
+	wealth.Post("/calculators/sip", calcHandler.SIP)
+	wealth.Post("/calculators/lumpsum", calcHandler.Lumpsum)
+	wealth.Post("/calculators/required-sip", calcHandler.RequiredSIP)
+	wealth.Post("/calculators/retirement", calcHandler.Retirement)
+	wealth.Post("/calculators/swp", calcHandler.SWP)
+	wealth.Post("/projections/monte-carlo", projectionHandler.MonteCarlo)
+
+	admin := wealth.Group("/admin", handler.RequireAdmin(cfg.AdminAPIKey))
+	admin.Post("/mf/factsheets/import", wealthHandler.ImportFactsheets)
+	admin.Post("/mf/nav/import", navHandler.Import)
+	admin.Post("/mf/idcw", idcwHandler.Declare)
+	admin.Get("/mf/idcw", idcwHandler.List)
+	admin.Post("/mf/idcw/:id/process", idcwHandler.Process)
+	admin.Post("/mf/corporate-actions", actionHandler.Register)
+	admin.Get("/mf/corporate-actions", actionHandler.List)
+	admin.Post("/mf/corporate-actions/:id/process", actionHandler.Process)
+	admin.Post("/gold/prices/import", goldHandler.ImportPrices)
+	admin.Put("/benchmarks/:code", benchmarkHandler.Save)
+	admin.Post("/benchmarks/values/import", benchmarkHandler.ImportValues)
+	admin.Put("/mf/schemes/:code/benchmark", benchmarkHandler.LinkScheme)
+	admin.Post("/nps/nav/import", npsHandler.ImportNAV)
+	admin.Post("/equities/bhavcopy/import", equityHandler.ImportBhavcopy)
+	admin.Post("/equities/corporate-actions", equityHandler.RegisterAction)
+	admin.Get("/equities/corporate-actions", equityHandler.ListActions)
+	admin.Post("/equities/corporate-actions/:id/process", equityHandler.ProcessAction)
+	admin.Post("/net-worth/feed", netWorthHandler.IngestFeed)
+	admin.Post("/investor-profiles/feed", statementHandler.IngestProfiles)
+	admin.Post("/portfolio/revalue", valuationHandler.RevalueAll)
+	admin.Post("/portfolio/review/run", reviewHandler.ReviewAll)
+	admin.Post("/insights/run", insightHandler.GenerateAll)
+	admin.Post("/mf/nfos", nfoHandler.Create)
+	admin.Get("/mf/nfos", nfoHandler.List)
+	admin.Post("/mf/nfos/:id/allot", nfoHandler.Allot)
+	admin.Put("/model-portfolios/:riskCategory", advisoryHandler.SaveModelPortfolio)
+	admin.Get("/model-portfolios", advisoryHandler.ListModelPortfolios)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -74,6 +279,7 @@ func main() {
 	}()
 
 	<-quit
+	stopJobs()
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	_ = app.ShutdownWithContext(ctx)
@@ -1,22 +1,33 @@
 package config
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	Port          string
 	MongoAtlasURI string
 	ServiceName   string
 	LogLevel      string
+	AdminAPIKey   string
+	// ValuationTime is when, after midnight IST, the nightly portfolio
+	// valuation runs; AMFI publishes the day's NAVs by 11 pm.
+	ValuationTime time.Duration
 }
 
 func Load() *Config {
 	viper.AutomaticEnv()
 	viper.SetDefault("PORT", "8080")
 	viper.SetDefault("LOG_LEVEL", "info")
+	viper.SetDefault("VALUATION_TIME", "23h45m")
 	return &Config{
 		Port:          viper.GetString("PORT"),
 		MongoAtlasURI: viper.GetString("MONGODB_ATLAS_URI"),
 		ServiceName:   viper.GetString("SERVICE_NAME"),
 		LogLevel:      viper.GetString("LOG_LEVEL"),
+		AdminAPIKey:   viper.GetString("ADMIN_API_KEY"),
+		ValuationTime: viper.GetDuration("VALUATION_TIME"),
 	}
 }
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequireAdmin guards back-office routes (data imports, batch triggers) with a
+// shared key passed in X-Admin-Key. An empty key disables the routes.
+func RequireAdmin(apiKey string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get("X-Admin-Key")
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) != 1 {
+			return respond(c, fiber.StatusForbidden, nil, "forbidden")
+		}
+		return c.Next()
+	}
+}
+
+// importFormat resolves the payload format of an admin import request.
+func importFormat(c *fiber.Ctx) string {
+	if f := c.Query("format"); f != "" {
+		return strings.ToLower(f)
+	}
+	ct := strings.ToLower(c.Get(fiber.HeaderContentType))
+	switch {
+	case strings.Contains(ct, "json"):
+		return "json"
+	case strings.Contains(ct, "csv"):
+		return "csv"
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type AdvisoryHandler struct{ svc service.AdvisoryService }
+
+func NewAdvisoryHandler(svc service.AdvisoryService) *AdvisoryHandler {
+	return &AdvisoryHandler{svc: svc}
+}
+
+func (h *AdvisoryHandler) SaveModelPortfolio(c *fiber.Ctx) error {
+	var req model.ModelPortfolioRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	mp, err := h.svc.SaveModelPortfolio(c.Context(), c.Params("riskCategory"), &req)
+	if err != nil {
+		return advisoryError(c, err)
+	}
+	return respond(c, fiber.StatusOK, mp, "")
+}
+
+func (h *AdvisoryHandler) ListModelPortfolios(c *fiber.Ctx) error {
+	mps, err := h.svc.ListModelPortfolios(c.Context())
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, mps, "")
+}
+
+func (h *AdvisoryHandler) Recommend(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	rec, err := h.svc.Recommend(c.Context(), userID, c.QueryFloat("budget"))
+	if err != nil {
+		return advisoryError(c, err)
+	}
+	return respond(c, fiber.StatusOK, rec, "")
+}
+
+func (h *AdvisoryHandler) Invest(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.InvestRecommendationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	sips, err := h.svc.InvestRecommendation(c.Context(), userID, &req)
+	if err != nil {
+		return advisoryError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, sips, "")
+}
+
+func advisoryError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrSchemeNotFound), errors.Is(err, service.ErrModelPortfolioNotFound):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, err.Error())
+	case errors.Is(err, service.ErrRiskProfileRequired), errors.Is(err, service.ErrBudgetTooLow):
+		return respond(c, fiber.StatusUnprocessableEntity, nil, err.Error())
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type BacktestHandler struct{ svc service.BacktestService }
+
+func NewBacktestHandler(svc service.BacktestService) *BacktestHandler {
+	return &BacktestHandler{svc: svc}
+}
+
+func (h *BacktestHandler) Backtest(c *fiber.Ctx) error {
+	var req model.BacktestRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	res, err := h.svc.Backtest(c.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSchemeNotFound):
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		case errors.Is(err, service.ErrInvalidRequest):
+			return respond(c, fiber.StatusBadRequest, nil, "scheme_codes must list 1 to 5 schemes; amount and start_date are required; mode must be sip or lumpsum and frequency monthly or weekly")
+		case errors.Is(err, service.ErrInsufficientHistory):
+			return respond(c, fiber.StatusUnprocessableEntity, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type BenchmarkHandler struct{ svc service.BenchmarkService }
+
+func NewBenchmarkHandler(svc service.BenchmarkService) *BenchmarkHandler {
+	return &BenchmarkHandler{svc: svc}
+}
+
+func (h *BenchmarkHandler) Save(c *fiber.Ctx) error {
+	var req model.BenchmarkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	b, err := h.svc.Save(c.Context(), c.Params("code"), &req)
+	if err != nil {
+		return benchmarkError(c, err)
+	}
+	return respond(c, fiber.StatusOK, b, "")
+}
+
+func (h *BenchmarkHandler) List(c *fiber.Ctx) error {
+	list, err := h.svc.List(c.Context())
+	if err != nil {
+		return benchmarkError(c, err)
+	}
+	return respond(c, fiber.StatusOK, list, "")
+}
+
+// ImportValues accepts a JSON array of {code, date, value}.
+func (h *BenchmarkHandler) ImportValues(c *fiber.Ctx) error {
+	res, err := h.svc.ImportValues(c.Context(), c.Body())
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedFormat) {
+			return respond(c, fiber.StatusBadRequest, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
+
+func (h *BenchmarkHandler) LinkScheme(c *fiber.Ctx) error {
+	var req model.BenchmarkLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	if err := h.svc.LinkScheme(c.Context(), c.Params("code"), req.BenchmarkCode); err != nil {
+		return benchmarkError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nil, "")
+}
+
+func (h *BenchmarkHandler) CompareScheme(c *fiber.Ctx) error {
+	cmp, err := h.svc.CompareScheme(c.Context(), c.Params("code"))
+	if err != nil {
+		return benchmarkError(c, err)
+	}
+	return respond(c, fiber.StatusOK, cmp, "")
+}
+
+// ComparePortfolio measures the user's funds against their benchmarks, or
+// against the one given in "benchmark".
+func (h *BenchmarkHandler) ComparePortfolio(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	cmp, err := h.svc.ComparePortfolio(c.Context(), userID, c.Query("benchmark"))
+	if err != nil {
+		return benchmarkError(c, err)
+	}
+	return respond(c, fiber.StatusOK, cmp, "")
+}
+
+func benchmarkError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrSchemeNotFound), errors.Is(err, service.ErrBenchmarkNotFound),
+		errors.Is(err, service.ErrNoSchemeBenchmark):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInsufficientHistory):
+		return respond(c, fiber.StatusUnprocessableEntity, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "code must be 3-32 upper-case letters, digits or underscores, not an NPS code or ISIN, and name is required")
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
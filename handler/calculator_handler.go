@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CalculatorHandler struct{ svc service.CalculatorService }
+
+func NewCalculatorHandler(svc service.CalculatorService) *CalculatorHandler {
+	return &CalculatorHandler{svc: svc}
+}
+
+func (h *CalculatorHandler) SIP(c *fiber.Ctx) error {
+	var req model.SIPCalculatorRequest
+	return calculate(c, &req, func() (interface{}, error) { return h.svc.SIP(&req) })
+}
+
+func (h *CalculatorHandler) Lumpsum(c *fiber.Ctx) error {
+	var req model.LumpsumCalculatorRequest
+	return calculate(c, &req, func() (interface{}, error) { return h.svc.Lumpsum(&req) })
+}
+
+func (h *CalculatorHandler) RequiredSIP(c *fiber.Ctx) error {
+	var req model.RequiredSIPRequest
+	return calculate(c, &req, func() (interface{}, error) { return h.svc.RequiredSIP(&req) })
+}
+
+func (h *CalculatorHandler) Retirement(c *fiber.Ctx) error {
+	var req model.RetirementCalculatorRequest
+	return calculate(c, &req, func() (interface{}, error) { return h.svc.Retirement(&req) })
+}
+
+func (h *CalculatorHandler) SWP(c *fiber.Ctx) error {
+	var req model.SWPCalculatorRequest
+	return calculate(c, &req, func() (interface{}, error) { return h.svc.SWP(&req) })
+}
+
+// calculate parses the body into req and runs fn. Calculators only fail on
+// invalid input.
+func calculate(c *fiber.Ctx, req interface{}, fn func() (interface{}, error)) error {
+	if err := c.BodyParser(req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	res, err := fn()
+	if err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
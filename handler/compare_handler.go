@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CompareHandler struct{ svc service.CompareService }
+
+func NewCompareHandler(svc service.CompareService) *CompareHandler { return &CompareHandler{svc: svc} }
+
+func (h *CompareHandler) Compare(c *fiber.Ctx) error {
+	codes := strings.Split(c.Query("codes"), ",")
+	cmp, err := h.svc.Compare(c.Context(), codes, c.Query("period"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSchemeNotFound):
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		case errors.Is(err, service.ErrInvalidRequest):
+			return respond(c, fiber.StatusBadRequest, nil, "codes must list 2 or 3 schemes; period must be 1M, 3M, 6M, 1Y, 3Y, 5Y or ALL")
+		case errors.Is(err, service.ErrInsufficientHistory):
+			return respond(c, fiber.StatusUnprocessableEntity, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, cmp, "")
+}
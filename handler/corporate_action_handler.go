@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type CorporateActionHandler struct {
+	svc service.CorporateActionService
+}
+
+func NewCorporateActionHandler(svc service.CorporateActionService) *CorporateActionHandler {
+	return &CorporateActionHandler{svc: svc}
+}
+
+func (h *CorporateActionHandler) Register(c *fiber.Ctx) error {
+	var req model.CorporateActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	a, err := h.svc.Register(c.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSchemeNotFound):
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		case errors.Is(err, service.ErrInvalidRequest):
+			return respond(c, fiber.StatusBadRequest, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusCreated, a, "")
+}
+
+func (h *CorporateActionHandler) List(c *fiber.Ctx) error {
+	list, err := h.svc.List(c.Context(), c.Query("status"))
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, list, "")
+}
+
+func (h *CorporateActionHandler) Process(c *fiber.Ctx) error {
+	a, err := h.svc.Process(c.Context(), c.Params("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrActionNotFound), errors.Is(err, service.ErrSchemeNotFound):
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		case errors.Is(err, service.ErrActionProcessed), errors.Is(err, service.ErrActionNotDue):
+			return respond(c, fiber.StatusConflict, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, a, "")
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type EquityHandler struct{ svc service.EquityService }
+
+func NewEquityHandler(svc service.EquityService) *EquityHandler { return &EquityHandler{svc: svc} }
+
+// ImportBhavcopy accepts an exchange's end-of-day bhavcopy CSV as the
+// request body; the "exchange" query parameter is nse or bse.
+func (h *EquityHandler) ImportBhavcopy(c *fiber.Ctx) error {
+	res, err := h.svc.ImportBhavcopy(c.Context(), c.Query("exchange"), c.Body())
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedFormat) {
+			return respond(c, fiber.StatusBadRequest, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
+
+func (h *EquityHandler) Trade(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.EquityTradeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	holding, err := h.svc.Trade(c.Context(), userID, &req)
+	if err != nil {
+		return equityError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, holding, "")
+}
+
+func (h *EquityHandler) ListHoldings(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	holdings, err := h.svc.ListHoldings(c.Context(), userID)
+	if err != nil {
+		return equityError(c, err)
+	}
+	return respond(c, fiber.StatusOK, holdings, "")
+}
+
+// CapitalGains reports the financial year given as "fy" (e.g. 2024-25),
+// defaulting to the current one.
+func (h *EquityHandler) CapitalGains(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	report, err := h.svc.CapitalGains(c.Context(), userID, c.Query("fy"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRequest) {
+			return respond(c, fiber.StatusBadRequest, nil, "fy must look like 2024-25")
+		}
+		return equityError(c, err)
+	}
+	return respond(c, fiber.StatusOK, report, "")
+}
+
+func (h *EquityHandler) RegisterAction(c *fiber.Ctx) error {
+	var req model.EquityActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	a, err := h.svc.RegisterAction(c.Context(), &req)
+	if err != nil {
+		return equityError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, a, "")
+}
+
+func (h *EquityHandler) ListActions(c *fiber.Ctx) error {
+	actions, err := h.svc.ListActions(c.Context(), c.Query("status"))
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, actions, "")
+}
+
+func (h *EquityHandler) ProcessAction(c *fiber.Ctx) error {
+	a, err := h.svc.ProcessAction(c.Context(), c.Params("id"))
+	if err != nil {
+		return equityError(c, err)
+	}
+	return respond(c, fiber.StatusOK, a, "")
+}
+
+func equityError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrSecurityNotFound), errors.Is(err, service.ErrEquityActionNotFound):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "trades need side buy or sell, a whole positive quantity, a positive price and a date not in the future; actions need type split or bonus with positive new_shares and for_shares, a split giving more shares than before")
+	case errors.Is(err, service.ErrInsufficientQuantity):
+		return respond(c, fiber.StatusUnprocessableEntity, nil, err.Error())
+	case errors.Is(err, service.ErrEquityActionProcessed), errors.Is(err, service.ErrEquityActionNotDue):
+		return respond(c, fiber.StatusConflict, nil, err.Error())
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
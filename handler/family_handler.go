@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FamilyHandler struct{ svc service.FamilyService }
+
+func NewFamilyHandler(svc service.FamilyService) *FamilyHandler { return &FamilyHandler{svc: svc} }
+
+func (h *FamilyHandler) Create(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.FamilyGroupRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	g, err := h.svc.Create(c.Context(), userID, &req)
+	if err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, g, "")
+}
+
+func (h *FamilyHandler) Get(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	v, err := h.svc.Get(c.Context(), userID)
+	if err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusOK, v, "")
+}
+
+func (h *FamilyHandler) Invite(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.FamilyInviteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	g, err := h.svc.Invite(c.Context(), userID, &req)
+	if err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusOK, g, "")
+}
+
+func (h *FamilyHandler) Accept(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	g, err := h.svc.Accept(c.Context(), userID, c.Params("id"))
+	if err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusOK, g, "")
+}
+
+// RemoveMember removes a member or invitation; members remove themselves
+// to leave.
+func (h *FamilyHandler) RemoveMember(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	if err := h.svc.RemoveMember(c.Context(), userID, c.Params("memberId")); err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nil, "")
+}
+
+func (h *FamilyHandler) GrantConsent(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.FamilyConsentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	consent, err := h.svc.GrantConsent(c.Context(), userID, &req)
+	if err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, consent, "")
+}
+
+func (h *FamilyHandler) RevokeConsent(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	if err := h.svc.RevokeConsent(c.Context(), userID, c.Params("id")); err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nil, "")
+}
+
+func (h *FamilyHandler) Portfolio(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	fp, err := h.svc.Portfolio(c.Context(), userID)
+	if err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusOK, fp, "")
+}
+
+func (h *FamilyHandler) MemberPortfolio(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	p, err := h.svc.MemberPortfolio(c.Context(), userID, c.Params("memberId"))
+	if err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusOK, p, "")
+}
+
+func (h *FamilyHandler) CreateMemberSIP(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.CreateSIPRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	sip, err := h.svc.CreateMemberSIP(c.Context(), userID, c.Params("memberId"), &req)
+	if err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, sip, "")
+}
+
+func (h *FamilyHandler) AccessLog(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	logs, err := h.svc.AccessLog(c.Context(), userID)
+	if err != nil {
+		return familyError(c, err)
+	}
+	return respond(c, fiber.StatusOK, logs, "")
+}
+
+func familyError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrNotFamilyOwner), errors.Is(err, service.ErrFamilyAccessDenied):
+		return respond(c, fiber.StatusForbidden, nil, err.Error())
+	case errors.Is(err, service.ErrFamilyNotFound), errors.Is(err, service.ErrFamilyMemberNotFound),
+		errors.Is(err, service.ErrConsentNotFound), errors.Is(err, service.ErrSchemeNotFound):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrAlreadyInFamily), errors.Is(err, service.ErrFamilyOwnerLeaving):
+		return respond(c, fiber.StatusConflict, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "groups need a name; invitations need another user's id; consents need another member as grantee, scope view or manage and an expiry within a year")
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type FixedDepositHandler struct{ svc service.FixedDepositService }
+
+func NewFixedDepositHandler(svc service.FixedDepositService) *FixedDepositHandler {
+	return &FixedDepositHandler{svc: svc}
+}
+
+func (h *FixedDepositHandler) Add(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.FixedDepositRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	fd, err := h.svc.Add(c.Context(), userID, &req)
+	if err != nil {
+		return fdError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, fd, "")
+}
+
+func (h *FixedDepositHandler) List(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	fds, err := h.svc.List(c.Context(), userID)
+	if err != nil {
+		return fdError(c, err)
+	}
+	return respond(c, fiber.StatusOK, fds, "")
+}
+
+func (h *FixedDepositHandler) Remove(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	if err := h.svc.Remove(c.Context(), userID, c.Params("id")); err != nil {
+		return fdError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nil, "")
+}
+
+func fdError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrDepositNotFound):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "bank, principal, rate_pct (up to 20) and a maturity_date or tenure_months after start_date are required; compounding must be monthly, quarterly, half_yearly or yearly and payout cumulative, monthly or quarterly")
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
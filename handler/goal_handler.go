@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GoalHandler struct{ svc service.GoalService }
+
+func NewGoalHandler(svc service.GoalService) *GoalHandler { return &GoalHandler{svc: svc} }
+
+func (h *GoalHandler) Create(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.GoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	p, err := h.svc.Create(c.Context(), userID, &req)
+	if err != nil {
+		return goalError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, p, "")
+}
+
+func (h *GoalHandler) List(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	goals, err := h.svc.List(c.Context(), userID)
+	if err != nil {
+		return goalError(c, err)
+	}
+	return respond(c, fiber.StatusOK, goals, "")
+}
+
+func (h *GoalHandler) Get(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	p, err := h.svc.Get(c.Context(), userID, c.Params("id"))
+	if err != nil {
+		return goalError(c, err)
+	}
+	return respond(c, fiber.StatusOK, p, "")
+}
+
+func (h *GoalHandler) Update(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.GoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	p, err := h.svc.Update(c.Context(), userID, c.Params("id"), &req)
+	if err != nil {
+		return goalError(c, err)
+	}
+	return respond(c, fiber.StatusOK, p, "")
+}
+
+func (h *GoalHandler) Link(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.LinkGoalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	p, err := h.svc.Link(c.Context(), userID, c.Params("id"), &req)
+	if err != nil {
+		return goalError(c, err)
+	}
+	return respond(c, fiber.StatusOK, p, "")
+}
+
+func (h *GoalHandler) Delete(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	if err := h.svc.Delete(c.Context(), userID, c.Params("id")); err != nil {
+		return goalError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nil, "")
+}
+
+func goalError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrGoalNotFound):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, err.Error())
+	case errors.Is(err, service.ErrLinkConflict):
+		return respond(c, fiber.StatusConflict, nil, err.Error())
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
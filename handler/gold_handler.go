@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type GoldHandler struct{ svc service.GoldService }
+
+func NewGoldHandler(svc service.GoldService) *GoldHandler { return &GoldHandler{svc: svc} }
+
+// ImportPrices accepts a JSON array of {date, price_per_gram, source}.
+func (h *GoldHandler) ImportPrices(c *fiber.Ctx) error {
+	res, err := h.svc.ImportPrices(c.Context(), c.Body())
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedFormat) {
+			return respond(c, fiber.StatusBadRequest, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
+
+func (h *GoldHandler) LatestPrice(c *fiber.Ctx) error {
+	p, err := h.svc.LatestPrice(c.Context())
+	if err != nil {
+		return goldError(c, err)
+	}
+	return respond(c, fiber.StatusOK, p, "")
+}
+
+func (h *GoldHandler) AddHolding(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.GoldHoldingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	hl, err := h.svc.AddHolding(c.Context(), userID, &req)
+	if err != nil {
+		return goldError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, hl, "")
+}
+
+func (h *GoldHandler) ListHoldings(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	list, err := h.svc.ListHoldings(c.Context(), userID)
+	if err != nil {
+		return goldError(c, err)
+	}
+	return respond(c, fiber.StatusOK, list, "")
+}
+
+func (h *GoldHandler) RemoveHolding(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	if err := h.svc.RemoveHolding(c.Context(), userID, c.Params("id")); err != nil {
+		return goldError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nil, "")
+}
+
+func goldError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrGoldHoldingNotFound), errors.Is(err, service.ErrNoGoldPrice):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "kind must be sgb or digital with positive grams and price_per_gram; SGBs need whole grams and an issue purchase_date")
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type IDCWHandler struct{ svc service.IDCWService }
+
+func NewIDCWHandler(svc service.IDCWService) *IDCWHandler { return &IDCWHandler{svc: svc} }
+
+func (h *IDCWHandler) Declare(c *fiber.Ctx) error {
+	var req model.DeclareIDCWRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	d, err := h.svc.Declare(c.Context(), &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSchemeNotFound):
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		case errors.Is(err, service.ErrInvalidRequest), errors.Is(err, service.ErrNotIDCWScheme):
+			return respond(c, fiber.StatusBadRequest, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusCreated, d, "")
+}
+
+func (h *IDCWHandler) List(c *fiber.Ctx) error {
+	list, err := h.svc.List(c.Context(), c.Query("status"))
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, list, "")
+}
+
+func (h *IDCWHandler) Process(c *fiber.Ctx) error {
+	d, err := h.svc.Process(c.Context(), c.Params("id"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrDeclarationNotFound), errors.Is(err, service.ErrSchemeNotFound):
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		case errors.Is(err, service.ErrDeclarationProcessed), errors.Is(err, service.ErrExNAVRequired),
+			errors.Is(err, service.ErrDeclarationNotDue):
+			return respond(c, fiber.StatusConflict, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, d, "")
+}
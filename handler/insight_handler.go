@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type InsightHandler struct{ svc service.InsightService }
+
+func NewInsightHandler(svc service.InsightService) *InsightHandler { return &InsightHandler{svc: svc} }
+
+// Latest returns the user's most recent monthly digest.
+func (h *InsightHandler) Latest(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	d, err := h.svc.Latest(c.Context(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnauthorized):
+			return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+		case errors.Is(err, service.ErrNoDigest):
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, d, "")
+}
+
+// GenerateAll runs the monthly digest job on demand.
+func (h *InsightHandler) GenerateAll(c *fiber.Ctx) error {
+	res, err := h.svc.GenerateAll(c.Context(), time.Now())
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
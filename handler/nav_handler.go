@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NAVHandler struct{ svc service.NAVService }
+
+func NewNAVHandler(svc service.NAVService) *NAVHandler { return &NAVHandler{svc: svc} }
+
+// Import accepts AMFI's NAVAll.txt (format=amfi) or a JSON array.
+func (h *NAVHandler) Import(c *fiber.Ctx) error {
+	res, err := h.svc.Import(c.Context(), importFormat(c), c.Body())
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedFormat) {
+			return respond(c, fiber.StatusBadRequest, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
+
+func (h *NAVHandler) GetHistory(c *fiber.Ctx) error {
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+	points, err := h.svc.GetHistory(c.Context(), c.Params("code"), from, to)
+	if err != nil {
+		if errors.Is(err, service.ErrSchemeNotFound) {
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, points, "")
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NetWorthHandler struct{ svc service.NetWorthService }
+
+func NewNetWorthHandler(svc service.NetWorthService) *NetWorthHandler {
+	return &NetWorthHandler{svc: svc}
+}
+
+func (h *NetWorthHandler) Get(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	nw, err := h.svc.Get(c.Context(), userID)
+	if err != nil {
+		return netWorthError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nw, "")
+}
+
+// History returns monthly snapshots for the last "months" months (default 12).
+func (h *NetWorthHandler) History(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	snaps, err := h.svc.History(c.Context(), userID, c.QueryInt("months"))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRequest) {
+			return respond(c, fiber.StatusBadRequest, nil, "months must be between 1 and 120")
+		}
+		return netWorthError(c, err)
+	}
+	return respond(c, fiber.StatusOK, snaps, "")
+}
+
+func (h *NetWorthHandler) AddItem(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.NetWorthItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	item, err := h.svc.AddItem(c.Context(), userID, &req)
+	if err != nil {
+		return netWorthError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, item, "")
+}
+
+func (h *NetWorthHandler) ListItems(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	items, err := h.svc.ListItems(c.Context(), userID)
+	if err != nil {
+		return netWorthError(c, err)
+	}
+	return respond(c, fiber.StatusOK, items, "")
+}
+
+func (h *NetWorthHandler) UpdateItem(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.NetWorthItemRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	item, err := h.svc.UpdateItem(c.Context(), userID, c.Params("id"), &req)
+	if err != nil {
+		return netWorthError(c, err)
+	}
+	return respond(c, fiber.StatusOK, item, "")
+}
+
+func (h *NetWorthHandler) RemoveItem(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	if err := h.svc.RemoveItem(c.Context(), userID, c.Params("id")); err != nil {
+		return netWorthError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nil, "")
+}
+
+// IngestFeed accepts a JSON array of balances from upstream systems.
+func (h *NetWorthHandler) IngestFeed(c *fiber.Ctx) error {
+	var records []model.NetWorthFeedRecord
+	if err := c.BodyParser(&records); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	res, err := h.svc.IngestFeed(c.Context(), records)
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
+
+func netWorthError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrNetWorthItemNotFound):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "type must be a known asset or liability type, with a name and a value of zero or more")
+	case errors.Is(err, service.ErrFeedItemReadOnly):
+		return respond(c, fiber.StatusConflict, nil, err.Error())
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NFOHandler struct{ svc service.NFOService }
+
+func NewNFOHandler(svc service.NFOService) *NFOHandler { return &NFOHandler{svc: svc} }
+
+func (h *NFOHandler) Create(c *fiber.Ctx) error {
+	var req model.NFORequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	n, err := h.svc.Create(c.Context(), &req)
+	if err != nil {
+		return nfoError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, n, "")
+}
+
+func (h *NFOHandler) List(c *fiber.Ctx) error {
+	list, err := h.svc.List(c.Context(), c.Query("status"))
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, list, "")
+}
+
+func (h *NFOHandler) ListOpen(c *fiber.Ctx) error {
+	list, err := h.svc.ListOpen(c.Context())
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, list, "")
+}
+
+func (h *NFOHandler) Apply(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.NFOOrderRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	o, err := h.svc.Apply(c.Context(), userID, c.Params("id"), &req)
+	if err != nil {
+		return nfoError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, o, "")
+}
+
+func (h *NFOHandler) ListOrders(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	orders, err := h.svc.ListOrders(c.Context(), userID)
+	if err != nil {
+		return nfoError(c, err)
+	}
+	return respond(c, fiber.StatusOK, orders, "")
+}
+
+func (h *NFOHandler) CancelOrder(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	if err := h.svc.CancelOrder(c.Context(), userID, c.Params("id")); err != nil {
+		return nfoError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nil, "")
+}
+
+func (h *NFOHandler) Allot(c *fiber.Ctx) error {
+	n, err := h.svc.Allot(c.Context(), c.Params("id"))
+	if err != nil {
+		return nfoError(c, err)
+	}
+	return respond(c, fiber.StatusOK, n, "")
+}
+
+func nfoError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrNFONotFound), errors.Is(err, service.ErrNFOOrderNotFound):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, err.Error())
+	case errors.Is(err, service.ErrNFONotOpen), errors.Is(err, service.ErrNFOAllotted),
+		errors.Is(err, service.ErrNFOStillOpen), errors.Is(err, service.ErrSchemeCodeInUse):
+		return respond(c, fiber.StatusConflict, nil, err.Error())
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
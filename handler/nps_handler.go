@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type NPSHandler struct{ svc service.NPSService }
+
+func NewNPSHandler(svc service.NPSService) *NPSHandler { return &NPSHandler{svc: svc} }
+
+// ImportNAV accepts the NPS Trust daily NAV file as the request body.
+func (h *NPSHandler) ImportNAV(c *fiber.Ctx) error {
+	res, err := h.svc.ImportNAV(c.Context(), c.Body())
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
+
+func (h *NPSHandler) ListSchemes(c *fiber.Ctx) error {
+	schemes, err := h.svc.ListSchemes(c.Context())
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, schemes, "")
+}
+
+func (h *NPSHandler) OpenAccount(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.NPSAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	acct, err := h.svc.OpenAccount(c.Context(), userID, &req)
+	if err != nil {
+		return npsError(c, err)
+	}
+	return respond(c, fiber.StatusCreated, acct, "")
+}
+
+func (h *NPSHandler) Contribute(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.NPSContributionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	acct, err := h.svc.Contribute(c.Context(), userID, c.Params("id"), &req)
+	if err != nil {
+		return npsError(c, err)
+	}
+	return respond(c, fiber.StatusOK, acct, "")
+}
+
+func (h *NPSHandler) ListAccounts(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	accts, err := h.svc.ListAccounts(c.Context(), userID)
+	if err != nil {
+		return npsError(c, err)
+	}
+	return respond(c, fiber.StatusOK, accts, "")
+}
+
+func npsError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrNPSAccountNotFound), errors.Is(err, service.ErrSchemeNotFound):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "pran must be 12 digits and tier I or II; allocation must use the manager's E/C/G/A schemes, total 100 and keep E at most 75 and A at most 5; contributions need a reference and must meet the tier minimum")
+	case errors.Is(err, service.ErrNPSAccountExists):
+		return respond(c, fiber.StatusConflict, nil, err.Error())
+	case errors.Is(err, service.ErrNPSNAVUnavailable):
+		return respond(c, fiber.StatusUnprocessableEntity, nil, err.Error())
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ProjectionHandler struct{ svc service.ProjectionService }
+
+func NewProjectionHandler(svc service.ProjectionService) *ProjectionHandler {
+	return &ProjectionHandler{svc: svc}
+}
+
+func (h *ProjectionHandler) MonteCarlo(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.MonteCarloRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	res, err := h.svc.MonteCarlo(c.Context(), userID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnauthorized):
+			return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+		case errors.Is(err, service.ErrGoalNotFound):
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		case errors.Is(err, service.ErrInvalidRequest):
+			return respond(c, fiber.StatusBadRequest, nil, err.Error())
+		case errors.Is(err, service.ErrNothingToProject), errors.Is(err, service.ErrInsufficientHistory):
+			return respond(c, fiber.StatusUnprocessableEntity, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
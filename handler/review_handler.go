@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ReviewHandler struct{ svc service.ReviewService }
+
+func NewReviewHandler(svc service.ReviewService) *ReviewHandler { return &ReviewHandler{svc: svc} }
+
+func (h *ReviewHandler) PortfolioReview(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	rv, err := h.svc.PortfolioReview(c.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUnauthorized) {
+			return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, rv, "")
+}
+
+// ReviewAll runs the periodic scheme review on demand.
+func (h *ReviewHandler) ReviewAll(c *fiber.Ctx) error {
+	res, err := h.svc.ReviewAll(c.Context(), time.Now())
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
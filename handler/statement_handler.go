@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type StatementHandler struct{ svc service.StatementService }
+
+func NewStatementHandler(svc service.StatementService) *StatementHandler {
+	return &StatementHandler{svc: svc}
+}
+
+// Generate downloads a statement. Query parameters: type (transactions or
+// capital_gains), format (pdf or csv), from/to for transactions, fy for
+// capital gains and protect=true for a password-protected PDF.
+func (h *StatementHandler) Generate(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+	req := &model.StatementRequest{
+		Type:    c.Query("type"),
+		Format:  c.Query("format"),
+		From:    from,
+		To:      to,
+		FY:      c.Query("fy"),
+		Protect: c.QueryBool("protect"),
+	}
+	st, err := h.svc.Generate(c.Context(), userID, req)
+	if err != nil {
+		return statementError(c, err)
+	}
+	c.Set(fiber.HeaderContentType, st.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", st.FileName))
+	return c.Send(st.Data)
+}
+
+// IngestProfiles accepts a JSON array of investor PAN and date of birth
+// records from the customer profile system.
+func (h *StatementHandler) IngestProfiles(c *fiber.Ctx) error {
+	var records []model.InvestorProfileRecord
+	if err := c.BodyParser(&records); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	res, err := h.svc.IngestProfiles(c.Context(), records)
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
+
+func statementError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "type must be transactions or capital_gains and format pdf or csv; only PDFs can be protected; to must not be before from and fy must look like 2025-26")
+	case errors.Is(err, service.ErrNoInvestorProfile):
+		return respond(c, fiber.StatusUnprocessableEntity, nil, err.Error())
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type TaxSavingHandler struct{ svc service.TaxSavingService }
+
+func NewTaxSavingHandler(svc service.TaxSavingService) *TaxSavingHandler {
+	return &TaxSavingHandler{svc: svc}
+}
+
+// Tracker reports 80C usage for the financial year given as "fy" (e.g.
+// 2025-26), defaulting to the current one.
+func (h *TaxSavingHandler) Tracker(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	t, err := h.svc.Tracker(c.Context(), userID, c.Query("fy"))
+	if err != nil {
+		return taxSavingError(c, err)
+	}
+	return respond(c, fiber.StatusOK, t, "")
+}
+
+// SaveDeclaration replaces the user's other 80C investments for "fy".
+func (h *TaxSavingHandler) SaveDeclaration(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.TaxSavingDeclarationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	d, err := h.svc.SaveDeclaration(c.Context(), userID, c.Query("fy"), &req)
+	if err != nil {
+		return taxSavingError(c, err)
+	}
+	return respond(c, fiber.StatusOK, d, "")
+}
+
+func taxSavingError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "fy must look like 2025-26; items need a known type and a positive amount")
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
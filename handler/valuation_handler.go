@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"errors"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type ValuationHandler struct{ svc service.ValuationService }
+
+func NewValuationHandler(svc service.ValuationService) *ValuationHandler {
+	return &ValuationHandler{svc: svc}
+}
+
+// History serves the value-over-time chart; "range" is 1M, 6M, 1Y or ALL.
+func (h *ValuationHandler) History(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	hist, err := h.svc.History(c.Context(), userID, c.Query("range"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnauthorized):
+			return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+		case errors.Is(err, service.ErrInvalidRequest):
+			return respond(c, fiber.StatusBadRequest, nil, "range must be 1M, 6M, 1Y or ALL")
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, hist, "")
+}
+
+// RevalueAll runs the nightly valuation on demand, e.g. after a late NAV
+// import.
+func (h *ValuationHandler) RevalueAll(c *fiber.Ctx) error {
+	res, err := h.svc.RevalueAll(c.Context(), time.Now())
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type WatchlistHandler struct{ svc service.WatchlistService }
+
+func NewWatchlistHandler(svc service.WatchlistService) *WatchlistHandler {
+	return &WatchlistHandler{svc: svc}
+}
+
+func (h *WatchlistHandler) Add(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	var req model.WatchlistRequest
+	if err := c.BodyParser(&req); err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, "invalid request body")
+	}
+	item, err := h.svc.Add(c.Context(), userID, &req)
+	if err != nil {
+		return watchlistError(c, err)
+	}
+	return respond(c, fiber.StatusOK, item, "")
+}
+
+func (h *WatchlistHandler) List(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	entries, err := h.svc.List(c.Context(), userID)
+	if err != nil {
+		return watchlistError(c, err)
+	}
+	return respond(c, fiber.StatusOK, entries, "")
+}
+
+func (h *WatchlistHandler) Remove(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	if err := h.svc.Remove(c.Context(), userID, c.Params("code")); err != nil {
+		return watchlistError(c, err)
+	}
+	return respond(c, fiber.StatusOK, nil, "")
+}
+
+func watchlistError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized):
+		return respond(c, fiber.StatusUnauthorized, nil, err.Error())
+	case errors.Is(err, service.ErrSchemeNotFound), errors.Is(err, service.ErrNotWatching):
+		return respond(c, fiber.StatusNotFound, nil, err.Error())
+	case errors.Is(err, service.ErrInvalidRequest):
+		return respond(c, fiber.StatusBadRequest, nil, "alerts.drop_pct must be between 0 and 100")
+	}
+	return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+}
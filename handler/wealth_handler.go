@@ -2,6 +2,7 @@ package handler
 
 import (
 	"errors"
+	"time"
 
 	"github.com/banking-superapp/wealth-service/model"
 	"github.com/banking-superapp/wealth-service/service"
@@ -14,11 +15,46 @@ func NewWealthHandler(svc service.WealthService) *WealthHandler { return &Wealth
 
 func (h *WealthHandler) GetCatalogue(c *fiber.Ctx) error {
 	category := c.Query("category")
-	schemes, err := h.svc.GetCatalogue(c.Context(), category)
+	funds, err := h.svc.GetCatalogue(c.Context(), category, c.Query("plan"), c.Query("option"))
 	if err != nil {
 		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
 	}
-	return respond(c, fiber.StatusOK, schemes, "")
+	return respond(c, fiber.StatusOK, funds, "")
+}
+
+func (h *WealthHandler) GetFund(c *fiber.Ctx) error {
+	fund, err := h.svc.GetFund(c.Context(), c.Params("fundCode"))
+	if err != nil {
+		if errors.Is(err, service.ErrFundNotFound) {
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, fund, "")
+}
+
+func (h *WealthHandler) GetSchemeDetail(c *fiber.Ctx) error {
+	detail, err := h.svc.GetSchemeDetail(c.Context(), c.Params("code"))
+	if err != nil {
+		if errors.Is(err, service.ErrSchemeNotFound) {
+			return respond(c, fiber.StatusNotFound, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, detail, "")
+}
+
+// ImportFactsheets accepts a CSV or JSON body. The format comes from the
+// "format" query parameter, falling back to the request Content-Type.
+func (h *WealthHandler) ImportFactsheets(c *fiber.Ctx) error {
+	res, err := h.svc.ImportFactsheets(c.Context(), importFormat(c), c.Body())
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedFormat) {
+			return respond(c, fiber.StatusBadRequest, nil, err.Error())
+		}
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, res, "")
 }
 
 func (h *WealthHandler) CreateSIP(c *fiber.Ctx) error {
@@ -55,6 +91,37 @@ func (h *WealthHandler) GetPortfolioAnalytics(c *fiber.Ctx) error {
 	return respond(c, fiber.StatusOK, analytics, "")
 }
 
+func (h *WealthHandler) GetPortfolioOverlap(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	overlap, err := h.svc.GetPortfolioOverlap(c.Context(), userID)
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, overlap, "")
+}
+
+func (h *WealthHandler) GetRebalancePlan(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	plan, err := h.svc.GetRebalancePlan(c.Context(), userID)
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, plan, "")
+}
+
+func (h *WealthHandler) GetTransactions(c *fiber.Ctx) error {
+	userID := c.Get("X-User-ID")
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return respond(c, fiber.StatusBadRequest, nil, err.Error())
+	}
+	txns, err := h.svc.GetTransactions(c.Context(), userID, from, to)
+	if err != nil {
+		return respond(c, fiber.StatusInternalServerError, nil, err.Error())
+	}
+	return respond(c, fiber.StatusOK, txns, "")
+}
+
 func (h *WealthHandler) AssessRiskProfile(c *fiber.Ctx) error {
 	userID := c.Get("X-User-ID")
 	var req model.RiskProfileRequest
@@ -77,6 +144,23 @@ func (h *WealthHandler) GetRiskProfile(c *fiber.Ctx) error {
 	return respond(c, fiber.StatusOK, rp, "")
 }
 
+// parseDateRange reads optional from/to (YYYY-MM-DD) query parameters; "to"
+// is inclusive of the whole day.
+func parseDateRange(c *fiber.Ctx) (from, to time.Time, err error) {
+	if v := c.Query("from"); v != "" {
+		if from, err = time.Parse("2006-01-02", v); err != nil {
+			return from, to, errors.New("invalid from date")
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if to, err = time.Parse("2006-01-02", v); err != nil {
+			return from, to, errors.New("invalid to date")
+		}
+		to = to.Add(24*time.Hour - time.Nanosecond)
+	}
+	return from, to, nil
+}
+
 func respond(c *fiber.Ctx, status int, data interface{}, errMsg string) error {
 	if errMsg != "" {
 		return c.Status(status).JSON(fiber.Map{"success": false, "error": errMsg})
@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+// BacktestRequest replays a SIP or lumpsum against stored NAV history. Each
+// scheme is simulated independently with the same parameters.
+type BacktestRequest struct {
+	SchemeCodes []string  `json:"scheme_codes"`
+	Mode        string    `json:"mode"` // sip | lumpsum
+	Amount      float64   `json:"amount"`
+	Frequency   string    `json:"frequency"` // monthly | weekly
+	StepUpPct   float64   `json:"step_up_pct"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"` // defaults to the latest NAV
+}
+
+type BacktestResult struct {
+	SchemeCode        string               `json:"scheme_code"`
+	SchemeName        string               `json:"scheme_name"`
+	From              time.Time            `json:"from"`
+	To                time.Time            `json:"to"`
+	TotalInvested     float64              `json:"total_invested"`
+	TotalUnits        float64              `json:"total_units"`
+	FinalNAV          float64              `json:"final_nav"`
+	FinalValue        float64              `json:"final_value"`
+	Gains             float64              `json:"gains"`
+	AbsoluteReturnPct float64              `json:"absolute_return_pct"`
+	XIRR              *float64             `json:"xirr"`
+	MaxDrawdown       Drawdown             `json:"max_drawdown"`
+	WorstLoss         UnrealisedLoss       `json:"worst_loss"`
+	Instalments       []BacktestInstalment `json:"instalments"`
+}
+
+// BacktestInstalment is one purchase. ExecutedDate differs from
+// ScheduledDate when the scheduled day had no NAV (weekend or holiday).
+type BacktestInstalment struct {
+	ScheduledDate time.Time `json:"scheduled_date"`
+	ExecutedDate  time.Time `json:"executed_date"`
+	Amount        float64   `json:"amount"`
+	NAV           float64   `json:"nav"`
+	Units         float64   `json:"units"`
+	TotalUnits    float64   `json:"total_units"`
+	TotalInvested float64   `json:"total_invested"`
+	Value         float64   `json:"value"`
+}
+
+// Drawdown is the largest NAV fall while invested. RecoveredDate is nil if
+// the NAV had not regained its peak by the end of the period.
+type Drawdown struct {
+	Pct           float64    `json:"pct"`
+	PeakDate      time.Time  `json:"peak_date"`
+	TroughDate    time.Time  `json:"trough_date"`
+	RecoveredDate *time.Time `json:"recovered_date"`
+}
+
+// UnrealisedLoss is the point at which the investment was furthest below the
+// amount invested so far.
+type UnrealisedLoss struct {
+	Amount float64   `json:"amount"`
+	Pct    float64   `json:"pct"`
+	Date   time.Time `json:"date"`
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Benchmark is a market index schemes are measured against, such as the
+// Nifty 50 TRI. Its daily values share nav_history under Code.
+type Benchmark struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"-"`
+	Code       string        `bson:"code" json:"code"`
+	Name       string        `bson:"name" json:"name"`
+	Aliases    []string      `bson:"aliases,omitempty" json:"aliases,omitempty"` // other names factsheets use
+	AssetClass string        `bson:"asset_class" json:"asset_class"`
+	Value      float64       `bson:"value" json:"value"`
+	ValueDate  time.Time     `bson:"value_date" json:"value_date"`
+}
+
+type BenchmarkRequest struct {
+	Name       string   `json:"name"`
+	Aliases    []string `json:"aliases"`
+	AssetClass string   `json:"asset_class"`
+}
+
+type BenchmarkImportResult struct {
+	Points     int      `json:"points"`
+	Benchmarks int      `json:"benchmarks_updated"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+type BenchmarkLinkRequest struct {
+	BenchmarkCode string `json:"benchmark_code"`
+}
+
+// SchemeBenchmarkComparison sets a scheme's point-to-point returns against
+// its benchmark's over trailing periods ending on the last date both have.
+type SchemeBenchmarkComparison struct {
+	SchemeCode    string            `json:"scheme_code"`
+	SchemeName    string            `json:"scheme_name"`
+	BenchmarkCode string            `json:"benchmark_code"`
+	BenchmarkName string            `json:"benchmark_name"`
+	AsOf          time.Time         `json:"as_of"`
+	Periods       []BenchmarkPeriod `json:"periods"`
+}
+
+// BenchmarkPeriod returns are CAGR, in percent; they are omitted when
+// either series does not cover the period.
+type BenchmarkPeriod struct {
+	Period          string   `json:"period"`
+	SchemeReturn    *float64 `json:"scheme_return,omitempty"`
+	BenchmarkReturn *float64 `json:"benchmark_return,omitempty"`
+	ExcessReturn    *float64 `json:"excess_return,omitempty"`
+}
+
+// PortfolioBenchmarkComparison replays the funds' cash flows into
+// each fund's benchmark (or one chosen benchmark): the direct-equivalent
+// method. Comparing the two XIRRs shows whether the funds beat simply
+// buying the index with the same money on the same days.
+type PortfolioBenchmarkComparison struct {
+	AsOf           time.Time                `json:"as_of"`
+	Invested       float64                  `json:"invested"`
+	CurrentValue   float64                  `json:"current_value"`
+	XIRR           *float64                 `json:"xirr"`
+	BenchmarkValue float64                  `json:"benchmark_value"`
+	BenchmarkXIRR  *float64                 `json:"benchmark_xirr"`
+	Schemes        []SchemeBenchmarkOutcome `json:"schemes"`
+	Excluded       []string                 `json:"excluded,omitempty"`
+	Note           string                   `json:"note,omitempty"`
+}
+
+type SchemeBenchmarkOutcome struct {
+	SchemeCode     string   `json:"scheme_code"`
+	SchemeName     string   `json:"scheme_name"`
+	BenchmarkCode  string   `json:"benchmark_code"`
+	Invested       float64  `json:"invested"`
+	CurrentValue   float64  `json:"current_value"`
+	XIRR           *float64 `json:"xirr"`
+	BenchmarkValue float64  `json:"benchmark_value"`
+	BenchmarkXIRR  *float64 `json:"benchmark_xirr"`
+}
@@ -0,0 +1,44 @@
+package model
+
+// Calculator requests. Rates are annual percentages; StepUpPct raises the SIP
+// (or withdrawal) once a year.
+
+type SIPCalculatorRequest struct {
+	MonthlyAmount float64 `json:"monthly_amount"`
+	ReturnPct     float64 `json:"return_pct"`
+	Years         int     `json:"years"`
+	StepUpPct     float64 `json:"step_up_pct"`
+}
+
+type LumpsumCalculatorRequest struct {
+	Amount    float64 `json:"amount"`
+	ReturnPct float64 `json:"return_pct"`
+	Years     int     `json:"years"`
+}
+
+type RequiredSIPRequest struct {
+	TargetAmount float64 `json:"target_amount"`
+	ReturnPct    float64 `json:"return_pct"`
+	Years        int     `json:"years"`
+	StepUpPct    float64 `json:"step_up_pct"`
+}
+
+type RetirementCalculatorRequest struct {
+	CurrentAge      int     `json:"current_age"`
+	RetirementAge   int     `json:"retirement_age"`
+	LifeExpectancy  int     `json:"life_expectancy"`
+	MonthlyExpenses float64 `json:"monthly_expenses"`
+	InflationPct    float64 `json:"inflation_pct"`
+	PreReturnPct    float64 `json:"pre_retirement_return_pct"`
+	PostReturnPct   float64 `json:"post_retirement_return_pct"`
+	CurrentSavings  float64 `json:"current_savings"`
+	StepUpPct       float64 `json:"step_up_pct"`
+}
+
+type SWPCalculatorRequest struct {
+	Corpus            float64 `json:"corpus"`
+	MonthlyWithdrawal float64 `json:"monthly_withdrawal"`
+	ReturnPct         float64 `json:"return_pct"`
+	StepUpPct         float64 `json:"step_up_pct"`
+	MaxYears          int     `json:"max_years"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// CorporateAction is an AMC-driven change to a scheme's identity. Mergers and
+// code changes move holdings, SIPs and ledger history from FromCode to the
+// surviving ToCode; renames only change the display name.
+type CorporateAction struct {
+	ID            bson.ObjectID           `bson:"_id,omitempty" json:"id"`
+	Type          string                  `bson:"type" json:"type"` // merger | code_change | rename
+	FromCode      string                  `bson:"from_code" json:"from_code"`
+	ToCode        string                  `bson:"to_code" json:"to_code"`
+	NewName       string                  `bson:"new_name,omitempty" json:"new_name,omitempty"`
+	Ratio         float64                 `bson:"ratio" json:"ratio"` // surviving units per unit of FromCode
+	EffectiveDate time.Time               `bson:"effective_date" json:"effective_date"`
+	Status        string                  `bson:"status" json:"status"` // pending | processed
+	Summary       *CorporateActionSummary `bson:"summary,omitempty" json:"summary,omitempty"`
+	CreatedAt     time.Time               `bson:"created_at" json:"created_at"`
+	ProcessedAt   *time.Time              `bson:"processed_at,omitempty" json:"processed_at,omitempty"`
+}
+
+type CorporateActionSummary struct {
+	Holdings     int   `bson:"holdings" json:"holdings"`
+	SIPs         int64 `bson:"sips" json:"sips"`
+	Transactions int64 `bson:"transactions" json:"transactions"`
+	GoalLinks    int64 `bson:"goal_links" json:"goal_links"`
+	Watchlist    int64 `bson:"watchlist" json:"watchlist"`
+}
+
+const (
+	ActionMerger     = "merger"
+	ActionCodeChange = "code_change"
+	ActionRename     = "rename"
+)
+
+// CorporateActionRequest registers an action. For mergers, Ratio may be left
+// zero and supplied as the two NAVs on the effective date instead.
+type CorporateActionRequest struct {
+	Type          string    `json:"type"`
+	FromCode      string    `json:"from_code"`
+	ToCode        string    `json:"to_code"`
+	NewName       string    `json:"new_name"`
+	Ratio         float64   `json:"ratio"`
+	FromNAV       float64   `json:"from_nav"`
+	ToNAV         float64   `json:"to_nav"`
+	EffectiveDate time.Time `json:"effective_date"`
+}
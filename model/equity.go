@@ -0,0 +1,132 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Security is a listed share or ETF, keyed by ISIN and priced from the
+// exchanges' end-of-day bhavcopy. Closing prices also go to nav_history
+// under the ISIN, which cannot clash with AMFI or NPS scheme codes.
+type Security struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" json:"-"`
+	ISIN      string        `bson:"isin" json:"isin"`
+	NSESymbol string        `bson:"nse_symbol,omitempty" json:"nse_symbol,omitempty"`
+	BSECode   string        `bson:"bse_code,omitempty" json:"bse_code,omitempty"`
+	Name      string        `bson:"name" json:"name"`
+	Kind      string        `bson:"kind" json:"kind"`         // stock | etf
+	Category  string        `bson:"category" json:"category"` // allocation class
+	Close     float64       `bson:"close" json:"close"`
+	PrevClose float64       `bson:"prev_close" json:"prev_close"`
+	PriceDate time.Time     `bson:"price_date" json:"price_date"`
+	Exchange  string        `bson:"exchange" json:"exchange"` // source of the close: NSE | BSE
+}
+
+const (
+	SecurityStock = "stock"
+	SecurityETF   = "etf"
+)
+
+type BhavcopyImportResult struct {
+	Exchange           string   `json:"exchange"`
+	Securities         int      `json:"securities"`
+	Skipped            int      `json:"skipped"`
+	PortfoliosRevalued int      `json:"portfolios_revalued"`
+	Errors             []string `json:"errors,omitempty"`
+}
+
+// Equity is the detail of an equity holding: one ISIN, with its quantity
+// held and average cost per share (charges included).
+type Equity struct {
+	ISIN      string    `bson:"isin" json:"isin"`
+	Symbol    string    `bson:"symbol" json:"symbol"`
+	Kind      string    `bson:"kind" json:"kind"`
+	Quantity  float64   `bson:"quantity" json:"quantity"`
+	AvgCost   float64   `bson:"avg_cost" json:"avg_cost"`
+	PriceDate time.Time `bson:"price_date" json:"price_date"` // date of the close used for valuation
+}
+
+type EquityTradeRequest struct {
+	ISIN     string    `json:"isin"`
+	Symbol   string    `json:"symbol"` // NSE symbol or BSE code when ISIN is omitted
+	Side     string    `json:"side"`   // buy | sell
+	Quantity float64   `json:"quantity"`
+	Price    float64   `json:"price"`
+	Charges  float64   `json:"charges"` // brokerage, STT, stamp duty and other levies
+	Date     time.Time `json:"date"`
+}
+
+// EquityAction is a split or bonus issue. Holders as of the day before
+// ExDate receive NewShares for every ForShares held: a split replaces each
+// block with NewShares, a bonus adds NewShares to it.
+type EquityAction struct {
+	ID          bson.ObjectID        `bson:"_id,omitempty" json:"id"`
+	ISIN        string               `bson:"isin" json:"isin"`
+	Name        string               `bson:"name" json:"name"`
+	Type        string               `bson:"type" json:"type"` // split | bonus
+	NewShares   float64              `bson:"new_shares" json:"new_shares"`
+	ForShares   float64              `bson:"for_shares" json:"for_shares"`
+	ExDate      time.Time            `bson:"ex_date" json:"ex_date"`
+	Status      string               `bson:"status" json:"status"` // pending | processed
+	Summary     *EquityActionSummary `bson:"summary,omitempty" json:"summary,omitempty"`
+	CreatedAt   time.Time            `bson:"created_at" json:"created_at"`
+	ProcessedAt *time.Time           `bson:"processed_at,omitempty" json:"processed_at,omitempty"`
+}
+
+type EquityActionSummary struct {
+	Holdings    int     `bson:"holdings" json:"holdings"`
+	SharesAdded float64 `bson:"shares_added" json:"shares_added"`
+}
+
+const (
+	EquitySplit = "split"
+	EquityBonus = "bonus"
+)
+
+type EquityActionRequest struct {
+	ISIN      string    `json:"isin"`
+	Type      string    `json:"type"`
+	NewShares float64   `json:"new_shares"`
+	ForShares float64   `json:"for_shares"`
+	ExDate    time.Time `json:"ex_date"`
+}
+
+// CapitalGain is one sale matched against one purchase lot, first in first
+// out.
+type CapitalGain struct {
+	ISIN        string    `json:"isin"`
+	Name        string    `json:"name"`
+	Quantity    float64   `json:"quantity"`
+	BuyDate     time.Time `json:"buy_date"`
+	SellDate    time.Time `json:"sell_date"`
+	Cost        float64   `json:"cost"`
+	Proceeds    float64   `json:"proceeds"`
+	Gain        float64   `json:"gain"`
+	Term        string    `json:"term"` // short | long
+	HoldingDays int       `json:"holding_days"`
+}
+
+// CapitalGainsReport covers one financial year's equity sales. Taxable
+// amounts are after loss set-off and the LTCG exemption; EstimatedTax
+// excludes surcharge and cess.
+type CapitalGainsReport struct {
+	FinancialYear    string        `json:"financial_year"` // e.g. 2025-26
+	From             time.Time     `json:"from"`
+	To               time.Time     `json:"to"`
+	Entries          []CapitalGain `json:"entries"`
+	ShortTermGain    float64       `json:"short_term_gain"`
+	LongTermGain     float64       `json:"long_term_gain"`
+	LTCGExemption    float64       `json:"ltcg_exemption"`
+	TaxableShortTerm float64       `json:"taxable_short_term"`
+	TaxableLongTerm  float64       `json:"taxable_long_term"`
+	ShortTermLossCF  float64       `json:"short_term_loss_carried_forward"`
+	LongTermLossCF   float64       `json:"long_term_loss_carried_forward"`
+	EstimatedTax     float64       `json:"estimated_tax"`
+	UnmatchedSales   []string      `json:"unmatched_sales,omitempty"`
+}
+
+const (
+	TermShort = "short"
+	TermLong  = "long"
+)
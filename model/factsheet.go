@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Factsheet is the monthly AMC disclosure for a scheme. One document is kept
+// per scheme per month; the latest one backs the scheme detail endpoint.
+type Factsheet struct {
+	ID               bson.ObjectID      `bson:"_id,omitempty" json:"id"`
+	SchemeCode       string             `bson:"scheme_code" json:"scheme_code"`
+	AsOf             time.Time          `bson:"as_of" json:"as_of"`
+	ExpenseRatio     float64            `bson:"expense_ratio" json:"expense_ratio"` // % p.a.
+	AUM              float64            `bson:"aum" json:"aum"`                     // ₹ crore
+	FundManagers     []string           `bson:"fund_managers" json:"fund_managers"`
+	Benchmark        string             `bson:"benchmark" json:"benchmark"`
+	InceptionDate    time.Time          `bson:"inception_date" json:"inception_date"`
+	TopHoldings      []FactsheetHolding `bson:"top_holdings" json:"top_holdings"`
+	SectorAllocation map[string]float64 `bson:"sector_allocation" json:"sector_allocation"` // sector -> % of AUM
+	ImportedAt       time.Time          `bson:"imported_at" json:"imported_at"`
+}
+
+type FactsheetHolding struct {
+	ISIN   string  `bson:"isin" json:"isin"`
+	Name   string  `bson:"name" json:"name"`
+	Sector string  `bson:"sector" json:"sector"`
+	Weight float64 `bson:"weight" json:"weight"` // % of AUM
+}
+
+type SchemeDetail struct {
+	Scheme    *MFScheme  `json:"scheme"`
+	Factsheet *Factsheet `json:"factsheet"`
+}
+
+type FactsheetImportResult struct {
+	Imported int      `json:"imported"`
+	Failed   int      `json:"failed"`
+	Errors   []string `json:"errors,omitempty"`
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// FamilyGroup is a household. Belonging to a group shares nothing by itself:
+// each member decides who may see or manage their wealth data through
+// FamilyConsent grants. A user belongs to at most one group.
+type FamilyGroup struct {
+	ID        bson.ObjectID  `bson:"_id,omitempty" json:"id"`
+	Name      string         `bson:"name" json:"name"`
+	OwnerID   bson.ObjectID  `bson:"owner_id" json:"owner_id"`
+	Members   []FamilyMember `bson:"members" json:"members"`
+	CreatedAt time.Time      `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time      `bson:"updated_at" json:"updated_at"`
+}
+
+// FamilyMember is a user invited to or in a group. Invitations take effect
+// when the invitee accepts.
+type FamilyMember struct {
+	UserID    bson.ObjectID `bson:"user_id" json:"user_id"`
+	Name      string        `bson:"name" json:"name"`     // display name given by the owner
+	Status    string        `bson:"status" json:"status"` // invited | active
+	InvitedAt time.Time     `bson:"invited_at" json:"invited_at"`
+	JoinedAt  *time.Time    `bson:"joined_at,omitempty" json:"joined_at,omitempty"`
+}
+
+const (
+	FamilyMemberInvited = "invited"
+	FamilyMemberActive  = "active"
+)
+
+// FamilyMembership records the one group a user has joined. Its unique
+// user_id index is what stops a user joining two groups at once.
+type FamilyMembership struct {
+	ID       bson.ObjectID `bson:"_id,omitempty" json:"-"`
+	UserID   bson.ObjectID `bson:"user_id" json:"user_id"`
+	GroupID  bson.ObjectID `bson:"group_id" json:"group_id"`
+	JoinedAt time.Time     `bson:"joined_at" json:"joined_at"`
+}
+
+// FamilyConsent lets Grantee access Grantor's wealth data until ExpiresAt
+// or until revoked. Manage access also allows acting on the grantor's
+// behalf, such as starting a SIP, and includes view access.
+type FamilyConsent struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	GroupID   bson.ObjectID `bson:"group_id" json:"group_id"`
+	GrantorID bson.ObjectID `bson:"grantor_id" json:"grantor_id"`
+	GranteeID bson.ObjectID `bson:"grantee_id" json:"grantee_id"`
+	Scope     string        `bson:"scope" json:"scope"` // view | manage
+	GrantedAt time.Time     `bson:"granted_at" json:"granted_at"`
+	ExpiresAt time.Time     `bson:"expires_at" json:"expires_at"`
+	RevokedAt *time.Time    `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
+
+const (
+	FamilyScopeView   = "view"
+	FamilyScopeManage = "manage"
+)
+
+// FamilyAccessLog records one attempt by a family member to read or act on
+// another member's data, whether or not it was allowed.
+type FamilyAccessLog struct {
+	ID        bson.ObjectID  `bson:"_id,omitempty" json:"id"`
+	GroupID   bson.ObjectID  `bson:"group_id,omitempty" json:"group_id,omitempty"`
+	ActorID   bson.ObjectID  `bson:"actor_id" json:"actor_id"`
+	SubjectID bson.ObjectID  `bson:"subject_id" json:"subject_id"`
+	Action    string         `bson:"action" json:"action"` // e.g. portfolio.view, sip.create
+	Scope     string         `bson:"scope" json:"scope"`   // scope the action needs
+	Allowed   bool           `bson:"allowed" json:"allowed"`
+	ConsentID *bson.ObjectID `bson:"consent_id,omitempty" json:"consent_id,omitempty"`
+	At        time.Time      `bson:"at" json:"at"`
+}
+
+type FamilyGroupRequest struct {
+	Name string `json:"name"`
+}
+
+type FamilyInviteRequest struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+type FamilyConsentRequest struct {
+	GranteeID string    `json:"grantee_id"`
+	Scope     string    `json:"scope"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// FamilyView is the caller's group with the consents given and received
+// within it.
+type FamilyView struct {
+	Group            FamilyGroup     `json:"group"`
+	ConsentsGiven    []FamilyConsent `json:"consents_given"`
+	ConsentsReceived []FamilyConsent `json:"consents_received"`
+}
+
+// FamilyPortfolio combines the caller's portfolio with those of the members
+// who currently let the caller view theirs. Members without such consent
+// are listed in Excluded and contribute nothing.
+type FamilyPortfolio struct {
+	GroupID   bson.ObjectID       `json:"group_id"`
+	Members   []FamilyMemberValue `json:"members"`
+	Holdings  []FamilyHolding     `json:"holdings"`
+	Analytics PortfolioAnalytics  `json:"analytics"`
+	Goals     []FamilyGoal        `json:"goals"`
+	Excluded  []bson.ObjectID     `json:"excluded,omitempty"`
+}
+
+type FamilyMemberValue struct {
+	UserID        bson.ObjectID `json:"user_id"`
+	Name          string        `json:"name"`
+	Self          bool          `json:"self"`
+	Scope         string        `json:"scope,omitempty"`
+	ExpiresAt     *time.Time    `json:"consent_expires_at,omitempty"`
+	CurrentValue  float64       `json:"current_value"`
+	TotalInvested float64       `json:"total_invested"`
+	GainLoss      float64       `json:"gain_loss"`
+}
+
+// FamilyHolding is one fund or security summed across members; other
+// assets appear once per member holding.
+type FamilyHolding struct {
+	AssetType     string          `json:"asset_type"`
+	Code          string          `json:"code"` // scheme code, ISIN or asset ID
+	Name          string          `json:"name"`
+	Units         float64         `json:"units"`
+	CurrentValue  float64         `json:"current_value"`
+	InvestedValue float64         `json:"invested_value"`
+	GainLoss      float64         `json:"gain_loss"`
+	HeldBy        []bson.ObjectID `json:"held_by"`
+}
+
+type FamilyGoal struct {
+	UserID bson.ObjectID `json:"user_id"`
+	GoalProgress
+}
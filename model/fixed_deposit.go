@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// FixedDeposit is the detail of a fixed_deposit holding. RatePct is the
+// nominal annual rate the bank quotes, compounded per Compounding.
+type FixedDeposit struct {
+	ID             bson.ObjectID `bson:"id" json:"id"`
+	Bank           string        `bson:"bank" json:"bank"`
+	AccountNumber  string        `bson:"account_number" json:"account_number"` // masked
+	Principal      float64       `bson:"principal" json:"principal"`
+	RatePct        float64       `bson:"rate_pct" json:"rate_pct"`
+	Compounding    string        `bson:"compounding" json:"compounding"` // monthly | quarterly | half_yearly | yearly
+	Payout         string        `bson:"payout" json:"payout"`           // cumulative | monthly | quarterly
+	StartDate      time.Time     `bson:"start_date" json:"start_date"`
+	MaturityDate   time.Time     `bson:"maturity_date" json:"maturity_date"`
+	MaturityAmount float64       `bson:"maturity_amount" json:"maturity_amount"` // principal plus interest not paid out before maturity
+	InterestPaid   float64       `bson:"interest_paid" json:"interest_paid"`     // paid out to date, for payout deposits
+	Status         string        `bson:"status" json:"status"`                   // active | matured
+	ReminderSent   bool          `bson:"reminder_sent" json:"-"`
+}
+
+const (
+	FDPayoutCumulative = "cumulative"
+
+	FDActive  = "active"
+	FDMatured = "matured"
+)
+
+type FixedDepositRequest struct {
+	Bank          string    `json:"bank"`
+	AccountNumber string    `json:"account_number"`
+	Principal     float64   `json:"principal"`
+	RatePct       float64   `json:"rate_pct"`
+	Compounding   string    `json:"compounding"` // defaults to quarterly
+	Payout        string    `json:"payout"`      // defaults to cumulative
+	StartDate     time.Time `json:"start_date"`
+	MaturityDate  time.Time `json:"maturity_date"`
+	TenureMonths  int       `json:"tenure_months"` // used when maturity_date is not given
+}
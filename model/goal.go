@@ -0,0 +1,57 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Goal is something a user is saving for. TargetAmount is in today's money
+// and is inflated to the target date when measuring progress.
+type Goal struct {
+	ID             bson.ObjectID     `bson:"_id,omitempty" json:"id"`
+	UserID         bson.ObjectID     `bson:"user_id" json:"user_id"`
+	Name           string            `bson:"name" json:"name"`
+	TargetAmount   float64           `bson:"target_amount" json:"target_amount"`
+	TargetDate     time.Time         `bson:"target_date" json:"target_date"`
+	InflationPct   float64           `bson:"inflation_pct" json:"inflation_pct"`
+	Priority       string            `bson:"priority" json:"priority"` // high | medium | low
+	LinkedSIPs     []bson.ObjectID   `bson:"linked_sips" json:"linked_sips"`
+	LinkedHoldings []GoalHoldingLink `bson:"linked_holdings" json:"linked_holdings"`
+	CreatedAt      time.Time         `bson:"created_at" json:"created_at"`
+	UpdatedAt      time.Time         `bson:"updated_at" json:"updated_at"`
+}
+
+// GoalHoldingLink earmarks a share of an existing holding for a goal.
+type GoalHoldingLink struct {
+	SchemeCode    string  `bson:"scheme_code" json:"scheme_code"`
+	AllocationPct float64 `bson:"allocation_pct" json:"allocation_pct"`
+}
+
+type GoalProgress struct {
+	Goal                 Goal    `json:"goal"`
+	MonthsRemaining      int     `json:"months_remaining"`
+	InflatedTarget       float64 `json:"inflated_target"`
+	CurrentValue         float64 `json:"current_value"`
+	MonthlySIP           float64 `json:"monthly_sip"`
+	ExpectedReturn       float64 `json:"expected_return"` // % p.a. on linked investments
+	ProjectedCorpus      float64 `json:"projected_corpus"`
+	Gap                  float64 `json:"gap"`
+	ProgressPct          float64 `json:"progress_pct"`
+	OnTrack              bool    `json:"on_track"`
+	AdditionalMonthlySIP float64 `json:"additional_monthly_sip"`
+	SuggestedReturn      float64 `json:"suggested_return"` // % p.a. assumed for the additional SIP
+}
+
+type GoalRequest struct {
+	Name         string    `json:"name"`
+	TargetAmount float64   `json:"target_amount"`
+	TargetDate   time.Time `json:"target_date"`
+	InflationPct *float64  `json:"inflation_pct"`
+	Priority     string    `json:"priority"`
+}
+
+type LinkGoalRequest struct {
+	SIPIDs   []string          `json:"sip_ids"`
+	Holdings []GoalHoldingLink `json:"holdings"`
+}
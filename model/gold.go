@@ -0,0 +1,77 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// GoldPrice is the day's 24K (999) gold price per gram.
+type GoldPrice struct {
+	ID           bson.ObjectID `bson:"_id,omitempty" json:"-"`
+	Date         time.Time     `bson:"date" json:"date"`
+	PricePerGram float64       `bson:"price_per_gram" json:"price_per_gram"`
+	Source       string        `bson:"source" json:"source"`
+}
+
+type GoldPriceImportResult struct {
+	Prices             int        `json:"prices"`
+	Latest             *GoldPrice `json:"latest,omitempty"`
+	PortfoliosRevalued int        `json:"portfolios_revalued"`
+	PortfoliosFailed   int        `json:"portfolios_failed"`
+	Errors             []string   `json:"errors,omitempty"`
+}
+
+// Gold is the detail of a gold holding. SGB tranches pay InterestRatePct a
+// year on the issue value in two instalments, mature eight years after
+// issue and may be redeemed early on an interest date from year five.
+type Gold struct {
+	ID              bson.ObjectID `bson:"id" json:"id"`
+	Kind            string        `bson:"kind" json:"kind"` // sgb | digital
+	Grams           float64       `bson:"grams" json:"grams"`
+	PricePerGram    float64       `bson:"price_per_gram" json:"price_per_gram"` // issue or purchase price
+	PurchaseDate    time.Time     `bson:"purchase_date" json:"purchase_date"`   // issue date for SGBs
+	Tranche         string        `bson:"tranche,omitempty" json:"tranche,omitempty"`
+	Provider        string        `bson:"provider,omitempty" json:"provider,omitempty"`
+	InterestRatePct float64       `bson:"interest_rate_pct,omitempty" json:"interest_rate_pct,omitempty"`
+	MaturityDate    *time.Time    `bson:"maturity_date,omitempty" json:"maturity_date,omitempty"`
+	InterestPaid    float64       `bson:"interest_paid,omitempty" json:"interest_paid,omitempty"`
+	NextExitWindow  *time.Time    `bson:"next_exit_window,omitempty" json:"next_exit_window,omitempty"`
+	PriceDate       time.Time     `bson:"price_date" json:"price_date"` // date of the gold price used for valuation
+}
+
+const (
+	GoldSGB     = "sgb"
+	GoldDigital = "digital"
+)
+
+type GoldHoldingRequest struct {
+	Kind         string    `json:"kind"`
+	Grams        float64   `json:"grams"`
+	PricePerGram float64   `json:"price_per_gram"`
+	PurchaseDate time.Time `json:"purchase_date"`
+	Tranche      string    `json:"tranche"`
+	Provider     string    `json:"provider"`
+}
+
+// RebalancePlan compares the portfolio's allocation with the user's
+// recommended mix and sizes the trades that would restore it.
+type RebalancePlan struct {
+	RiskCategory   string       `json:"risk_category"`
+	TotalValue     float64      `json:"total_value"`
+	BandPct        float64      `json:"band_pct"`
+	NeedsRebalance bool         `json:"needs_rebalance"`
+	Classes        []ClassDrift `json:"classes"`
+}
+
+// ClassDrift is one allocation class in a rebalancing plan. Classes outside
+// the recommended mix have no target and are always held.
+type ClassDrift struct {
+	Class        string  `bson:"class" json:"class"`
+	CurrentValue float64 `bson:"current_value" json:"current_value"`
+	CurrentPct   float64 `bson:"current_pct" json:"current_pct"`
+	TargetPct    float64 `bson:"target_pct" json:"target_pct"`
+	DriftPct     float64 `bson:"drift_pct" json:"drift_pct"`
+	Action       string  `bson:"action" json:"action"` // buy | sell | hold
+	Amount       float64 `bson:"amount" json:"amount"`
+}
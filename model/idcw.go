@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// IDCWDeclaration is an income distribution announced by an AMC for one
+// IDCW scheme variant. Whether holders are paid out or reinvested follows the
+// variant's Option.
+type IDCWDeclaration struct {
+	ID          bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	SchemeCode  string        `bson:"scheme_code" json:"scheme_code"`
+	RecordDate  time.Time     `bson:"record_date" json:"record_date"`
+	PaymentDate time.Time     `bson:"payment_date" json:"payment_date"`
+	RatePerUnit float64       `bson:"rate_per_unit" json:"rate_per_unit"`
+	ExNAV       float64       `bson:"ex_nav" json:"ex_nav"` // reinvestment price; falls back to scheme NAV
+	Status      string        `bson:"status" json:"status"` // pending | processed
+	Summary     *IDCWSummary  `bson:"summary,omitempty" json:"summary,omitempty"`
+	CreatedAt   time.Time     `bson:"created_at" json:"created_at"`
+	ProcessedAt *time.Time    `bson:"processed_at,omitempty" json:"processed_at,omitempty"`
+}
+
+type IDCWSummary struct {
+	Holders         int     `bson:"holders" json:"holders"`
+	GrossAmount     float64 `bson:"gross_amount" json:"gross_amount"`
+	TDS             float64 `bson:"tds" json:"tds"`
+	NetAmount       float64 `bson:"net_amount" json:"net_amount"`
+	UnitsReinvested float64 `bson:"units_reinvested" json:"units_reinvested"`
+}
+
+type DeclareIDCWRequest struct {
+	SchemeCode  string    `json:"scheme_code"`
+	RecordDate  time.Time `json:"record_date"`
+	PaymentDate time.Time `json:"payment_date"`
+	RatePerUnit float64   `json:"rate_per_unit"`
+	ExNAV       float64   `json:"ex_nav"`
+}
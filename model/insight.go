@@ -0,0 +1,65 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MonthlyDigest summarises one calendar month of a user's portfolio. It is
+// written once, early in the following month, and announced through a
+// notification event.
+type MonthlyDigest struct {
+	ID           bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID       bson.ObjectID `bson:"user_id" json:"-"`
+	Month        string        `bson:"month" json:"month"` // YYYY-MM
+	OpeningValue float64       `bson:"opening_value" json:"opening_value"`
+	ClosingValue float64       `bson:"closing_value" json:"closing_value"`
+	Change       float64       `bson:"change" json:"change"`
+	ChangePct    *float64      `bson:"change_pct" json:"change_pct"`     // nil without an opening value
+	NetInvested  float64       `bson:"net_invested" json:"net_invested"` // money in less money out
+	MarketGain   float64       `bson:"market_gain" json:"market_gain"`   // change not explained by net investment
+
+	Best  []HoldingPerformance `bson:"best" json:"best"`
+	Worst []HoldingPerformance `bson:"worst" json:"worst"`
+
+	SIPsExecuted []SIPInstalment `bson:"sips_executed" json:"sips_executed"` // instalments the SIPs have run
+	SIPsMissed   []SIPInstalment `bson:"sips_missed" json:"sips_missed"`     // instalments of active SIPs still not run
+
+	RiskCategory   string       `bson:"risk_category,omitempty" json:"risk_category,omitempty"`
+	Allocation     []ClassDrift `bson:"allocation" json:"allocation"`
+	NeedsRebalance bool         `bson:"needs_rebalance" json:"needs_rebalance"`
+
+	UpcomingSIPs     []UpcomingInstalment `bson:"upcoming_sips" json:"upcoming_sips"` // due within a month of writing
+	UpcomingSIPTotal float64              `bson:"upcoming_sip_total" json:"upcoming_sip_total"`
+
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// HoldingPerformance is a fund's or share's NAV or price change over the
+// month.
+type HoldingPerformance struct {
+	SchemeCode   string  `bson:"scheme_code" json:"scheme_code"`
+	SchemeName   string  `bson:"scheme_name" json:"scheme_name"`
+	AssetType    string  `bson:"asset_type" json:"asset_type"`
+	CurrentValue float64 `bson:"current_value" json:"current_value"`
+	ReturnPct    float64 `bson:"return_pct" json:"return_pct"`
+}
+
+// SIPInstalment is one SIP instalment due in the month. It has run when it
+// falls before the SIP's next due date and was missed when it falls on or
+// after it.
+type SIPInstalment struct {
+	SIPID      bson.ObjectID `bson:"sip_id" json:"sip_id"`
+	SchemeCode string        `bson:"scheme_code" json:"scheme_code"`
+	SchemeName string        `bson:"scheme_name" json:"scheme_name"`
+	DueDate    time.Time     `bson:"due_date" json:"due_date"`
+	Amount     float64       `bson:"amount" json:"amount"`
+}
+
+type InsightRunResult struct {
+	Month   string `json:"month"`
+	Users   int    `json:"users"`
+	Digests int    `json:"digests"` // newly written and notified
+	Failed  int    `json:"failed"`  // logged and left for the next run
+}
@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ModelPortfolio is the admin-curated set of schemes recommended to users of
+// one risk category. Weights sum to 100.
+type ModelPortfolio struct {
+	ID           bson.ObjectID     `bson:"_id,omitempty" json:"id"`
+	RiskCategory string            `bson:"risk_category" json:"risk_category"` // conservative | moderate | aggressive
+	Name         string            `bson:"name" json:"name"`
+	Description  string            `bson:"description" json:"description"`
+	Allocations  []ModelAllocation `bson:"allocations" json:"allocations"`
+	UpdatedAt    time.Time         `bson:"updated_at" json:"updated_at"`
+}
+
+type ModelAllocation struct {
+	SchemeCode string  `bson:"scheme_code" json:"scheme_code"`
+	SchemeName string  `bson:"scheme_name" json:"scheme_name"`
+	Category   string  `bson:"category" json:"category"`
+	WeightPct  float64 `bson:"weight_pct" json:"weight_pct"`
+}
+
+type ModelPortfolioRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Allocations []struct {
+		SchemeCode string  `json:"scheme_code"`
+		WeightPct  float64 `json:"weight_pct"`
+	} `json:"allocations"`
+}
+
+// Recommendation splits a monthly budget across the user's model portfolio.
+// Schemes whose share would fall below their MinSIP are dropped and their
+// weight spread over the rest.
+type Recommendation struct {
+	RiskCategory  string           `json:"risk_category"`
+	ModelName     string           `json:"model_name"`
+	MonthlyBudget float64          `json:"monthly_budget"`
+	SIPs          []RecommendedSIP `json:"sips"`
+	Skipped       []RecommendedSIP `json:"skipped"`
+}
+
+type RecommendedSIP struct {
+	SchemeCode string  `json:"scheme_code"`
+	SchemeName string  `json:"scheme_name"`
+	Category   string  `json:"category"`
+	WeightPct  float64 `json:"weight_pct"`
+	Amount     float64 `json:"amount"`
+	MinSIP     float64 `json:"min_sip"`
+}
+
+type InvestRecommendationRequest struct {
+	MonthlyBudget float64   `json:"monthly_budget"`
+	StartDate     time.Time `json:"start_date"`
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// NAVPoint is one day's published NAV for a scheme.
+type NAVPoint struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"-"`
+	SchemeCode string        `bson:"scheme_code" json:"scheme_code"`
+	Date       time.Time     `bson:"date" json:"date"`
+	NAV        float64       `bson:"nav" json:"nav"`
+}
+
+type NAVImportResult struct {
+	Points         int      `json:"points"`
+	SchemesUpdated int      `json:"schemes_updated"`
+	Skipped        int      `json:"skipped"`
+	AlertsRaised   int      `json:"alerts_raised"`
+	Errors         []string `json:"errors,omitempty"`
+}
+
+// FundComparison lines up two or more schemes over the date range they all
+// have NAVs for.
+type FundComparison struct {
+	From    time.Time          `json:"from"`
+	To      time.Time          `json:"to"`
+	Schemes []SchemeComparison `json:"schemes"`
+	Growth  []GrowthPoint      `json:"growth"` // value of 10,000 invested at From
+}
+
+type SchemeComparison struct {
+	SchemeCode   string      `json:"scheme_code"`
+	SchemeName   string      `json:"scheme_name"`
+	Category     string      `json:"category"`
+	SubCategory  string      `json:"sub_category"`
+	Plan         string      `json:"plan"`
+	Risk         string      `json:"risk"`
+	Returns1Y    float64     `json:"returns_1y"`
+	Returns3Y    float64     `json:"returns_3y"`
+	Returns5Y    float64     `json:"returns_5y"`
+	PeriodReturn float64     `json:"period_return"` // annualised over From..To
+	RiskMetrics  RiskMetrics `json:"risk_metrics"`
+	ExpenseRatio *float64    `json:"expense_ratio"` // nil without a factsheet
+	AUM          *float64    `json:"aum"`
+	ExitLoadPct  float64     `json:"exit_load_pct"`
+	ExitLoadDays int         `json:"exit_load_days"`
+	MinSIP       float64     `json:"min_sip"`
+	MinLumpsum   float64     `json:"min_lumpsum"`
+}
+
+type RiskMetrics struct {
+	Volatility  float64 `json:"volatility"` // annualised std dev of daily returns, %
+	SharpeRatio float64 `json:"sharpe_ratio"`
+	MaxDrawdown float64 `json:"max_drawdown"` // %, as a negative number
+}
+
+type GrowthPoint struct {
+	Date   time.Time          `json:"date"`
+	Values map[string]float64 `json:"values"` // scheme code -> value
+}
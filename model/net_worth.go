@@ -0,0 +1,89 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// NetWorthItem is an asset or liability held outside the investment
+// portfolio: a bank balance, property, provident fund or loan. Manual items
+// are entered by the user; feed items are pushed by the bank's systems and
+// refreshed by ExternalRef.
+type NetWorthItem struct {
+	ID          bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID      bson.ObjectID `bson:"user_id" json:"user_id"`
+	Kind        string        `bson:"kind" json:"kind"` // asset | liability
+	Type        string        `bson:"type" json:"type"` // savings | property | epf | ppf | home_loan | personal_loan | ...
+	Name        string        `bson:"name" json:"name"`
+	Institution string        `bson:"institution,omitempty" json:"institution,omitempty"`
+	Value       float64       `bson:"value" json:"value"`   // balance, market value or outstanding principal
+	Source      string        `bson:"source" json:"source"` // manual | feed
+	ExternalRef string        `bson:"external_ref,omitempty" json:"external_ref,omitempty"`
+	AsOf        time.Time     `bson:"as_of" json:"as_of"`
+	CreatedAt   time.Time     `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time     `bson:"updated_at" json:"updated_at"`
+}
+
+const (
+	NetWorthAsset     = "asset"
+	NetWorthLiability = "liability"
+
+	SourceManual = "manual"
+	SourceFeed   = "feed"
+)
+
+type NetWorthItemRequest struct {
+	Type        string    `json:"type"`
+	Name        string    `json:"name"`
+	Institution string    `json:"institution"`
+	Value       float64   `json:"value"`
+	AsOf        time.Time `json:"as_of"`
+}
+
+// NetWorthFeedRecord is one balance pushed by an upstream system, such as
+// core banking for savings balances and loan outstandings.
+type NetWorthFeedRecord struct {
+	UserID      string    `json:"user_id"`
+	Type        string    `json:"type"`
+	ExternalRef string    `json:"external_ref"`
+	Name        string    `json:"name"`
+	Institution string    `json:"institution"`
+	Value       float64   `json:"value"`
+	AsOf        time.Time `json:"as_of"`
+}
+
+type NetWorthFeedResult struct {
+	Upserted int      `json:"upserted"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// NetWorth is assets, portfolio investments included, less liabilities.
+type NetWorth struct {
+	AsOf             time.Time      `json:"as_of"`
+	Assets           []NetWorthLine `json:"assets"`
+	Liabilities      []NetWorthLine `json:"liabilities"`
+	TotalAssets      float64        `json:"total_assets"`
+	TotalLiabilities float64        `json:"total_liabilities"`
+	NetWorth         float64        `json:"net_worth"`
+}
+
+type NetWorthLine struct {
+	Type  string  `json:"type"`
+	Value float64 `json:"value"`
+	Items int     `json:"items"`
+}
+
+// NetWorthSnapshot is a user's net worth for one calendar month. The
+// current month's snapshot tracks the latest value until the month ends.
+type NetWorthSnapshot struct {
+	ID               bson.ObjectID      `bson:"_id,omitempty" json:"-"`
+	UserID           bson.ObjectID      `bson:"user_id" json:"-"`
+	Month            string             `bson:"month" json:"month"` // YYYY-MM
+	Assets           map[string]float64 `bson:"assets" json:"assets"`
+	Liabilities      map[string]float64 `bson:"liabilities" json:"liabilities"`
+	TotalAssets      float64            `bson:"total_assets" json:"total_assets"`
+	TotalLiabilities float64            `bson:"total_liabilities" json:"total_liabilities"`
+	NetWorth         float64            `bson:"net_worth" json:"net_worth"`
+	UpdatedAt        time.Time          `bson:"updated_at" json:"updated_at"`
+}
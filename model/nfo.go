@@ -0,0 +1,82 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// NFO is a new fund offer. It carries the metadata of the scheme variant it
+// will become; the MFScheme itself is created at allotment.
+type NFO struct {
+	ID             bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	SchemeCode     string        `bson:"scheme_code" json:"scheme_code"`
+	SchemeName     string        `bson:"scheme_name" json:"scheme_name"`
+	FundCode       string        `bson:"fund_code" json:"fund_code"`
+	FundName       string        `bson:"fund_name" json:"fund_name"`
+	Plan           string        `bson:"plan" json:"plan"`
+	Option         string        `bson:"option" json:"option"`
+	ISIN           string        `bson:"isin" json:"isin"`
+	AMC            string        `bson:"amc" json:"amc"`
+	Category       string        `bson:"category" json:"category"`
+	SubCategory    string        `bson:"sub_category" json:"sub_category"`
+	Risk           string        `bson:"risk" json:"risk"`
+	Description    string        `bson:"description" json:"description"`
+	OfferPrice     float64       `bson:"offer_price" json:"offer_price"`
+	MinApplication float64       `bson:"min_application" json:"min_application"`
+	MinSIP         float64       `bson:"min_sip" json:"min_sip"`
+	OpenDate       time.Time     `bson:"open_date" json:"open_date"`
+	CloseDate      time.Time     `bson:"close_date" json:"close_date"`
+	AllotmentDate  time.Time     `bson:"allotment_date" json:"allotment_date"`
+	Status         string        `bson:"status" json:"status"` // pending | allotted
+	Summary        *NFOSummary   `bson:"summary,omitempty" json:"summary,omitempty"`
+	CreatedAt      time.Time     `bson:"created_at" json:"created_at"`
+	AllottedAt     *time.Time    `bson:"allotted_at,omitempty" json:"allotted_at,omitempty"`
+}
+
+type NFOSummary struct {
+	Orders int     `bson:"orders" json:"orders"`
+	Amount float64 `bson:"amount" json:"amount"`
+	Units  float64 `bson:"units" json:"units"`
+}
+
+// NFOOrder is an application made during the offer window. The money is
+// held and no units exist until the NFO is allotted.
+type NFOOrder struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     bson.ObjectID `bson:"user_id" json:"user_id"`
+	NFOID      bson.ObjectID `bson:"nfo_id" json:"nfo_id"`
+	SchemeCode string        `bson:"scheme_code" json:"scheme_code"`
+	SchemeName string        `bson:"scheme_name" json:"scheme_name"`
+	Amount     float64       `bson:"amount" json:"amount"`
+	Status     string        `bson:"status" json:"status"` // pending | allotted | cancelled
+	Units      float64       `bson:"units" json:"units"`
+	NAV        float64       `bson:"nav" json:"nav"`
+	CreatedAt  time.Time     `bson:"created_at" json:"created_at"`
+	AllottedAt *time.Time    `bson:"allotted_at,omitempty" json:"allotted_at,omitempty"`
+}
+
+type NFORequest struct {
+	SchemeCode     string    `json:"scheme_code"`
+	SchemeName     string    `json:"scheme_name"`
+	FundCode       string    `json:"fund_code"`
+	FundName       string    `json:"fund_name"`
+	Plan           string    `json:"plan"`
+	Option         string    `json:"option"`
+	ISIN           string    `json:"isin"`
+	AMC            string    `json:"amc"`
+	Category       string    `json:"category"`
+	SubCategory    string    `json:"sub_category"`
+	Risk           string    `json:"risk"`
+	Description    string    `json:"description"`
+	OfferPrice     float64   `json:"offer_price"` // defaults to 10
+	MinApplication float64   `json:"min_application"`
+	MinSIP         float64   `json:"min_sip"`
+	OpenDate       time.Time `json:"open_date"`
+	CloseDate      time.Time `json:"close_date"`
+	AllotmentDate  time.Time `json:"allotment_date"`
+}
+
+type NFOOrderRequest struct {
+	Amount float64 `json:"amount"`
+}
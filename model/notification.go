@@ -0,0 +1,28 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// NotificationEvent is written to the notification_events outbox for the
+// notification service to deliver. DispatchedAt is set by the consumer.
+type NotificationEvent struct {
+	ID           bson.ObjectID          `bson:"_id,omitempty" json:"id"`
+	UserID       bson.ObjectID          `bson:"user_id" json:"user_id"`
+	Type         string                 `bson:"type" json:"type"`
+	Title        string                 `bson:"title" json:"title"`
+	Body         string                 `bson:"body" json:"body"`
+	Data         map[string]interface{} `bson:"data,omitempty" json:"data,omitempty"`
+	CreatedAt    time.Time              `bson:"created_at" json:"created_at"`
+	DispatchedAt *time.Time             `bson:"dispatched_at,omitempty" json:"dispatched_at,omitempty"`
+}
+
+const (
+	EventWatchNAVDrop   = "watchlist.nav_drop"
+	EventWatch52WeekLow = "watchlist.52_week_low"
+	EventFDMaturing     = "fd.maturing"
+	EventUnderperformer = "portfolio.underperformer"
+	EventMonthlyDigest  = "insights.monthly_digest"
+)
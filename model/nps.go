@@ -0,0 +1,85 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// NPSScheme is a pension fund manager's scheme for one asset class and tier,
+// kept current from the NPS Trust NAV file. Its NAV history shares
+// nav_history with mutual funds; NPS scheme codes (SM...) cannot clash
+// with AMFI's numeric ones.
+type NPSScheme struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	SchemeCode string        `bson:"scheme_code" json:"scheme_code"`
+	SchemeName string        `bson:"scheme_name" json:"scheme_name"`
+	PFMCode    string        `bson:"pfm_code" json:"pfm_code"`
+	PFMName    string        `bson:"pfm_name" json:"pfm_name"`
+	AssetClass string        `bson:"asset_class" json:"asset_class"` // E | C | G | A
+	Tier       string        `bson:"tier" json:"tier"`               // I | II
+	NAV        float64       `bson:"nav" json:"nav"`
+	NAVDate    time.Time     `bson:"nav_date" json:"nav_date"`
+}
+
+type NPSNAVImportResult struct {
+	Points             int      `json:"points"`
+	Schemes            int      `json:"schemes"`
+	PortfoliosRevalued int      `json:"portfolios_revalued"`
+	PortfoliosFailed   int      `json:"portfolios_failed"`
+	Errors             []string `json:"errors,omitempty"`
+}
+
+// NPSAccount is the detail of an nps holding: one tier of a PRAN, invested
+// with one pension fund manager across asset-class schemes.
+type NPSAccount struct {
+	ID          bson.ObjectID    `bson:"id" json:"id"`
+	PRAN        string           `bson:"pran" json:"pran"` // masked
+	Tier        string           `bson:"tier" json:"tier"` // I | II
+	PFMCode     string           `bson:"pfm_code" json:"pfm_code"`
+	PFMName     string           `bson:"pfm_name" json:"pfm_name"`
+	OpenedDate  time.Time        `bson:"opened_date" json:"opened_date"`
+	DateOfBirth *time.Time       `bson:"date_of_birth,omitempty" json:"date_of_birth,omitempty"`
+	Schemes     []NPSSchemeUnits `bson:"schemes" json:"schemes"`
+	Contributed float64          `bson:"contributed" json:"contributed"`
+	LockIn      NPSLockIn        `bson:"lock_in" json:"lock_in"`
+}
+
+type NPSSchemeUnits struct {
+	AssetClass    string  `bson:"asset_class" json:"asset_class"`
+	SchemeCode    string  `bson:"scheme_code" json:"scheme_code"`
+	SchemeName    string  `bson:"scheme_name" json:"scheme_name"`
+	AllocationPct float64 `bson:"allocation_pct" json:"allocation_pct"` // share of each contribution
+	Units         float64 `bson:"units" json:"units"`
+	NAV           float64 `bson:"nav" json:"nav"`
+	Value         float64 `bson:"value" json:"value"`
+}
+
+// NPSLockIn summarises what the account allows today. Tier I is locked until
+// 60 with partial withdrawals after three years; Tier II is not locked.
+type NPSLockIn struct {
+	Locked                bool       `bson:"locked" json:"locked"`
+	LockedUntil           *time.Time `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+	PartialWithdrawalFrom *time.Time `bson:"partial_withdrawal_from,omitempty" json:"partial_withdrawal_from,omitempty"`
+	Note                  string     `bson:"note" json:"note"`
+}
+
+const (
+	NPSTierI  = "I"
+	NPSTierII = "II"
+)
+
+type NPSAccountRequest struct {
+	PRAN        string             `json:"pran"`
+	Tier        string             `json:"tier"`
+	PFMCode     string             `json:"pfm_code"`
+	OpenedDate  time.Time          `json:"opened_date"`
+	DateOfBirth *time.Time         `json:"date_of_birth"`
+	Allocation  map[string]float64 `json:"allocation"` // asset class -> %, e.g. {"E": 50, "C": 30, "G": 20}
+}
+
+type NPSContributionRequest struct {
+	Amount    float64   `json:"amount"`
+	Date      time.Time `json:"date"`
+	Reference string    `json:"reference"` // client-chosen, unique per contribution; repeats are not reinvested
+}
@@ -0,0 +1,36 @@
+package model
+
+// PortfolioOverlap is the look-through view of a user's mutual fund holdings
+// built from each scheme's latest factsheet holdings. Factsheets disclose
+// only top holdings, so exposures cover the disclosed part of each fund.
+type PortfolioOverlap struct {
+	AnalysedValue  float64         `json:"analysed_value"`
+	Unanalysed     []string        `json:"unanalysed"` // held schemes without factsheet holdings
+	Pairs          []SchemeOverlap `json:"pairs"`
+	StockExposure  []Exposure      `json:"stock_exposure"`
+	SectorExposure []Exposure      `json:"sector_exposure"`
+	Warnings       []Warning       `json:"warnings"`
+}
+
+// SchemeOverlap is the share of portfolio two schemes hold in common: the sum
+// over shared stocks of the smaller of the two weights.
+type SchemeOverlap struct {
+	SchemeA      string   `json:"scheme_a"`
+	SchemeB      string   `json:"scheme_b"`
+	OverlapPct   float64  `json:"overlap_pct"`
+	CommonStocks []string `json:"common_stocks"`
+}
+
+type Exposure struct {
+	Name   string  `json:"name"`
+	ISIN   string  `json:"isin,omitempty"`
+	Weight float64 `json:"weight"` // % of analysed value
+	Value  float64 `json:"value"`
+}
+
+type Warning struct {
+	Type    string  `json:"type"`
+	Subject string  `json:"subject"`
+	Value   float64 `json:"value"`
+	Message string  `json:"message"`
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// PortfolioSnapshot is a portfolio's value at the end of one day, written by
+// the nightly valuation.
+type PortfolioSnapshot struct {
+	ID       bson.ObjectID      `bson:"_id,omitempty" json:"-"`
+	UserID   bson.ObjectID      `bson:"user_id" json:"-"`
+	Date     time.Time          `bson:"date" json:"date"`
+	Value    float64            `bson:"value" json:"value"`
+	Invested float64            `bson:"invested" json:"invested"`
+	GainLoss float64            `bson:"gain_loss" json:"gain_loss"`
+	ByAsset  map[string]float64 `bson:"by_asset" json:"by_asset"` // asset type -> value
+}
+
+type PortfolioValuationResult struct {
+	Portfolios int `json:"portfolios"`
+	Snapshots  int `json:"snapshots"`
+	Failed     int `json:"failed"` // logged and left for the next run
+}
+
+// PortfolioHistory is value against invested over a range. The ALL range
+// is thinned to one point per week.
+type PortfolioHistory struct {
+	Range     string              `json:"range"`
+	From      time.Time           `json:"from"`
+	To        time.Time           `json:"to"`
+	Points    []PortfolioSnapshot `json:"points"`
+	Change    float64             `json:"change"`     // value change over the range
+	ChangePct float64             `json:"change_pct"` // of the first point's value
+}
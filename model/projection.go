@@ -0,0 +1,17 @@
+package model
+
+// MonteCarloRequest configures a portfolio or goal simulation. With GoalID
+// set the horizon and target come from the goal; otherwise Years is used.
+type MonteCarloRequest struct {
+	Years       int                           `json:"years"`
+	Simulations int                           `json:"simulations"`
+	Seed        *int64                        `json:"seed"`
+	Method      string                        `json:"method"` // assumptions | bootstrap
+	GoalID      string                        `json:"goal_id"`
+	Assumptions map[string]CategoryAssumption `json:"assumptions"` // per-category overrides
+}
+
+type CategoryAssumption struct {
+	ReturnPct     float64 `json:"return_pct"`
+	VolatilityPct float64 `json:"volatility_pct"`
+}
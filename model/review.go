@@ -0,0 +1,85 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// SchemeReview is the periodic performance review of a growth scheme
+// against its peers (active schemes in the same sub-category and plan) and
+// its benchmark, over trailing 1Y and 3Y windows ending at each of the last
+// few quarter ends. Other options of a fund are reviewed through its growth
+// variant.
+type SchemeReview struct {
+	ID            bson.ObjectID       `bson:"_id,omitempty" json:"-"`
+	SchemeCode    string              `bson:"scheme_code" json:"scheme_code"`
+	SchemeName    string              `bson:"scheme_name" json:"scheme_name"`
+	SubCategory   string              `bson:"sub_category" json:"sub_category"`
+	Plan          string              `bson:"plan" json:"plan"`
+	BenchmarkCode string              `bson:"benchmark_code" json:"benchmark_code,omitempty"`
+	AsOf          time.Time           `bson:"as_of" json:"as_of"`       // latest quarter end reviewed
+	Quarters      []QuarterReview     `bson:"quarters" json:"quarters"` // oldest first
+	Ranks         []PeerRank          `bson:"ranks" json:"ranks"`
+	Flagged       bool                `bson:"flagged" json:"flagged"`
+	Reasons       []string            `bson:"reasons" json:"reasons,omitempty"`
+	Alternatives  []ReviewAlternative `bson:"alternatives" json:"alternatives,omitempty"`
+	ReviewedAt    time.Time           `bson:"reviewed_at" json:"reviewed_at"`
+	NotifiedAsOf  time.Time           `bson:"notified_as_of" json:"-"` // quarter end whose holders have all been notified
+}
+
+// QuarterReview holds trailing returns (CAGR, %) to one quarter end. A nil
+// value means the series does not cover the window or there were too few
+// peers for a median.
+type QuarterReview struct {
+	QuarterEnd  time.Time `bson:"quarter_end" json:"quarter_end"`
+	Return1Y    *float64  `bson:"return_1y" json:"return_1y"`
+	Return3Y    *float64  `bson:"return_3y" json:"return_3y"`
+	Median1Y    *float64  `bson:"median_1y" json:"category_median_1y"`
+	Median3Y    *float64  `bson:"median_3y" json:"category_median_3y"`
+	Benchmark1Y *float64  `bson:"benchmark_1y" json:"benchmark_1y"`
+	Benchmark3Y *float64  `bson:"benchmark_3y" json:"benchmark_3y"`
+}
+
+// PeerRank is the scheme's position among peers by return over Window at
+// the latest quarter end; 1 is best.
+type PeerRank struct {
+	Window string `bson:"window" json:"window"`
+	Rank   int    `bson:"rank" json:"rank"`
+	Of     int    `bson:"of" json:"of"`
+}
+
+type ReviewAlternative struct {
+	SchemeCode string  `bson:"scheme_code" json:"scheme_code"`
+	SchemeName string  `bson:"scheme_name" json:"scheme_name"`
+	AMC        string  `bson:"amc" json:"amc"`
+	Window     string  `bson:"window" json:"window"`
+	Rank       int     `bson:"rank" json:"rank"`
+	Return     float64 `bson:"return" json:"return"`
+}
+
+type ReviewRunResult struct {
+	QuarterEnd    time.Time `json:"quarter_end"`
+	Schemes       int       `json:"schemes_reviewed"`
+	Flagged       int       `json:"flagged"`
+	Notifications int       `json:"notifications"`
+}
+
+// PortfolioReview lists the user's funds with their latest review, flagged
+// ones first.
+type PortfolioReview struct {
+	Holdings    []HoldingReview `json:"holdings"`
+	Flagged     int             `json:"flagged"`
+	NotReviewed []string        `json:"not_reviewed,omitempty"`
+}
+
+type HoldingReview struct {
+	SchemeCode   string              `json:"scheme_code"`
+	SchemeName   string              `json:"scheme_name"`
+	CurrentValue float64             `json:"current_value"`
+	AsOf         time.Time           `json:"as_of"`
+	Flagged      bool                `json:"flagged"`
+	Reasons      []string            `json:"reasons,omitempty"`
+	Ranks        []PeerRank          `json:"ranks"`
+	Alternatives []ReviewAlternative `json:"alternatives,omitempty"`
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// InvestorProfile holds the KYC details statements need, pushed by the
+// customer profile system. PAN and date of birth form the password of
+// protected statements.
+type InvestorProfile struct {
+	ID          bson.ObjectID `bson:"_id,omitempty" json:"-"`
+	UserID      bson.ObjectID `bson:"user_id" json:"user_id"`
+	Name        string        `bson:"name" json:"name"`
+	PAN         string        `bson:"pan" json:"pan"`
+	DateOfBirth time.Time     `bson:"date_of_birth" json:"date_of_birth"`
+	UpdatedAt   time.Time     `bson:"updated_at" json:"updated_at"`
+}
+
+type InvestorProfileRecord struct {
+	UserID      string `json:"user_id"`
+	Name        string `json:"name"`
+	PAN         string `json:"pan"`
+	DateOfBirth string `json:"date_of_birth"` // YYYY-MM-DD
+}
+
+type InvestorProfileFeedResult struct {
+	Upserted int      `json:"upserted"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// StatementRequest selects a statement. Transaction statements cover From
+// to To; capital gains statements cover the financial year FY.
+type StatementRequest struct {
+	Type    string // transactions | capital_gains
+	Format  string // pdf | csv
+	From    time.Time
+	To      time.Time
+	FY      string
+	Protect bool // password-protect a PDF
+}
+
+const (
+	StatementTransactions = "transactions"
+	StatementCapitalGains = "capital_gains"
+)
+
+// Statement is a rendered statement file.
+type Statement struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TaxSavingDeclaration is what the user tells us they have put into other
+// section 80C instruments in one financial year, such as EPF, PPF or life
+// insurance premiums. ELSS bought through us is taken from the ledger and
+// SIPs; lump sums the ledger does not have are declared as elss.
+type TaxSavingDeclaration struct {
+	ID            bson.ObjectID   `bson:"_id,omitempty" json:"-"`
+	UserID        bson.ObjectID   `bson:"user_id" json:"-"`
+	FinancialYear string          `bson:"financial_year" json:"financial_year"` // e.g. 2025-26
+	Items         []TaxSavingItem `bson:"items" json:"items"`
+	UpdatedAt     time.Time       `bson:"updated_at" json:"updated_at"`
+}
+
+type TaxSavingItem struct {
+	Type        string  `bson:"type" json:"type"` // epf | ppf | life_insurance | nsc | tax_saver_fd | home_loan_principal | tuition_fees | sukanya_samriddhi | ulip | elss | other
+	Description string  `bson:"description,omitempty" json:"description,omitempty"`
+	Amount      float64 `bson:"amount" json:"amount"`
+}
+
+type TaxSavingDeclarationRequest struct {
+	Items []TaxSavingItem `json:"items"`
+}
+
+// Section80CTracker shows how much of the year's 80C deduction is used and
+// how to use the rest before 31 March. 80C applies under the old tax regime
+// only.
+type Section80CTracker struct {
+	FinancialYear     string               `json:"financial_year"`
+	From              time.Time            `json:"from"`
+	To                time.Time            `json:"to"`
+	Limit             float64              `json:"limit"`
+	ELSSInvested      float64              `json:"elss_invested"`
+	ELSSInvestments   []ELSSInvestment     `json:"elss_investments"`
+	OtherInvestments  []TaxSavingItem      `json:"other_investments"`
+	OtherInvested     float64              `json:"other_invested"`
+	Used              float64              `json:"used"` // capped at Limit
+	Headroom          float64              `json:"headroom"`
+	UpcomingSIPs      []UpcomingInstalment `json:"upcoming_sips"`
+	UpcomingSIPTotal  float64              `json:"upcoming_sip_total"`
+	HeadroomAfterSIPs float64              `json:"headroom_after_sips"`
+	TopUp             *TopUpSuggestion     `json:"top_up,omitempty"`
+	Note              string               `json:"note,omitempty"`
+}
+
+// ELSSInvestment is one ELSS purchase or SIP instalment; its units are locked in for three
+// years from the purchase date.
+type ELSSInvestment struct {
+	Date        time.Time `json:"date"`
+	SchemeCode  string    `json:"scheme_code"`
+	SchemeName  string    `json:"scheme_name"`
+	Type        string    `json:"type"`
+	Amount      float64   `json:"amount"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+type UpcomingInstalment struct {
+	SIPID      bson.ObjectID `bson:"sip_id" json:"sip_id"`
+	SchemeCode string        `bson:"scheme_code" json:"scheme_code"`
+	SchemeName string        `bson:"scheme_name" json:"scheme_name"`
+	Date       time.Time     `bson:"date" json:"date"`
+	Amount     float64       `bson:"amount" json:"amount"`
+}
+
+// TopUpSuggestion is a lumpsum that fills the headroom left after upcoming
+// SIPs. SchemeCode is the user's main ELSS scheme, when they have one.
+type TopUpSuggestion struct {
+	Amount     float64   `json:"amount"`
+	SchemeCode string    `json:"scheme_code,omitempty"`
+	SchemeName string    `json:"scheme_name,omitempty"`
+	By         time.Time `json:"by"`
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Transaction is one entry in a user's unit ledger. Units are signed: credits
+// (purchases, reinvestments) are positive and debits negative. Statements and
+// tax reports are built from the ledger, not from Portfolio snapshots.
+type Transaction struct {
+	ID                 bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID             bson.ObjectID `bson:"user_id" json:"user_id"`
+	SchemeCode         string        `bson:"scheme_code" json:"scheme_code"`
+	SchemeName         string        `bson:"scheme_name" json:"scheme_name"`
+	AMC                string        `bson:"amc" json:"amc"`
+	Type               string        `bson:"type" json:"type"` // purchase | sip | redemption | nfo_allotment | nps_contribution | equity_buy | equity_sell | idcw_payout | idcw_reinvest | scheme_merger | scheme_code_change | scheme_rename | equity_split | equity_bonus
+	Date               time.Time     `bson:"date" json:"date"`
+	Units              float64       `bson:"units" json:"units"`
+	NAV                float64       `bson:"nav" json:"nav"`
+	Amount             float64       `bson:"amount" json:"amount"` // gross
+	TDS                float64       `bson:"tds" json:"tds"`
+	NetAmount          float64       `bson:"net_amount" json:"net_amount"`
+	Reference          string        `bson:"reference,omitempty" json:"reference,omitempty"` // source event, e.g. IDCW declaration ID
+	Note               string        `bson:"note,omitempty" json:"note,omitempty"`
+	OriginalSchemeCode string        `bson:"original_scheme_code,omitempty" json:"original_scheme_code,omitempty"` // pre-merger code; units/NAV are restated in surviving terms
+	CreatedAt          time.Time     `bson:"created_at" json:"created_at"`
+}
+
+const (
+	TxnPurchase        = "purchase"
+	TxnSIP             = "sip"
+	TxnRedemption      = "redemption"
+	TxnNFOAllotment    = "nfo_allotment"
+	TxnNPSContribution = "nps_contribution"
+	TxnEquityBuy       = "equity_buy"
+	TxnEquitySell      = "equity_sell"
+	TxnIDCWPayout      = "idcw_payout"
+	TxnIDCWReinvest    = "idcw_reinvest"
+
+	// Corporate action entries carry no units or cash; they mark the event on
+	// the user's statement.
+	TxnSchemeMerger     = "scheme_merger"
+	TxnSchemeCodeChange = "scheme_code_change"
+	TxnSchemeRename     = "scheme_rename"
+
+	// Splits and bonuses credit the new shares at no cost; SchemeCode holds
+	// the ISIN as for equity trades.
+	TxnEquitySplit = "equity_split"
+	TxnEquityBonus = "equity_bonus"
+)
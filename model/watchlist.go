@@ -0,0 +1,46 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type WatchlistItem struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     bson.ObjectID `bson:"user_id" json:"user_id"`
+	SchemeCode string        `bson:"scheme_code" json:"scheme_code"`
+	SchemeName string        `bson:"scheme_name" json:"scheme_name"`
+	AddedAt    time.Time     `bson:"added_at" json:"added_at"`
+	NAVAtAdd   float64       `bson:"nav_at_add" json:"nav_at_add"`
+	Alerts     WatchAlerts   `bson:"alerts" json:"alerts"`
+	// DropAlerted is set when the drop alert fires and cleared once the NAV
+	// recovers above the threshold, so each fall is reported once.
+	DropAlerted bool `bson:"drop_alerted" json:"-"`
+	// LowAlertedFor is the NAV date of the last 52-week-low alert.
+	LowAlertedFor time.Time `bson:"low_alerted_for" json:"-"`
+}
+
+type WatchAlerts struct {
+	DropPct         float64 `bson:"drop_pct" json:"drop_pct"` // 0 disables
+	FiftyTwoWeekLow bool    `bson:"fifty_two_week_low" json:"fifty_two_week_low"`
+}
+
+type WatchlistRequest struct {
+	SchemeCode string      `json:"scheme_code"`
+	Alerts     WatchAlerts `json:"alerts"`
+}
+
+// WatchlistEntry is a watched scheme with its latest NAV movement.
+type WatchlistEntry struct {
+	WatchlistItem
+	Category          string    `json:"category"`
+	NAV               float64   `json:"nav"`
+	NAVDate           time.Time `json:"nav_date"`
+	DayChange         float64   `json:"day_change"`
+	DayChangePct      float64   `json:"day_change_pct"`
+	ChangeSinceAddPct float64   `json:"change_since_add_pct"`
+	Returns1Y         float64   `json:"returns_1y"`
+	Returns3Y         float64   `json:"returns_3y"`
+	Returns5Y         float64   `json:"returns_5y"`
+}
@@ -6,10 +6,18 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+// MFScheme is one purchasable variant of a fund: a single plan/option
+// combination with its own scheme code, ISIN and NAV. Variants of the same
+// fund share a FundCode.
 type MFScheme struct {
 	ID           bson.ObjectID `bson:"_id,omitempty" json:"id"`
 	SchemeCode   string        `bson:"scheme_code" json:"scheme_code"`
 	SchemeName   string        `bson:"scheme_name" json:"scheme_name"`
+	FundCode     string        `bson:"fund_code" json:"fund_code"`
+	FundName     string        `bson:"fund_name" json:"fund_name"`
+	Plan         string        `bson:"plan" json:"plan"`     // direct | regular
+	Option       string        `bson:"option" json:"option"` // growth | idcw_payout | idcw_reinvest
+	ISIN         string        `bson:"isin" json:"isin"`
 	AMC          string        `bson:"amc" json:"amc"`
 	Category     string        `bson:"category" json:"category"` // equity | debt | hybrid | liquid
 	SubCategory  string        `bson:"sub_category" json:"sub_category"`
@@ -21,7 +29,48 @@ type MFScheme struct {
 	Risk         string        `bson:"risk" json:"risk"` // low | moderate | high
 	MinSIP       float64       `bson:"min_sip" json:"min_sip"`
 	MinLumpsum   float64       `bson:"min_lumpsum" json:"min_lumpsum"`
+	ExitLoadPct  float64       `bson:"exit_load_pct" json:"exit_load_pct"`
+	ExitLoadDays int           `bson:"exit_load_days" json:"exit_load_days"` // load applies to units redeemed within this many days
 	IsActive     bool          `bson:"is_active" json:"is_active"`
+	// BenchmarkCode links the scheme to its benchmark index; when empty the
+	// factsheet's benchmark name is matched instead.
+	BenchmarkCode string `bson:"benchmark_code,omitempty" json:"benchmark_code,omitempty"`
+}
+
+const (
+	PlanDirect  = "direct"
+	PlanRegular = "regular"
+
+	OptionGrowth       = "growth"
+	OptionIDCWPayout   = "idcw_payout"
+	OptionIDCWReinvest = "idcw_reinvest"
+)
+
+// Fund groups the plan/option variants of one underlying portfolio so the
+// catalogue can present a fund first and its variants second.
+type Fund struct {
+	FundCode    string        `json:"fund_code"`
+	FundName    string        `json:"fund_name"`
+	AMC         string        `json:"amc"`
+	Category    string        `json:"category"`
+	SubCategory string        `json:"sub_category"`
+	Risk        string        `json:"risk"`
+	Variants    []FundVariant `json:"variants"`
+}
+
+type FundVariant struct {
+	SchemeCode string    `json:"scheme_code"`
+	SchemeName string    `json:"scheme_name"`
+	Plan       string    `json:"plan"`
+	Option     string    `json:"option"`
+	ISIN       string    `json:"isin"`
+	NAV        float64   `json:"nav"`
+	NAVDate    time.Time `json:"nav_date"`
+	Returns1Y  float64   `json:"returns_1y"`
+	Returns3Y  float64   `json:"returns_3y"`
+	Returns5Y  float64   `json:"returns_5y"`
+	MinSIP     float64   `json:"min_sip"`
+	MinLumpsum float64   `json:"min_lumpsum"`
 }
 
 type SIP struct {
@@ -50,16 +99,36 @@ type Portfolio struct {
 	UpdatedAt   time.Time       `bson:"updated_at" json:"updated_at"`
 }
 
+// Holding is one asset in a portfolio. AssetType says which fields apply:
+// fund holdings are keyed by SchemeCode and carry units and NAV, other assets
+// are keyed by AssetID and carry their details in a type-specific
+// sub-document. SchemeName is the display name for every type. Holdings
+// stored before AssetType existed are mutual funds.
 type Holding struct {
-	SchemeCode    string  `bson:"scheme_code" json:"scheme_code"`
-	SchemeName    string  `bson:"scheme_name" json:"scheme_name"`
-	Units         float64 `bson:"units" json:"units"`
-	CurrentNAV    float64 `bson:"current_nav" json:"current_nav"`
-	CurrentValue  float64 `bson:"current_value" json:"current_value"`
-	InvestedValue float64 `bson:"invested_value" json:"invested_value"`
-	GainLoss      float64 `bson:"gain_loss" json:"gain_loss"`
+	AssetType     string        `bson:"asset_type,omitempty" json:"asset_type"`
+	AssetID       string        `bson:"asset_id,omitempty" json:"asset_id,omitempty"`
+	Category      string        `bson:"category,omitempty" json:"category"` // allocation class: equity | debt | hybrid | liquid | gold
+	SchemeCode    string        `bson:"scheme_code" json:"scheme_code"`
+	SchemeName    string        `bson:"scheme_name" json:"scheme_name"`
+	Units         float64       `bson:"units" json:"units"`
+	CurrentNAV    float64       `bson:"current_nav" json:"current_nav"`
+	CurrentValue  float64       `bson:"current_value" json:"current_value"`
+	InvestedValue float64       `bson:"invested_value" json:"invested_value"`
+	GainLoss      float64       `bson:"gain_loss" json:"gain_loss"`
+	FD            *FixedDeposit `bson:"fd,omitempty" json:"fd,omitempty"`
+	Gold          *Gold         `bson:"gold,omitempty" json:"gold,omitempty"`
+	NPS           *NPSAccount   `bson:"nps,omitempty" json:"nps,omitempty"`
+	Equity        *Equity       `bson:"equity,omitempty" json:"equity,omitempty"`
 }
 
+const (
+	AssetMutualFund   = "mutual_fund"
+	AssetFixedDeposit = "fixed_deposit"
+	AssetGold         = "gold"
+	AssetNPS          = "nps"
+	AssetEquity       = "equity" // listed shares and ETFs
+)
+
 type RiskProfile struct {
 	ID              bson.ObjectID `bson:"_id,omitempty" json:"id"`
 	UserID          bson.ObjectID `bson:"user_id" json:"user_id"`
@@ -91,6 +160,7 @@ type PortfolioAnalytics struct {
 	CurrentValue   float64            `json:"current_value"`
 	TotalGainLoss  float64            `json:"total_gain_loss"`
 	ReturnPct      float64            `json:"return_pct"`
-	CategoryBreakdown map[string]float64 `json:"category_breakdown"`
+	CategoryBreakdown map[string]float64 `json:"category_breakdown"` // % of current value
+	AssetBreakdown    map[string]float64 `json:"asset_breakdown"`    // % of current value
 	TopHoldings    []Holding          `json:"top_holdings"`
 }
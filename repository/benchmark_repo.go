@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type BenchmarkRepo interface {
+	Upsert(ctx context.Context, b *model.Benchmark) error
+	FindByCode(ctx context.Context, code string) (*model.Benchmark, error)
+	FindAll(ctx context.Context) ([]model.Benchmark, error)
+	// UpdateValue sets the latest value unless a newer one is already stored.
+	UpdateValue(ctx context.Context, code string, value float64, date time.Time) (bool, error)
+}
+
+type benchmarkRepo struct{ col *mongo.Collection }
+
+func NewBenchmarkRepo(db *mongo.Database) BenchmarkRepo {
+	return &benchmarkRepo{col: db.Collection("benchmarks")}
+}
+
+func (r *benchmarkRepo) Upsert(ctx context.Context, b *model.Benchmark) error {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"code": b.Code},
+		bson.M{"$set": bson.M{"name": b.Name, "aliases": b.Aliases, "asset_class": b.AssetClass}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *benchmarkRepo) FindByCode(ctx context.Context, code string) (*model.Benchmark, error) {
+	var b model.Benchmark
+	if err := r.col.FindOne(ctx, bson.M{"code": code}).Decode(&b); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func (r *benchmarkRepo) FindAll(ctx context.Context) ([]model.Benchmark, error) {
+	cursor, err := r.col.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "code", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.Benchmark
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *benchmarkRepo) UpdateValue(ctx context.Context, code string, value float64, date time.Time) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"code": code, "$or": bson.A{
+			bson.M{"value_date": bson.M{"$lte": date}},
+			bson.M{"value_date": bson.M{"$exists": false}},
+		}},
+		bson.M{"$set": bson.M{"value": value, "value_date": date}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
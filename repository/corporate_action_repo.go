@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type CorporateActionRepo interface {
+	Create(ctx context.Context, a *model.CorporateAction) error
+	FindByID(ctx context.Context, id bson.ObjectID) (*model.CorporateAction, error)
+	FindByStatus(ctx context.Context, status string) ([]model.CorporateAction, error)
+	FindDue(ctx context.Context, asOf time.Time) ([]model.CorporateAction, error)
+	// MarkProcessed moves a pending action to processed. It reports false
+	// if the action was no longer pending.
+	MarkProcessed(ctx context.Context, id bson.ObjectID, summary *model.CorporateActionSummary) (bool, error)
+}
+
+type corporateActionRepo struct{ col *mongo.Collection }
+
+func NewCorporateActionRepo(db *mongo.Database) CorporateActionRepo {
+	return &corporateActionRepo{col: db.Collection("corporate_actions")}
+}
+
+func (r *corporateActionRepo) Create(ctx context.Context, a *model.CorporateAction) error {
+	a.CreatedAt = time.Now()
+	res, err := r.col.InsertOne(ctx, a)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		a.ID = oid
+	}
+	return nil
+}
+
+func (r *corporateActionRepo) FindByID(ctx context.Context, id bson.ObjectID) (*model.CorporateAction, error) {
+	var a model.CorporateAction
+	if err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *corporateActionRepo) FindByStatus(ctx context.Context, status string) ([]model.CorporateAction, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *corporateActionRepo) FindDue(ctx context.Context, asOf time.Time) ([]model.CorporateAction, error) {
+	return r.find(ctx, bson.M{"status": "pending", "effective_date": bson.M{"$lte": asOf}})
+}
+
+func (r *corporateActionRepo) MarkProcessed(ctx context.Context, id bson.ObjectID, summary *model.CorporateActionSummary) (bool, error) {
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "status": "pending"}, bson.M{"$set": bson.M{
+		"status":       "processed",
+		"summary":      summary,
+		"processed_at": time.Now(),
+	}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *corporateActionRepo) find(ctx context.Context, filter bson.M) ([]model.CorporateAction, error) {
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "effective_date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.CorporateAction
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
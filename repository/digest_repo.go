@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type DigestRepo interface {
+	Create(ctx context.Context, d *model.MonthlyDigest) error
+	Find(ctx context.Context, userID bson.ObjectID, month string) (*model.MonthlyDigest, error)
+	// FindLatest returns the user's digest for the most recent month.
+	FindLatest(ctx context.Context, userID bson.ObjectID) (*model.MonthlyDigest, error)
+}
+
+type digestRepo struct{ col *mongo.Collection }
+
+func NewDigestRepo(db *mongo.Database) DigestRepo {
+	return &digestRepo{col: db.Collection("monthly_digests")}
+}
+
+func (r *digestRepo) Create(ctx context.Context, d *model.MonthlyDigest) error {
+	d.CreatedAt = time.Now()
+	res, err := r.col.InsertOne(ctx, d)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		d.ID = oid
+	}
+	return nil
+}
+
+func (r *digestRepo) Find(ctx context.Context, userID bson.ObjectID, month string) (*model.MonthlyDigest, error) {
+	var d model.MonthlyDigest
+	if err := r.col.FindOne(ctx, bson.M{"user_id": userID, "month": month}).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *digestRepo) FindLatest(ctx context.Context, userID bson.ObjectID) (*model.MonthlyDigest, error) {
+	var d model.MonthlyDigest
+	opts := options.FindOne().SetSort(bson.D{{Key: "month", Value: -1}})
+	if err := r.col.FindOne(ctx, bson.M{"user_id": userID}, opts).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
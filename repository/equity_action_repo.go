@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type EquityActionRepo interface {
+	Create(ctx context.Context, a *model.EquityAction) error
+	FindByID(ctx context.Context, id bson.ObjectID) (*model.EquityAction, error)
+	FindByStatus(ctx context.Context, status string) ([]model.EquityAction, error)
+	FindDue(ctx context.Context, asOf time.Time) ([]model.EquityAction, error)
+	// MarkProcessed moves a pending action to processed, reporting false
+	// when it was not pending.
+	MarkProcessed(ctx context.Context, id bson.ObjectID, summary *model.EquityActionSummary) (bool, error)
+}
+
+type equityActionRepo struct{ col *mongo.Collection }
+
+func NewEquityActionRepo(db *mongo.Database) EquityActionRepo {
+	return &equityActionRepo{col: db.Collection("equity_actions")}
+}
+
+func (r *equityActionRepo) Create(ctx context.Context, a *model.EquityAction) error {
+	a.CreatedAt = time.Now()
+	res, err := r.col.InsertOne(ctx, a)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		a.ID = oid
+	}
+	return nil
+}
+
+func (r *equityActionRepo) FindByID(ctx context.Context, id bson.ObjectID) (*model.EquityAction, error) {
+	var a model.EquityAction
+	if err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *equityActionRepo) FindByStatus(ctx context.Context, status string) ([]model.EquityAction, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *equityActionRepo) FindDue(ctx context.Context, asOf time.Time) ([]model.EquityAction, error) {
+	return r.find(ctx, bson.M{"status": "pending", "ex_date": bson.M{"$lte": asOf}})
+}
+
+func (r *equityActionRepo) MarkProcessed(ctx context.Context, id bson.ObjectID, summary *model.EquityActionSummary) (bool, error) {
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "status": "pending"}, bson.M{"$set": bson.M{
+		"status":       "processed",
+		"summary":      summary,
+		"processed_at": time.Now(),
+	}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *equityActionRepo) find(ctx context.Context, filter bson.M) ([]model.EquityAction, error) {
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "ex_date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.EquityAction
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
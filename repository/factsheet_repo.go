@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type FactsheetRepo interface {
+	Upsert(ctx context.Context, f *model.Factsheet) error
+	FindLatest(ctx context.Context, schemeCode string) (*model.Factsheet, error)
+}
+
+type factsheetRepo struct{ col *mongo.Collection }
+
+func NewFactsheetRepo(db *mongo.Database) FactsheetRepo {
+	return &factsheetRepo{col: db.Collection("mf_factsheets")}
+}
+
+func (r *factsheetRepo) Upsert(ctx context.Context, f *model.Factsheet) error {
+	f.ImportedAt = time.Now()
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"scheme_code": f.SchemeCode, "as_of": f.AsOf},
+		bson.M{"$set": f},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *factsheetRepo) FindLatest(ctx context.Context, schemeCode string) (*model.Factsheet, error) {
+	var f model.Factsheet
+	err := r.col.FindOne(ctx,
+		bson.M{"scheme_code": schemeCode},
+		options.FindOne().SetSort(bson.D{{Key: "as_of", Value: -1}}),
+	).Decode(&f)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type FamilyRepo interface {
+	Create(ctx context.Context, g *model.FamilyGroup) error
+	FindByID(ctx context.Context, id bson.ObjectID) (*model.FamilyGroup, error)
+	// FindByMember returns the group the user is an active member of.
+	FindByMember(ctx context.Context, userID bson.ObjectID) (*model.FamilyGroup, error)
+	// AddMember appends m unless the user is already invited or a member.
+	AddMember(ctx context.Context, groupID bson.ObjectID, m model.FamilyMember) (bool, error)
+	// ActivateMember accepts the user's pending invitation.
+	ActivateMember(ctx context.Context, groupID, userID bson.ObjectID, at time.Time) (bool, error)
+	RemoveMember(ctx context.Context, groupID, userID bson.ObjectID) (bool, error)
+	// Touch bumps the group's updated_at so that concurrent transactions
+	// changing the group conflict instead of both committing.
+	Touch(ctx context.Context, id bson.ObjectID) error
+	Delete(ctx context.Context, id bson.ObjectID) error
+}
+
+type FamilyMembershipRepo interface {
+	// Create fails with a duplicate key error if the user already belongs
+	// to a group.
+	Create(ctx context.Context, m *model.FamilyMembership) error
+	Delete(ctx context.Context, groupID, userID bson.ObjectID) error
+	DeleteByGroup(ctx context.Context, groupID bson.ObjectID) error
+}
+
+type FamilyConsentRepo interface {
+	Create(ctx context.Context, c *model.FamilyConsent) error
+	FindByID(ctx context.Context, id bson.ObjectID) (*model.FamilyConsent, error)
+	// FindActive returns the unrevoked, unexpired consent from grantor to
+	// grantee in the group.
+	FindActive(ctx context.Context, groupID, grantorID, granteeID bson.ObjectID, at time.Time) (*model.FamilyConsent, error)
+	// FindActiveByGroup returns the group's consents in force at the given time.
+	FindActiveByGroup(ctx context.Context, groupID bson.ObjectID, at time.Time) ([]model.FamilyConsent, error)
+	Revoke(ctx context.Context, id bson.ObjectID, at time.Time) (bool, error)
+	// RevokePair revokes every unrevoked consent from grantor to grantee in
+	// the group, expired or not.
+	RevokePair(ctx context.Context, groupID, grantorID, granteeID bson.ObjectID, at time.Time) (int64, error)
+	// RevokeInvolving revokes every unrevoked consent the user gave or
+	// received in the group.
+	RevokeInvolving(ctx context.Context, groupID, userID bson.ObjectID, at time.Time) (int64, error)
+}
+
+type FamilyAccessLogRepo interface {
+	Create(ctx context.Context, l *model.FamilyAccessLog) error
+	// FindBySubject returns the latest accesses to the user's data, newest first.
+	FindBySubject(ctx context.Context, subjectID bson.ObjectID, limit int64) ([]model.FamilyAccessLog, error)
+}
+
+type familyRepo struct{ col *mongo.Collection }
+type familyMembershipRepo struct{ col *mongo.Collection }
+type familyConsentRepo struct{ col *mongo.Collection }
+type familyAccessLogRepo struct{ col *mongo.Collection }
+
+func NewFamilyRepo(db *mongo.Database) FamilyRepo {
+	return &familyRepo{col: db.Collection("family_groups")}
+}
+
+func NewFamilyMembershipRepo(db *mongo.Database) FamilyMembershipRepo {
+	return &familyMembershipRepo{col: db.Collection("family_memberships")}
+}
+
+func NewFamilyConsentRepo(db *mongo.Database) FamilyConsentRepo {
+	return &familyConsentRepo{col: db.Collection("family_consents")}
+}
+
+func NewFamilyAccessLogRepo(db *mongo.Database) FamilyAccessLogRepo {
+	return &familyAccessLogRepo{col: db.Collection("family_access_logs")}
+}
+
+func (r *familyRepo) Create(ctx context.Context, g *model.FamilyGroup) error {
+	g.CreatedAt = time.Now()
+	g.UpdatedAt = g.CreatedAt
+	res, err := r.col.InsertOne(ctx, g)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		g.ID = oid
+	}
+	return nil
+}
+
+func (r *familyRepo) FindByID(ctx context.Context, id bson.ObjectID) (*model.FamilyGroup, error) {
+	var g model.FamilyGroup
+	if err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *familyRepo) FindByMember(ctx context.Context, userID bson.ObjectID) (*model.FamilyGroup, error) {
+	var g model.FamilyGroup
+	filter := bson.M{"members": bson.M{"$elemMatch": bson.M{"user_id": userID, "status": model.FamilyMemberActive}}}
+	if err := r.col.FindOne(ctx, filter).Decode(&g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *familyRepo) AddMember(ctx context.Context, groupID bson.ObjectID, m model.FamilyMember) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": groupID, "members.user_id": bson.M{"$ne": m.UserID}},
+		bson.M{"$push": bson.M{"members": m}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *familyRepo) ActivateMember(ctx context.Context, groupID, userID bson.ObjectID, at time.Time) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": groupID, "members": bson.M{"$elemMatch": bson.M{"user_id": userID, "status": model.FamilyMemberInvited}}},
+		bson.M{"$set": bson.M{
+			"members.$.status":    model.FamilyMemberActive,
+			"members.$.joined_at": at,
+			"updated_at":          time.Now(),
+		}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *familyRepo) RemoveMember(ctx context.Context, groupID, userID bson.ObjectID) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": groupID},
+		bson.M{"$pull": bson.M{"members": bson.M{"user_id": userID}}, "$set": bson.M{"updated_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *familyRepo) Touch(ctx context.Context, id bson.ObjectID) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"updated_at": time.Now()}})
+	return err
+}
+
+func (r *familyRepo) Delete(ctx context.Context, id bson.ObjectID) error {
+	_, err := r.col.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+func (r *familyMembershipRepo) Create(ctx context.Context, m *model.FamilyMembership) error {
+	res, err := r.col.InsertOne(ctx, m)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		m.ID = oid
+	}
+	return nil
+}
+
+func (r *familyMembershipRepo) Delete(ctx context.Context, groupID, userID bson.ObjectID) error {
+	_, err := r.col.DeleteOne(ctx, bson.M{"group_id": groupID, "user_id": userID})
+	return err
+}
+
+func (r *familyMembershipRepo) DeleteByGroup(ctx context.Context, groupID bson.ObjectID) error {
+	_, err := r.col.DeleteMany(ctx, bson.M{"group_id": groupID})
+	return err
+}
+
+func (r *familyConsentRepo) Create(ctx context.Context, c *model.FamilyConsent) error {
+	res, err := r.col.InsertOne(ctx, c)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		c.ID = oid
+	}
+	return nil
+}
+
+func (r *familyConsentRepo) FindByID(ctx context.Context, id bson.ObjectID) (*model.FamilyConsent, error) {
+	var c model.FamilyConsent
+	if err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *familyConsentRepo) FindActive(ctx context.Context, groupID, grantorID, granteeID bson.ObjectID, at time.Time) (*model.FamilyConsent, error) {
+	var c model.FamilyConsent
+	filter := activeConsents(at)
+	filter["group_id"], filter["grantor_id"], filter["grantee_id"] = groupID, grantorID, granteeID
+	opts := options.FindOne().SetSort(bson.D{{Key: "granted_at", Value: -1}})
+	if err := r.col.FindOne(ctx, filter, opts).Decode(&c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *familyConsentRepo) FindActiveByGroup(ctx context.Context, groupID bson.ObjectID, at time.Time) ([]model.FamilyConsent, error) {
+	filter := activeConsents(at)
+	filter["group_id"] = groupID
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "granted_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.FamilyConsent
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *familyConsentRepo) Revoke(ctx context.Context, id bson.ObjectID, at time.Time) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": at}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *familyConsentRepo) RevokePair(ctx context.Context, groupID, grantorID, granteeID bson.ObjectID, at time.Time) (int64, error) {
+	res, err := r.col.UpdateMany(ctx,
+		bson.M{"group_id": groupID, "grantor_id": grantorID, "grantee_id": granteeID, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": at}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *familyConsentRepo) RevokeInvolving(ctx context.Context, groupID, userID bson.ObjectID, at time.Time) (int64, error) {
+	res, err := r.col.UpdateMany(ctx,
+		bson.M{
+			"group_id":   groupID,
+			"$or":        bson.A{bson.M{"grantor_id": userID}, bson.M{"grantee_id": userID}},
+			"revoked_at": bson.M{"$exists": false},
+		},
+		bson.M{"$set": bson.M{"revoked_at": at}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// activeConsents matches consents neither revoked nor expired at the given time.
+func activeConsents(at time.Time) bson.M {
+	return bson.M{"revoked_at": bson.M{"$exists": false}, "expires_at": bson.M{"$gt": at}}
+}
+
+func (r *familyAccessLogRepo) Create(ctx context.Context, l *model.FamilyAccessLog) error {
+	l.At = time.Now()
+	res, err := r.col.InsertOne(ctx, l)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		l.ID = oid
+	}
+	return nil
+}
+
+func (r *familyAccessLogRepo) FindBySubject(ctx context.Context, subjectID bson.ObjectID, limit int64) ([]model.FamilyAccessLog, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "at", Value: -1}}).SetLimit(limit)
+	cursor, err := r.col.Find(ctx, bson.M{"subject_id": subjectID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.FamilyAccessLog
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type GoalRepo interface {
+	Create(ctx context.Context, g *model.Goal) error
+	FindByID(ctx context.Context, userID, id bson.ObjectID) (*model.Goal, error)
+	FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.Goal, error)
+	Update(ctx context.Context, g *model.Goal) error
+	Delete(ctx context.Context, userID, id bson.ObjectID) (bool, error)
+	// MigrateLinks points every holding link in fromCode at toCode.
+	MigrateLinks(ctx context.Context, fromCode, toCode string) (int64, error)
+}
+
+type goalRepo struct{ col *mongo.Collection }
+
+func NewGoalRepo(db *mongo.Database) GoalRepo { return &goalRepo{col: db.Collection("goals")} }
+
+func (r *goalRepo) Create(ctx context.Context, g *model.Goal) error {
+	g.CreatedAt = time.Now()
+	g.UpdatedAt = g.CreatedAt
+	res, err := r.col.InsertOne(ctx, g)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		g.ID = oid
+	}
+	return nil
+}
+
+func (r *goalRepo) FindByID(ctx context.Context, userID, id bson.ObjectID) (*model.Goal, error) {
+	var g model.Goal
+	if err := r.col.FindOne(ctx, bson.M{"_id": id, "user_id": userID}).Decode(&g); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+func (r *goalRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.Goal, error) {
+	cursor, err := r.col.Find(ctx, bson.M{"user_id": userID}, options.Find().SetSort(bson.D{{Key: "target_date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var goals []model.Goal
+	if err := cursor.All(ctx, &goals); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func (r *goalRepo) Update(ctx context.Context, g *model.Goal) error {
+	g.UpdatedAt = time.Now()
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": g.ID, "user_id": g.UserID}, bson.M{"$set": g})
+	return err
+}
+
+func (r *goalRepo) MigrateLinks(ctx context.Context, fromCode, toCode string) (int64, error) {
+	res, err := r.col.UpdateMany(ctx,
+		bson.M{"linked_holdings.scheme_code": fromCode},
+		bson.M{"$set": bson.M{"linked_holdings.$[l].scheme_code": toCode, "updated_at": time.Now()}},
+		options.UpdateMany().SetArrayFilters([]interface{}{bson.M{"l.scheme_code": fromCode}}),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *goalRepo) Delete(ctx context.Context, userID, id bson.ObjectID) (bool, error) {
+	res, err := r.col.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type GoldPriceRepo interface {
+	UpsertMany(ctx context.Context, prices []model.GoldPrice) error
+	FindLatest(ctx context.Context) (*model.GoldPrice, error)
+}
+
+type goldPriceRepo struct{ col *mongo.Collection }
+
+func NewGoldPriceRepo(db *mongo.Database) GoldPriceRepo {
+	return &goldPriceRepo{col: db.Collection("gold_prices")}
+}
+
+func (r *goldPriceRepo) UpsertMany(ctx context.Context, prices []model.GoldPrice) error {
+	if len(prices) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(prices))
+	for _, p := range prices {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"date": p.Date}).
+			SetUpdate(bson.M{"$set": bson.M{"price_per_gram": p.PricePerGram, "source": p.Source}}).
+			SetUpsert(true))
+	}
+	_, err := r.col.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *goldPriceRepo) FindLatest(ctx context.Context) (*model.GoldPrice, error) {
+	var p model.GoldPrice
+	opts := options.FindOne().SetSort(bson.D{{Key: "date", Value: -1}})
+	if err := r.col.FindOne(ctx, bson.M{}, opts).Decode(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type IDCWRepo interface {
+	Create(ctx context.Context, d *model.IDCWDeclaration) error
+	FindByID(ctx context.Context, id bson.ObjectID) (*model.IDCWDeclaration, error)
+	FindByStatus(ctx context.Context, status string) ([]model.IDCWDeclaration, error)
+	FindDue(ctx context.Context, asOf time.Time) ([]model.IDCWDeclaration, error)
+	// MarkProcessed moves a pending declaration to processed. It reports
+	// false if the declaration was no longer pending.
+	MarkProcessed(ctx context.Context, id bson.ObjectID, summary *model.IDCWSummary) (bool, error)
+}
+
+type idcwRepo struct{ col *mongo.Collection }
+
+func NewIDCWRepo(db *mongo.Database) IDCWRepo {
+	return &idcwRepo{col: db.Collection("idcw_declarations")}
+}
+
+func (r *idcwRepo) Create(ctx context.Context, d *model.IDCWDeclaration) error {
+	d.CreatedAt = time.Now()
+	res, err := r.col.InsertOne(ctx, d)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		d.ID = oid
+	}
+	return nil
+}
+
+func (r *idcwRepo) FindByID(ctx context.Context, id bson.ObjectID) (*model.IDCWDeclaration, error) {
+	var d model.IDCWDeclaration
+	if err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *idcwRepo) FindByStatus(ctx context.Context, status string) ([]model.IDCWDeclaration, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	return r.find(ctx, filter)
+}
+
+// FindDue returns pending declarations whose payment date has arrived.
+func (r *idcwRepo) FindDue(ctx context.Context, asOf time.Time) ([]model.IDCWDeclaration, error) {
+	return r.find(ctx, bson.M{"status": "pending", "payment_date": bson.M{"$lte": asOf}})
+}
+
+func (r *idcwRepo) MarkProcessed(ctx context.Context, id bson.ObjectID, summary *model.IDCWSummary) (bool, error) {
+	now := time.Now()
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "status": "pending"}, bson.M{"$set": bson.M{
+		"status":       "processed",
+		"summary":      summary,
+		"processed_at": now,
+	}})
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
+func (r *idcwRepo) find(ctx context.Context, filter bson.M) ([]model.IDCWDeclaration, error) {
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "record_date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.IDCWDeclaration
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
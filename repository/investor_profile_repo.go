@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type InvestorProfileRepo interface {
+	Upsert(ctx context.Context, p *model.InvestorProfile) error
+	FindByUserID(ctx context.Context, userID bson.ObjectID) (*model.InvestorProfile, error)
+}
+
+type investorProfileRepo struct{ col *mongo.Collection }
+
+func NewInvestorProfileRepo(db *mongo.Database) InvestorProfileRepo {
+	return &investorProfileRepo{col: db.Collection("investor_profiles")}
+}
+
+func (r *investorProfileRepo) Upsert(ctx context.Context, p *model.InvestorProfile) error {
+	p.UpdatedAt = time.Now()
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"user_id": p.UserID},
+		bson.M{"$set": bson.M{"name": p.Name, "pan": p.PAN, "date_of_birth": p.DateOfBirth, "updated_at": p.UpdatedAt}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *investorProfileRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) (*model.InvestorProfile, error) {
+	var p model.InvestorProfile
+	if err := r.col.FindOne(ctx, bson.M{"user_id": userID}).Decode(&p); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type ModelPortfolioRepo interface {
+	Upsert(ctx context.Context, mp *model.ModelPortfolio) error
+	FindByRiskCategory(ctx context.Context, category string) (*model.ModelPortfolio, error)
+	FindAll(ctx context.Context) ([]model.ModelPortfolio, error)
+}
+
+type modelPortfolioRepo struct{ col *mongo.Collection }
+
+func NewModelPortfolioRepo(db *mongo.Database) ModelPortfolioRepo {
+	return &modelPortfolioRepo{col: db.Collection("model_portfolios")}
+}
+
+func (r *modelPortfolioRepo) Upsert(ctx context.Context, mp *model.ModelPortfolio) error {
+	mp.UpdatedAt = time.Now()
+	update := bson.M{"$set": bson.M{
+		"name":        mp.Name,
+		"description": mp.Description,
+		"allocations": mp.Allocations,
+		"updated_at":  mp.UpdatedAt,
+	}}
+	_, err := r.col.UpdateOne(ctx, bson.M{"risk_category": mp.RiskCategory}, update, options.UpdateOne().SetUpsert(true))
+	return err
+}
+
+func (r *modelPortfolioRepo) FindByRiskCategory(ctx context.Context, category string) (*model.ModelPortfolio, error) {
+	var mp model.ModelPortfolio
+	if err := r.col.FindOne(ctx, bson.M{"risk_category": category}).Decode(&mp); err != nil {
+		return nil, err
+	}
+	return &mp, nil
+}
+
+func (r *modelPortfolioRepo) FindAll(ctx context.Context) ([]model.ModelPortfolio, error) {
+	cursor, err := r.col.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "risk_category", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var mps []model.ModelPortfolio
+	if err := cursor.All(ctx, &mps); err != nil {
+		return nil, err
+	}
+	return mps, nil
+}
@@ -23,3 +23,25 @@ func NewMongoClient(uri string) (*mongo.Client, error) {
 
 	return client, nil
 }
+
+// Transactor runs fn inside a multi-document transaction. Repository calls
+// made with the ctx passed to fn join the transaction.
+type Transactor interface {
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type mongoTransactor struct{ client *mongo.Client }
+
+func NewTransactor(client *mongo.Client) Transactor { return &mongoTransactor{client: client} }
+
+func (t *mongoTransactor) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	sess, err := t.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer sess.EndSession(ctx)
+	_, err = sess.WithTransaction(ctx, func(ctx context.Context) (interface{}, error) {
+		return nil, fn(ctx)
+	})
+	return err
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type NAVRepo interface {
+	UpsertMany(ctx context.Context, points []model.NAVPoint) error
+	FindRange(ctx context.Context, schemeCode string, from, to time.Time) ([]model.NAVPoint, error)
+}
+
+type navRepo struct{ col *mongo.Collection }
+
+func NewNAVRepo(db *mongo.Database) NAVRepo {
+	return &navRepo{col: db.Collection("nav_history")}
+}
+
+func (r *navRepo) UpsertMany(ctx context.Context, points []model.NAVPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(points))
+	for _, p := range points {
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"scheme_code": p.SchemeCode, "date": p.Date}).
+			SetUpdate(bson.M{"$set": bson.M{"nav": p.NAV}}).
+			SetUpsert(true))
+	}
+	_, err := r.col.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+// FindRange returns the scheme's NAVs in date order. Zero bounds are open.
+func (r *navRepo) FindRange(ctx context.Context, schemeCode string, from, to time.Time) ([]model.NAVPoint, error) {
+	filter := bson.M{"scheme_code": schemeCode}
+	if date := dateRange(from, to); date != nil {
+		filter["date"] = date
+	}
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var points []model.NAVPoint
+	if err := cursor.All(ctx, &points); err != nil {
+		return nil, err
+	}
+	return points, nil
+}
@@ -0,0 +1,153 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type NetWorthRepo interface {
+	Create(ctx context.Context, item *model.NetWorthItem) error
+	FindByID(ctx context.Context, userID, id bson.ObjectID) (*model.NetWorthItem, error)
+	FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.NetWorthItem, error)
+	Update(ctx context.Context, item *model.NetWorthItem) error
+	Delete(ctx context.Context, userID, id bson.ObjectID) (bool, error)
+	// UpsertFeed stores a feed item, matched on user and external reference.
+	UpsertFeed(ctx context.Context, item *model.NetWorthItem) error
+	UserIDs(ctx context.Context) ([]bson.ObjectID, error)
+}
+
+type NetWorthSnapshotRepo interface {
+	Upsert(ctx context.Context, s *model.NetWorthSnapshot) error
+	// FindByUserID returns snapshots from the given month (YYYY-MM) on, oldest first.
+	FindByUserID(ctx context.Context, userID bson.ObjectID, fromMonth string) ([]model.NetWorthSnapshot, error)
+}
+
+type netWorthRepo struct{ col *mongo.Collection }
+type netWorthSnapshotRepo struct{ col *mongo.Collection }
+
+func NewNetWorthRepo(db *mongo.Database) NetWorthRepo {
+	return &netWorthRepo{col: db.Collection("net_worth_items")}
+}
+
+func NewNetWorthSnapshotRepo(db *mongo.Database) NetWorthSnapshotRepo {
+	return &netWorthSnapshotRepo{col: db.Collection("net_worth_snapshots")}
+}
+
+func (r *netWorthRepo) Create(ctx context.Context, item *model.NetWorthItem) error {
+	item.CreatedAt = time.Now()
+	item.UpdatedAt = item.CreatedAt
+	res, err := r.col.InsertOne(ctx, item)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		item.ID = oid
+	}
+	return nil
+}
+
+func (r *netWorthRepo) FindByID(ctx context.Context, userID, id bson.ObjectID) (*model.NetWorthItem, error) {
+	var item model.NetWorthItem
+	if err := r.col.FindOne(ctx, bson.M{"_id": id, "user_id": userID}).Decode(&item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *netWorthRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.NetWorthItem, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "kind", Value: 1}, {Key: "type", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.col.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var items []model.NetWorthItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *netWorthRepo) Update(ctx context.Context, item *model.NetWorthItem) error {
+	item.UpdatedAt = time.Now()
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": item.ID, "user_id": item.UserID}, bson.M{"$set": item})
+	return err
+}
+
+func (r *netWorthRepo) Delete(ctx context.Context, userID, id bson.ObjectID) (bool, error) {
+	res, err := r.col.DeleteOne(ctx, bson.M{"_id": id, "user_id": userID})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+func (r *netWorthRepo) UpsertFeed(ctx context.Context, item *model.NetWorthItem) error {
+	now := time.Now()
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"user_id": item.UserID, "source": model.SourceFeed, "external_ref": item.ExternalRef},
+		bson.M{
+			"$set": bson.M{
+				"kind":        item.Kind,
+				"type":        item.Type,
+				"name":        item.Name,
+				"institution": item.Institution,
+				"value":       item.Value,
+				"as_of":       item.AsOf,
+				"updated_at":  now,
+			},
+			"$setOnInsert": bson.M{"created_at": now},
+		},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *netWorthRepo) UserIDs(ctx context.Context) ([]bson.ObjectID, error) {
+	return distinctUserIDs(ctx, r.col)
+}
+
+func (r *netWorthSnapshotRepo) Upsert(ctx context.Context, s *model.NetWorthSnapshot) error {
+	s.UpdatedAt = time.Now()
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"user_id": s.UserID, "month": s.Month},
+		bson.M{"$set": bson.M{
+			"assets":            s.Assets,
+			"liabilities":       s.Liabilities,
+			"total_assets":      s.TotalAssets,
+			"total_liabilities": s.TotalLiabilities,
+			"net_worth":         s.NetWorth,
+			"updated_at":        s.UpdatedAt,
+		}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *netWorthSnapshotRepo) FindByUserID(ctx context.Context, userID bson.ObjectID, fromMonth string) ([]model.NetWorthSnapshot, error) {
+	filter := bson.M{"user_id": userID, "month": bson.M{"$gte": fromMonth}}
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "month", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.NetWorthSnapshot
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// distinctUserIDs lists the users with at least one document in col.
+func distinctUserIDs(ctx context.Context, col *mongo.Collection) ([]bson.ObjectID, error) {
+	var ids []bson.ObjectID
+	if err := col.Distinct(ctx, "user_id", bson.M{}).Decode(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type NFORepo interface {
+	Create(ctx context.Context, n *model.NFO) error
+	FindByID(ctx context.Context, id bson.ObjectID) (*model.NFO, error)
+	FindByStatus(ctx context.Context, status string) ([]model.NFO, error)
+	FindOpen(ctx context.Context, asOf time.Time) ([]model.NFO, error)
+	FindDue(ctx context.Context, asOf time.Time) ([]model.NFO, error)
+	// MarkAllotted moves a pending NFO to allotted. It reports false if the
+	// NFO was no longer pending.
+	MarkAllotted(ctx context.Context, id bson.ObjectID, summary *model.NFOSummary) (bool, error)
+}
+
+type NFOOrderRepo interface {
+	Create(ctx context.Context, o *model.NFOOrder) error
+	FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.NFOOrder, error)
+	FindPending(ctx context.Context, nfoID bson.ObjectID) ([]model.NFOOrder, error)
+	// Cancel withdraws a pending order. It reports false if the order does
+	// not exist, belongs to someone else or is no longer pending.
+	Cancel(ctx context.Context, userID, id bson.ObjectID) (bool, error)
+	// MarkAllotted moves a pending order to allotted. It reports false if
+	// the order was cancelled or allotted meanwhile.
+	MarkAllotted(ctx context.Context, id bson.ObjectID, units, nav float64) (bool, error)
+}
+
+type nfoRepo struct{ col *mongo.Collection }
+type nfoOrderRepo struct{ col *mongo.Collection }
+
+func NewNFORepo(db *mongo.Database) NFORepo { return &nfoRepo{col: db.Collection("nfos")} }
+func NewNFOOrderRepo(db *mongo.Database) NFOOrderRepo {
+	return &nfoOrderRepo{col: db.Collection("nfo_orders")}
+}
+
+func (r *nfoRepo) Create(ctx context.Context, n *model.NFO) error {
+	n.CreatedAt = time.Now()
+	res, err := r.col.InsertOne(ctx, n)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		n.ID = oid
+	}
+	return nil
+}
+
+func (r *nfoRepo) FindByID(ctx context.Context, id bson.ObjectID) (*model.NFO, error) {
+	var n model.NFO
+	if err := r.col.FindOne(ctx, bson.M{"_id": id}).Decode(&n); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+func (r *nfoRepo) FindByStatus(ctx context.Context, status string) ([]model.NFO, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	return r.find(ctx, filter)
+}
+
+// FindOpen returns NFOs accepting applications on asOf.
+func (r *nfoRepo) FindOpen(ctx context.Context, asOf time.Time) ([]model.NFO, error) {
+	return r.find(ctx, bson.M{
+		"status":     "pending",
+		"open_date":  bson.M{"$lte": asOf},
+		"close_date": bson.M{"$gte": asOf},
+	})
+}
+
+// FindDue returns pending NFOs whose allotment date has arrived.
+func (r *nfoRepo) FindDue(ctx context.Context, asOf time.Time) ([]model.NFO, error) {
+	return r.find(ctx, bson.M{"status": "pending", "allotment_date": bson.M{"$lte": asOf}})
+}
+
+func (r *nfoRepo) MarkAllotted(ctx context.Context, id bson.ObjectID, summary *model.NFOSummary) (bool, error) {
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "status": "pending"}, bson.M{"$set": bson.M{
+		"status":      "allotted",
+		"summary":     summary,
+		"allotted_at": time.Now(),
+	}})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (r *nfoRepo) find(ctx context.Context, filter bson.M) ([]model.NFO, error) {
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "close_date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.NFO
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *nfoOrderRepo) Create(ctx context.Context, o *model.NFOOrder) error {
+	o.CreatedAt = time.Now()
+	res, err := r.col.InsertOne(ctx, o)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		o.ID = oid
+	}
+	return nil
+}
+
+func (r *nfoOrderRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.NFOOrder, error) {
+	return r.find(ctx, bson.M{"user_id": userID})
+}
+
+func (r *nfoOrderRepo) FindPending(ctx context.Context, nfoID bson.ObjectID) ([]model.NFOOrder, error) {
+	return r.find(ctx, bson.M{"nfo_id": nfoID, "status": "pending"})
+}
+
+func (r *nfoOrderRepo) Cancel(ctx context.Context, userID, id bson.ObjectID) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"_id": id, "user_id": userID, "status": "pending"},
+		bson.M{"$set": bson.M{"status": "cancelled"}})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (r *nfoOrderRepo) MarkAllotted(ctx context.Context, id bson.ObjectID, units, nav float64) (bool, error) {
+	res, err := r.col.UpdateOne(ctx, bson.M{"_id": id, "status": "pending"}, bson.M{"$set": bson.M{
+		"status":      "allotted",
+		"units":       units,
+		"nav":         nav,
+		"allotted_at": time.Now(),
+	}})
+	if err != nil {
+		return false, err
+	}
+	return res.MatchedCount > 0, nil
+}
+
+func (r *nfoOrderRepo) find(ctx context.Context, filter bson.M) ([]model.NFOOrder, error) {
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.NFOOrder
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
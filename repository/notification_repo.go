@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type NotificationRepo interface {
+	Create(ctx context.Context, e *model.NotificationEvent) error
+}
+
+type notificationRepo struct{ col *mongo.Collection }
+
+func NewNotificationRepo(db *mongo.Database) NotificationRepo {
+	return &notificationRepo{col: db.Collection("notification_events")}
+}
+
+func (r *notificationRepo) Create(ctx context.Context, e *model.NotificationEvent) error {
+	e.CreatedAt = time.Now()
+	res, err := r.col.InsertOne(ctx, e)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		e.ID = oid
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type NPSSchemeRepo interface {
+	Upsert(ctx context.Context, sc *model.NPSScheme) error
+	FindByCode(ctx context.Context, code string) (*model.NPSScheme, error)
+	// FindByPFM returns the manager's schemes, optionally for one tier.
+	FindByPFM(ctx context.Context, pfmCode, tier string) ([]model.NPSScheme, error)
+	FindAll(ctx context.Context) ([]model.NPSScheme, error)
+}
+
+type npsSchemeRepo struct{ col *mongo.Collection }
+
+func NewNPSSchemeRepo(db *mongo.Database) NPSSchemeRepo {
+	return &npsSchemeRepo{col: db.Collection("nps_schemes")}
+}
+
+func (r *npsSchemeRepo) Upsert(ctx context.Context, sc *model.NPSScheme) error {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"scheme_code": sc.SchemeCode},
+		bson.M{"$set": bson.M{
+			"scheme_name": sc.SchemeName,
+			"pfm_code":    sc.PFMCode,
+			"pfm_name":    sc.PFMName,
+			"asset_class": sc.AssetClass,
+			"tier":        sc.Tier,
+			"nav":         sc.NAV,
+			"nav_date":    sc.NAVDate,
+		}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *npsSchemeRepo) FindByCode(ctx context.Context, code string) (*model.NPSScheme, error) {
+	var sc model.NPSScheme
+	if err := r.col.FindOne(ctx, bson.M{"scheme_code": code}).Decode(&sc); err != nil {
+		return nil, err
+	}
+	return &sc, nil
+}
+
+func (r *npsSchemeRepo) FindByPFM(ctx context.Context, pfmCode, tier string) ([]model.NPSScheme, error) {
+	filter := bson.M{"pfm_code": pfmCode}
+	if tier != "" {
+		filter["tier"] = tier
+	}
+	return r.find(ctx, filter)
+}
+
+func (r *npsSchemeRepo) FindAll(ctx context.Context) ([]model.NPSScheme, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *npsSchemeRepo) find(ctx context.Context, filter bson.M) ([]model.NPSScheme, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "pfm_code", Value: 1}, {Key: "scheme_code", Value: 1}})
+	cursor, err := r.col.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.NPSScheme
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type PortfolioSnapshotRepo interface {
+	// Upsert stores the snapshot, replacing any earlier one for the same day.
+	Upsert(ctx context.Context, s *model.PortfolioSnapshot) error
+	// FindRange returns the user's snapshots between from and to inclusive,
+	// oldest first; zero bounds are open.
+	FindRange(ctx context.Context, userID bson.ObjectID, from, to time.Time) ([]model.PortfolioSnapshot, error)
+}
+
+type portfolioSnapshotRepo struct{ col *mongo.Collection }
+
+func NewPortfolioSnapshotRepo(db *mongo.Database) PortfolioSnapshotRepo {
+	return &portfolioSnapshotRepo{col: db.Collection("portfolio_snapshots")}
+}
+
+func (r *portfolioSnapshotRepo) Upsert(ctx context.Context, s *model.PortfolioSnapshot) error {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"user_id": s.UserID, "date": s.Date},
+		bson.M{"$set": bson.M{
+			"value":     s.Value,
+			"invested":  s.Invested,
+			"gain_loss": s.GainLoss,
+			"by_asset":  s.ByAsset,
+		}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *portfolioSnapshotRepo) FindRange(ctx context.Context, userID bson.ObjectID, from, to time.Time) ([]model.PortfolioSnapshot, error) {
+	filter := bson.M{"user_id": userID}
+	if date := dateRange(from, to); date != nil {
+		filter["date"] = date
+	}
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.PortfolioSnapshot
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type SchemeReviewRepo interface {
+	// Upsert replaces the scheme's review.
+	Upsert(ctx context.Context, r *model.SchemeReview) error
+	// MarkNotified records that every holder has been told of the scheme's
+	// flag as of the given quarter end.
+	MarkNotified(ctx context.Context, schemeCode string, asOf time.Time) error
+	FindByCodes(ctx context.Context, codes []string) ([]model.SchemeReview, error)
+}
+
+type schemeReviewRepo struct{ col *mongo.Collection }
+
+func NewSchemeReviewRepo(db *mongo.Database) SchemeReviewRepo {
+	return &schemeReviewRepo{col: db.Collection("scheme_reviews")}
+}
+
+func (r *schemeReviewRepo) Upsert(ctx context.Context, rv *model.SchemeReview) error {
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"scheme_code": rv.SchemeCode},
+		bson.M{"$set": rv},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *schemeReviewRepo) MarkNotified(ctx context.Context, schemeCode string, asOf time.Time) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"scheme_code": schemeCode}, bson.M{"$set": bson.M{"notified_as_of": asOf}})
+	return err
+}
+
+func (r *schemeReviewRepo) FindByCodes(ctx context.Context, codes []string) ([]model.SchemeReview, error) {
+	cursor, err := r.col.Find(ctx, bson.M{"scheme_code": bson.M{"$in": codes}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.SchemeReview
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type SecurityRepo interface {
+	// UpsertMany stores each security's latest close. Exchange identifiers
+	// left empty keep their stored value, so NSE and BSE files can be loaded
+	// for the same ISIN.
+	UpsertMany(ctx context.Context, secs []model.Security) error
+	FindByISIN(ctx context.Context, isin string) (*model.Security, error)
+	FindByISINs(ctx context.Context, isins []string) ([]model.Security, error)
+	// FindBySymbol matches an NSE symbol or a BSE scrip code.
+	FindBySymbol(ctx context.Context, symbol string) (*model.Security, error)
+}
+
+type securityRepo struct{ col *mongo.Collection }
+
+func NewSecurityRepo(db *mongo.Database) SecurityRepo {
+	return &securityRepo{col: db.Collection("securities")}
+}
+
+func (r *securityRepo) UpsertMany(ctx context.Context, secs []model.Security) error {
+	if len(secs) == 0 {
+		return nil
+	}
+	models := make([]mongo.WriteModel, 0, len(secs))
+	for _, s := range secs {
+		set := bson.M{
+			"name":       s.Name,
+			"kind":       s.Kind,
+			"category":   s.Category,
+			"close":      s.Close,
+			"prev_close": s.PrevClose,
+			"price_date": s.PriceDate,
+			"exchange":   s.Exchange,
+		}
+		if s.NSESymbol != "" {
+			set["nse_symbol"] = s.NSESymbol
+		}
+		if s.BSECode != "" {
+			set["bse_code"] = s.BSECode
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"isin": s.ISIN}).
+			SetUpdate(bson.M{"$set": set}).
+			SetUpsert(true))
+	}
+	_, err := r.col.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
+
+func (r *securityRepo) FindByISIN(ctx context.Context, isin string) (*model.Security, error) {
+	var s model.Security
+	if err := r.col.FindOne(ctx, bson.M{"isin": isin}).Decode(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (r *securityRepo) FindByISINs(ctx context.Context, isins []string) ([]model.Security, error) {
+	cursor, err := r.col.Find(ctx, bson.M{"isin": bson.M{"$in": isins}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var out []model.Security
+	if err := cursor.All(ctx, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *securityRepo) FindBySymbol(ctx context.Context, symbol string) (*model.Security, error) {
+	var s model.Security
+	filter := bson.M{"$or": bson.A{bson.M{"nse_symbol": symbol}, bson.M{"bse_code": symbol}}}
+	if err := r.col.FindOne(ctx, filter).Decode(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
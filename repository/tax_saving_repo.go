@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type TaxSavingRepo interface {
+	// Upsert replaces the user's declaration for the financial year.
+	Upsert(ctx context.Context, d *model.TaxSavingDeclaration) error
+	Find(ctx context.Context, userID bson.ObjectID, financialYear string) (*model.TaxSavingDeclaration, error)
+}
+
+type taxSavingRepo struct{ col *mongo.Collection }
+
+func NewTaxSavingRepo(db *mongo.Database) TaxSavingRepo {
+	return &taxSavingRepo{col: db.Collection("tax_saving_declarations")}
+}
+
+func (r *taxSavingRepo) Upsert(ctx context.Context, d *model.TaxSavingDeclaration) error {
+	d.UpdatedAt = time.Now()
+	_, err := r.col.UpdateOne(ctx,
+		bson.M{"user_id": d.UserID, "financial_year": d.FinancialYear},
+		bson.M{"$set": bson.M{"items": d.Items, "updated_at": d.UpdatedAt}},
+		options.UpdateOne().SetUpsert(true),
+	)
+	return err
+}
+
+func (r *taxSavingRepo) Find(ctx context.Context, userID bson.ObjectID, financialYear string) (*model.TaxSavingDeclaration, error) {
+	var d model.TaxSavingDeclaration
+	if err := r.col.FindOne(ctx, bson.M{"user_id": userID, "financial_year": financialYear}).Decode(&d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type TransactionRepo interface {
+	Create(ctx context.Context, t *model.Transaction) error
+	FindByUserID(ctx context.Context, userID bson.ObjectID, from, to time.Time) ([]model.Transaction, error)
+	SumUnitsAfter(ctx context.Context, userID bson.ObjectID, schemeCode string, after time.Time) (float64, error)
+	// UserIDsWithUnitsAfter lists the users with unit movements in a scheme
+	// strictly after the given date.
+	UserIDsWithUnitsAfter(ctx context.Context, schemeCode string, after time.Time) ([]bson.ObjectID, error)
+	SumAmount(ctx context.Context, userID bson.ObjectID, amc string, types []string, from, to time.Time) (float64, error)
+	ExistsByReference(ctx context.Context, userID bson.ObjectID, reference string) (bool, error)
+	// UserIDsInScheme lists the users with any ledger entry in the scheme.
+	UserIDsInScheme(ctx context.Context, schemeCode string) ([]bson.ObjectID, error)
+	MigrateScheme(ctx context.Context, userID bson.ObjectID, fromCode, toCode, toName string, ratio float64) (int64, error)
+}
+
+type transactionRepo struct{ col *mongo.Collection }
+
+func NewTransactionRepo(db *mongo.Database) TransactionRepo {
+	return &transactionRepo{col: db.Collection("transactions")}
+}
+
+func (r *transactionRepo) Create(ctx context.Context, t *model.Transaction) error {
+	t.CreatedAt = time.Now()
+	res, err := r.col.InsertOne(ctx, t)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		t.ID = oid
+	}
+	return nil
+}
+
+// FindByUserID returns the user's ledger in date order. Zero bounds are open.
+func (r *transactionRepo) FindByUserID(ctx context.Context, userID bson.ObjectID, from, to time.Time) ([]model.Transaction, error) {
+	filter := bson.M{"user_id": userID}
+	if date := dateRange(from, to); date != nil {
+		filter["date"] = date
+	}
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "date", Value: 1}, {Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var txns []model.Transaction
+	if err := cursor.All(ctx, &txns); err != nil {
+		return nil, err
+	}
+	return txns, nil
+}
+
+// SumUnitsAfter is the net unit movement in a scheme strictly after the given
+// date, used to roll current holdings back to a record date.
+func (r *transactionRepo) SumUnitsAfter(ctx context.Context, userID bson.ObjectID, schemeCode string, after time.Time) (float64, error) {
+	return r.sum(ctx, bson.M{
+		"user_id":     userID,
+		"scheme_code": schemeCode,
+		"date":        bson.M{"$gt": after},
+	}, "$units")
+}
+
+func (r *transactionRepo) UserIDsWithUnitsAfter(ctx context.Context, schemeCode string, after time.Time) ([]bson.ObjectID, error) {
+	var ids []bson.ObjectID
+	filter := bson.M{"scheme_code": schemeCode, "date": bson.M{"$gt": after}, "units": bson.M{"$ne": 0}}
+	if err := r.col.Distinct(ctx, "user_id", filter).Decode(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *transactionRepo) SumAmount(ctx context.Context, userID bson.ObjectID, amc string, types []string, from, to time.Time) (float64, error) {
+	filter := bson.M{"user_id": userID, "type": bson.M{"$in": types}}
+	if amc != "" {
+		filter["amc"] = amc
+	}
+	if date := dateRange(from, to); date != nil {
+		filter["date"] = date
+	}
+	return r.sum(ctx, filter, "$amount")
+}
+
+func (r *transactionRepo) ExistsByReference(ctx context.Context, userID bson.ObjectID, reference string) (bool, error) {
+	n, err := r.col.CountDocuments(ctx, bson.M{"user_id": userID, "reference": reference}, options.Count().SetLimit(1))
+	return n > 0, err
+}
+
+func (r *transactionRepo) UserIDsInScheme(ctx context.Context, schemeCode string) ([]bson.ObjectID, error) {
+	var ids []bson.ObjectID
+	if err := r.col.Distinct(ctx, "user_id", bson.M{"scheme_code": schemeCode}).Decode(&ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// MigrateScheme restates every one of the user's ledger entries in fromCode as the surviving
+// scheme: units are multiplied and NAV divided by ratio so amounts are
+// unchanged, and the first pre-merger code is preserved.
+func (r *transactionRepo) MigrateScheme(ctx context.Context, userID bson.ObjectID, fromCode, toCode, toName string, ratio float64) (int64, error) {
+	res, err := r.col.UpdateMany(ctx, bson.M{"user_id": userID, "scheme_code": fromCode}, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"scheme_code":          toCode,
+			"scheme_name":          toName,
+			"original_scheme_code": bson.M{"$ifNull": bson.A{"$original_scheme_code", fromCode}},
+			"units":                bson.M{"$multiply": bson.A{"$units", ratio}},
+			"nav":                  bson.M{"$divide": bson.A{"$nav", ratio}},
+		}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *transactionRepo) sum(ctx context.Context, filter bson.M, field string) (float64, error) {
+	cursor, err := r.col.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "total": bson.M{"$sum": field}}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+	var out []struct {
+		Total float64 `bson:"total"`
+	}
+	if err := cursor.All(ctx, &out); err != nil {
+		return 0, err
+	}
+	if len(out) == 0 {
+		return 0, nil
+	}
+	return out[0].Total, nil
+}
+
+// dateRange builds an inclusive date filter; zero bounds are left open.
+func dateRange(from, to time.Time) bson.M {
+	if from.IsZero() && to.IsZero() {
+		return nil
+	}
+	m := bson.M{}
+	if !from.IsZero() {
+		m["$gte"] = from
+	}
+	if !to.IsZero() {
+		m["$lte"] = to
+	}
+	return m
+}
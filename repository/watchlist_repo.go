@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type WatchlistRepo interface {
+	// Upsert adds the scheme or, if already watched, updates its alerts
+	// while keeping the original add date and NAV.
+	Upsert(ctx context.Context, item *model.WatchlistItem) error
+	FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.WatchlistItem, error)
+	FindByScheme(ctx context.Context, schemeCode string) ([]model.WatchlistItem, error)
+	SetAlertState(ctx context.Context, id bson.ObjectID, dropAlerted bool, lowAlertedFor time.Time) error
+	Delete(ctx context.Context, userID bson.ObjectID, schemeCode string) (bool, error)
+	// MigrateScheme moves entries in fromCode to the surviving scheme,
+	// restating the NAV at add by ratio and resetting alert state. Users
+	// already watching toCode keep that entry and lose the old one. For a
+	// rename fromCode and toCode are the same.
+	MigrateScheme(ctx context.Context, fromCode, toCode, toName string, ratio float64) (int64, error)
+}
+
+type watchlistRepo struct{ col *mongo.Collection }
+
+func NewWatchlistRepo(db *mongo.Database) WatchlistRepo {
+	return &watchlistRepo{col: db.Collection("watchlist")}
+}
+
+func (r *watchlistRepo) Upsert(ctx context.Context, item *model.WatchlistItem) error {
+	filter := bson.M{"user_id": item.UserID, "scheme_code": item.SchemeCode}
+	update := bson.M{
+		"$set": bson.M{"alerts": item.Alerts, "scheme_name": item.SchemeName, "drop_alerted": false},
+		"$setOnInsert": bson.M{
+			"added_at":        item.AddedAt,
+			"nav_at_add":      item.NAVAtAdd,
+			"low_alerted_for": time.Time{},
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	return r.col.FindOneAndUpdate(ctx, filter, update, opts).Decode(item)
+}
+
+func (r *watchlistRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.WatchlistItem, error) {
+	return r.find(ctx, bson.M{"user_id": userID})
+}
+
+func (r *watchlistRepo) FindByScheme(ctx context.Context, schemeCode string) ([]model.WatchlistItem, error) {
+	return r.find(ctx, bson.M{"scheme_code": schemeCode})
+}
+
+func (r *watchlistRepo) MigrateScheme(ctx context.Context, fromCode, toCode, toName string, ratio float64) (int64, error) {
+	if fromCode == toCode {
+		res, err := r.col.UpdateMany(ctx, bson.M{"scheme_code": fromCode}, bson.M{"$set": bson.M{"scheme_name": toName}})
+		if err != nil {
+			return 0, err
+		}
+		return res.ModifiedCount, nil
+	}
+	var watching []bson.ObjectID
+	if err := r.col.Distinct(ctx, "user_id", bson.M{"scheme_code": toCode}).Decode(&watching); err != nil {
+		return 0, err
+	}
+	if len(watching) > 0 {
+		if _, err := r.col.DeleteMany(ctx, bson.M{"scheme_code": fromCode, "user_id": bson.M{"$in": watching}}); err != nil {
+			return 0, err
+		}
+	}
+	res, err := r.col.UpdateMany(ctx, bson.M{"scheme_code": fromCode}, mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"scheme_code":     toCode,
+			"scheme_name":     toName,
+			"nav_at_add":      bson.M{"$divide": bson.A{"$nav_at_add", ratio}},
+			"drop_alerted":    false,
+			"low_alerted_for": time.Time{},
+		}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+func (r *watchlistRepo) find(ctx context.Context, filter bson.M) ([]model.WatchlistItem, error) {
+	cursor, err := r.col.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "added_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var items []model.WatchlistItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *watchlistRepo) SetAlertState(ctx context.Context, id bson.ObjectID, dropAlerted bool, lowAlertedFor time.Time) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{
+		"drop_alerted":    dropAlerted,
+		"low_alerted_for": lowAlertedFor,
+	}})
+	return err
+}
+
+func (r *watchlistRepo) Delete(ctx context.Context, userID bson.ObjectID, schemeCode string) (bool, error) {
+	res, err := r.col.DeleteOne(ctx, bson.M{"user_id": userID, "scheme_code": schemeCode})
+	if err != nil {
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
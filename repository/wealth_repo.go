@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/banking-superapp/wealth-service/model"
@@ -13,16 +14,45 @@ import (
 type MFSchemeRepo interface {
 	FindAll(ctx context.Context, category string) ([]model.MFScheme, error)
 	FindByCode(ctx context.Context, code string) (*model.MFScheme, error)
+	FindByFundCode(ctx context.Context, fundCode string) ([]model.MFScheme, error)
+	Create(ctx context.Context, sc *model.MFScheme) error
+	SetActive(ctx context.Context, code string, active bool) error
+	Rename(ctx context.Context, code, name string) error
+	SetBenchmark(ctx context.Context, code, benchmarkCode string) error
+	UpdateNAV(ctx context.Context, code string, nav float64, date time.Time) (bool, error)
 }
 
 type SIPRepo interface {
 	Create(ctx context.Context, s *model.SIP) error
 	FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.SIP, error)
+	MigrateScheme(ctx context.Context, fromCode, toCode, toName string) (int64, error)
 }
 
 type PortfolioRepo interface {
 	FindByUserID(ctx context.Context, userID bson.ObjectID) (*model.Portfolio, error)
+	FindHolders(ctx context.Context, schemeCode string) ([]model.Portfolio, error)
+	// FindWithMaturingDeposits returns portfolios holding a deposit that
+	// matures by the given time and has not had its reminder sent, whether
+	// or not it has been marked matured yet.
+	FindWithMaturingDeposits(ctx context.Context, by time.Time) ([]model.Portfolio, error)
+	FindByAssetType(ctx context.Context, assetType string) ([]model.Portfolio, error)
+	// FindAssetHolders returns every portfolio holding the given non-fund asset.
+	FindAssetHolders(ctx context.Context, assetType, assetID string) ([]model.Portfolio, error)
+	UserIDs(ctx context.Context) ([]bson.ObjectID, error)
+	// HeldSchemeCodes lists every scheme code held in any portfolio.
+	HeldSchemeCodes(ctx context.Context) ([]string, error)
 	Upsert(ctx context.Context, p *model.Portfolio) error
+	// ReplaceFundHoldings rewrites p's fund holdings in the given schemes and
+	// its totals, leaving every other holding as stored. Run it in the
+	// transaction that read p.
+	ReplaceFundHoldings(ctx context.Context, p *model.Portfolio, schemeCodes ...string) error
+	// UpdateValuation writes only the prices, values and totals of p. The
+	// values come from the units p was read with, so run it in the
+	// transaction that read p or a concurrent trade's value is overwritten.
+	UpdateValuation(ctx context.Context, p *model.Portfolio) error
+	// MarkDepositReminded sets reminder_sent on one deposit, reporting false
+	// when it was already set.
+	MarkDepositReminded(ctx context.Context, userID bson.ObjectID, depositID string) (bool, error)
 }
 
 type RiskProfileRepo interface {
@@ -64,11 +94,68 @@ func (r *mfSchemeRepo) FindByCode(ctx context.Context, code string) (*model.MFSc
 	return &s, nil
 }
 
+func (r *mfSchemeRepo) FindByFundCode(ctx context.Context, fundCode string) ([]model.MFScheme, error) {
+	cursor, err := r.col.Find(ctx, bson.M{"fund_code": fundCode, "is_active": true})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var schemes []model.MFScheme
+	if err := cursor.All(ctx, &schemes); err != nil {
+		return nil, err
+	}
+	return schemes, nil
+}
+
+func (r *mfSchemeRepo) Create(ctx context.Context, sc *model.MFScheme) error {
+	res, err := r.col.InsertOne(ctx, sc)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		sc.ID = oid
+	}
+	return nil
+}
+
+func (r *mfSchemeRepo) SetActive(ctx context.Context, code string, active bool) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"scheme_code": code}, bson.M{"$set": bson.M{"is_active": active}})
+	return err
+}
+
+func (r *mfSchemeRepo) Rename(ctx context.Context, code, name string) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"scheme_code": code}, bson.M{"$set": bson.M{"scheme_name": name}})
+	return err
+}
+
+func (r *mfSchemeRepo) SetBenchmark(ctx context.Context, code, benchmarkCode string) error {
+	_, err := r.col.UpdateOne(ctx, bson.M{"scheme_code": code}, bson.M{"$set": bson.M{"benchmark_code": benchmarkCode}})
+	return err
+}
+
+// UpdateNAV sets the scheme's latest NAV unless a newer one is already stored.
+func (r *mfSchemeRepo) UpdateNAV(ctx context.Context, code string, nav float64, date time.Time) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"scheme_code": code, "nav_date": bson.M{"$lte": date}},
+		bson.M{"$set": bson.M{"nav": nav, "nav_date": date}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
 func (r *sipRepo) Create(ctx context.Context, s *model.SIP) error {
 	s.CreatedAt = time.Now()
 	s.UpdatedAt = time.Now()
-	_, err := r.col.InsertOne(ctx, s)
-	return err
+	res, err := r.col.InsertOne(ctx, s)
+	if err != nil {
+		return err
+	}
+	if oid, ok := res.InsertedID.(bson.ObjectID); ok {
+		s.ID = oid
+	}
+	return nil
 }
 
 func (r *sipRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]model.SIP, error) {
@@ -82,6 +169,19 @@ func (r *sipRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) ([]mod
 	return sips, nil
 }
 
+// MigrateScheme points every SIP in fromCode at the surviving scheme. For a
+// rename fromCode and toCode are the same.
+func (r *sipRepo) MigrateScheme(ctx context.Context, fromCode, toCode, toName string) (int64, error) {
+	res, err := r.col.UpdateMany(ctx,
+		bson.M{"scheme_code": fromCode},
+		bson.M{"$set": bson.M{"scheme_code": toCode, "scheme_name": toName, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
 func (r *portfolioRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) (*model.Portfolio, error) {
 	var p model.Portfolio
 	err := r.col.FindOne(ctx, bson.M{"user_id": userID}).Decode(&p)
@@ -91,6 +191,78 @@ func (r *portfolioRepo) FindByUserID(ctx context.Context, userID bson.ObjectID)
 	return &p, nil
 }
 
+// FindHolders returns every portfolio with a holding in the scheme.
+func (r *portfolioRepo) FindHolders(ctx context.Context, schemeCode string) ([]model.Portfolio, error) {
+	cursor, err := r.col.Find(ctx, bson.M{"holdings.scheme_code": schemeCode})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var portfolios []model.Portfolio
+	if err := cursor.All(ctx, &portfolios); err != nil {
+		return nil, err
+	}
+	return portfolios, nil
+}
+
+func (r *portfolioRepo) FindByAssetType(ctx context.Context, assetType string) ([]model.Portfolio, error) {
+	cursor, err := r.col.Find(ctx, bson.M{"holdings.asset_type": assetType})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var portfolios []model.Portfolio
+	if err := cursor.All(ctx, &portfolios); err != nil {
+		return nil, err
+	}
+	return portfolios, nil
+}
+
+func (r *portfolioRepo) FindAssetHolders(ctx context.Context, assetType, assetID string) ([]model.Portfolio, error) {
+	cursor, err := r.col.Find(ctx, bson.M{"holdings": bson.M{"$elemMatch": bson.M{
+		"asset_type": assetType,
+		"asset_id":   assetID,
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var portfolios []model.Portfolio
+	if err := cursor.All(ctx, &portfolios); err != nil {
+		return nil, err
+	}
+	return portfolios, nil
+}
+
+func (r *portfolioRepo) UserIDs(ctx context.Context) ([]bson.ObjectID, error) {
+	return distinctUserIDs(ctx, r.col)
+}
+
+func (r *portfolioRepo) HeldSchemeCodes(ctx context.Context) ([]string, error) {
+	var codes []string
+	if err := r.col.Distinct(ctx, "holdings.scheme_code", bson.M{}).Decode(&codes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (r *portfolioRepo) FindWithMaturingDeposits(ctx context.Context, by time.Time) ([]model.Portfolio, error) {
+	cursor, err := r.col.Find(ctx, bson.M{"holdings": bson.M{"$elemMatch": bson.M{
+		"asset_type":       model.AssetFixedDeposit,
+		"fd.reminder_sent": false,
+		"fd.maturity_date": bson.M{"$lte": by},
+	}}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	var portfolios []model.Portfolio
+	if err := cursor.All(ctx, &portfolios); err != nil {
+		return nil, err
+	}
+	return portfolios, nil
+}
+
 func (r *portfolioRepo) Upsert(ctx context.Context, p *model.Portfolio) error {
 	p.UpdatedAt = time.Now()
 	_, err := r.col.UpdateOne(ctx,
@@ -101,6 +273,104 @@ func (r *portfolioRepo) Upsert(ctx context.Context, p *model.Portfolio) error {
 	return err
 }
 
+func (r *portfolioRepo) ReplaceFundHoldings(ctx context.Context, p *model.Portfolio, schemeCodes ...string) error {
+	fund := bson.M{"scheme_code": bson.M{"$in": schemeCodes}, "asset_id": bson.M{"$exists": false}}
+	if _, err := r.col.UpdateOne(ctx, bson.M{"user_id": p.UserID}, bson.M{"$pull": bson.M{"holdings": fund}}); err != nil {
+		return err
+	}
+	keep := []model.Holding{}
+	for _, h := range p.Holdings {
+		if h.AssetID != "" {
+			continue
+		}
+		for _, code := range schemeCodes {
+			if h.SchemeCode == code {
+				keep = append(keep, h)
+				break
+			}
+		}
+	}
+	p.UpdatedAt = time.Now()
+	_, err := r.col.UpdateOne(ctx, bson.M{"user_id": p.UserID}, bson.M{
+		"$push": bson.M{"holdings": bson.M{"$each": keep}},
+		"$set": bson.M{
+			"total_value":  p.TotalValue,
+			"total_return": p.TotalReturn,
+			"return_pct":   p.ReturnPct,
+			"updated_at":   p.UpdatedAt,
+		},
+	})
+	return err
+}
+
+// UpdateValuation matches each holding by scheme code (funds) or asset ID
+// (everything else) and NPS schemes by scheme code, through array filters.
+func (r *portfolioRepo) UpdateValuation(ctx context.Context, p *model.Portfolio) error {
+	set := bson.M{
+		"total_value":  p.TotalValue,
+		"total_return": p.TotalReturn,
+		"return_pct":   p.ReturnPct,
+		"updated_at":   time.Now(),
+	}
+	var filters []interface{}
+	for i, h := range p.Holdings {
+		id := fmt.Sprintf("h%d", i)
+		if h.AssetID == "" {
+			filters = append(filters, bson.M{id + ".scheme_code": h.SchemeCode, id + ".asset_id": bson.M{"$exists": false}})
+		} else {
+			filters = append(filters, bson.M{id + ".asset_id": h.AssetID})
+		}
+		path := "holdings.$[" + id + "]."
+		set[path+"current_nav"] = h.CurrentNAV
+		set[path+"current_value"] = h.CurrentValue
+		set[path+"gain_loss"] = h.GainLoss
+		switch {
+		case h.FD != nil:
+			set[path+"fd.interest_paid"] = h.FD.InterestPaid
+			set[path+"fd.status"] = h.FD.Status
+		case h.Gold != nil:
+			set[path+"gold.price_date"] = h.Gold.PriceDate
+			set[path+"gold.interest_paid"] = h.Gold.InterestPaid
+			if h.Gold.NextExitWindow != nil {
+				set[path+"gold.next_exit_window"] = h.Gold.NextExitWindow
+			}
+		case h.Equity != nil:
+			set[path+"category"] = h.Category
+			set[path+"equity.price_date"] = h.Equity.PriceDate
+		case h.NPS != nil:
+			set[path+"nps.lock_in"] = h.NPS.LockIn
+			for j, su := range h.NPS.Schemes {
+				sid := fmt.Sprintf("%ss%d", id, j)
+				filters = append(filters, bson.M{sid + ".scheme_code": su.SchemeCode})
+				set[path+"nps.schemes.$["+sid+"].nav"] = su.NAV
+				set[path+"nps.schemes.$["+sid+"].value"] = su.Value
+			}
+		}
+	}
+	opts := options.UpdateOne()
+	if len(filters) > 0 {
+		opts.SetArrayFilters(filters)
+	}
+	_, err := r.col.UpdateOne(ctx, bson.M{"user_id": p.UserID}, bson.M{"$set": set}, opts)
+	return err
+}
+
+func (r *portfolioRepo) MarkDepositReminded(ctx context.Context, userID bson.ObjectID, depositID string) (bool, error) {
+	res, err := r.col.UpdateOne(ctx,
+		bson.M{"user_id": userID},
+		bson.M{"$set": bson.M{"holdings.$[h].fd.reminder_sent": true}},
+		options.UpdateOne().SetArrayFilters([]interface{}{bson.M{
+			"h.asset_type":       model.AssetFixedDeposit,
+			"h.asset_id":         depositID,
+			"h.fd.reminder_sent": false,
+		}}),
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
+
 func (r *riskProfileRepo) FindByUserID(ctx context.Context, userID bson.ObjectID) (*model.RiskProfile, error) {
 	var rp model.RiskProfile
 	err := r.col.FindOne(ctx, bson.M{"user_id": userID}).Decode(&rp)
@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Every runs fn once per interval until ctx is cancelled. Jobs are expected to
+// be idempotent: a failed run is logged and simply retried on the next tick.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				start := time.Now()
+				if err := fn(ctx); err != nil {
+					log.Printf("job %s failed: %v", name, err)
+					continue
+				}
+				log.Printf("job %s completed in %s", name, time.Since(start).Round(time.Millisecond))
+			}
+		}
+	}()
+}
+
+// Daily runs fn once a day at the given offset from midnight in loc, until
+// ctx is cancelled. A failed run is logged and retried the next day.
+func Daily(ctx context.Context, name string, at time.Duration, loc *time.Location, fn func(ctx context.Context) error) {
+	go func() {
+		for {
+			timer := time.NewTimer(time.Until(nextRun(time.Now().In(loc), at)))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				start := time.Now()
+				if err := fn(ctx); err != nil {
+					log.Printf("job %s failed: %v", name, err)
+					continue
+				}
+				log.Printf("job %s completed in %s", name, time.Since(start).Round(time.Millisecond))
+			}
+		}
+	}()
+}
+
+// nextRun is the first time after now that falls at the offset past midnight.
+func nextRun(now time.Time, at time.Duration) time.Time {
+	y, m, d := now.Date()
+	next := time.Date(y, m, d, 0, 0, 0, 0, now.Location()).Add(at)
+	if !next.After(now) {
+		next = time.Date(y, m, d+1, 0, 0, 0, 0, now.Location()).Add(at)
+	}
+	return next
+}
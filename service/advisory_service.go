@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrModelPortfolioNotFound = errors.New("no model portfolio for risk category")
+	ErrRiskProfileRequired    = errors.New("risk profile not assessed")
+	ErrBudgetTooLow           = errors.New("budget below the minimum SIP of every recommended scheme")
+)
+
+var riskCategories = map[string]bool{"conservative": true, "moderate": true, "aggressive": true}
+
+type AdvisoryService interface {
+	SaveModelPortfolio(ctx context.Context, riskCategory string, req *model.ModelPortfolioRequest) (*model.ModelPortfolio, error)
+	ListModelPortfolios(ctx context.Context) ([]model.ModelPortfolio, error)
+	Recommend(ctx context.Context, userID string, budget float64) (*model.Recommendation, error)
+	InvestRecommendation(ctx context.Context, userID string, req *model.InvestRecommendationRequest) ([]model.SIP, error)
+}
+
+type advisoryService struct {
+	modelRepo repository.ModelPortfolioRepo
+	riskRepo  repository.RiskProfileRepo
+	mfRepo    repository.MFSchemeRepo
+	sipRepo   repository.SIPRepo
+	tx        repository.Transactor
+}
+
+func NewAdvisoryService(mpr repository.ModelPortfolioRepo, rr repository.RiskProfileRepo, mr repository.MFSchemeRepo, sr repository.SIPRepo, tx repository.Transactor) AdvisoryService {
+	return &advisoryService{mpr, rr, mr, sr, tx}
+}
+
+func (s *advisoryService) SaveModelPortfolio(ctx context.Context, riskCategory string, req *model.ModelPortfolioRequest) (*model.ModelPortfolio, error) {
+	if !riskCategories[riskCategory] || req.Name == "" || len(req.Allocations) == 0 {
+		return nil, ErrInvalidRequest
+	}
+	mp := &model.ModelPortfolio{RiskCategory: riskCategory, Name: req.Name, Description: req.Description}
+	seen := make(map[string]bool)
+	total := 0.0
+	for _, a := range req.Allocations {
+		if a.WeightPct <= 0 || seen[a.SchemeCode] {
+			return nil, ErrInvalidRequest
+		}
+		seen[a.SchemeCode] = true
+		sc, err := s.mfRepo.FindByCode(ctx, a.SchemeCode)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, ErrSchemeNotFound
+			}
+			return nil, err
+		}
+		if !sc.IsActive {
+			return nil, ErrSchemeNotFound
+		}
+		mp.Allocations = append(mp.Allocations, model.ModelAllocation{
+			SchemeCode: sc.SchemeCode,
+			SchemeName: sc.SchemeName,
+			Category:   sc.Category,
+			WeightPct:  a.WeightPct,
+		})
+		total += a.WeightPct
+	}
+	if math.Abs(total-100) > 0.01 {
+		return nil, ErrInvalidRequest
+	}
+
+	if err := s.modelRepo.Upsert(ctx, mp); err != nil {
+		return nil, err
+	}
+	return s.modelRepo.FindByRiskCategory(ctx, riskCategory)
+}
+
+func (s *advisoryService) ListModelPortfolios(ctx context.Context) ([]model.ModelPortfolio, error) {
+	mps, err := s.modelRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if mps == nil {
+		mps = []model.ModelPortfolio{}
+	}
+	return mps, nil
+}
+
+func (s *advisoryService) Recommend(ctx context.Context, userID string, budget float64) (*model.Recommendation, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	return s.recommend(ctx, oid, budget)
+}
+
+// InvestRecommendation creates every recommended SIP or none of them.
+func (s *advisoryService) InvestRecommendation(ctx context.Context, userID string, req *model.InvestRecommendationRequest) ([]model.SIP, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	rec, err := s.recommend(ctx, oid, req.MonthlyBudget)
+	if err != nil {
+		return nil, err
+	}
+
+	startDate := req.StartDate
+	if startDate.IsZero() {
+		startDate = time.Now().AddDate(0, 1, 0)
+	}
+	var sips []model.SIP
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		sips = sips[:0] // the driver may retry the callback
+		for _, r := range rec.SIPs {
+			sip := model.SIP{
+				UserID:      oid,
+				SchemeCode:  r.SchemeCode,
+				SchemeName:  r.SchemeName,
+				Amount:      r.Amount,
+				Frequency:   "monthly",
+				StartDate:   startDate,
+				NextSIPDate: startDate,
+				Status:      "active",
+			}
+			if err := s.sipRepo.Create(ctx, &sip); err != nil {
+				return err
+			}
+			sips = append(sips, sip)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sips, nil
+}
+
+func (s *advisoryService) recommend(ctx context.Context, userID bson.ObjectID, budget float64) (*model.Recommendation, error) {
+	if budget <= 0 {
+		return nil, ErrInvalidRequest
+	}
+	rp, err := s.riskRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrRiskProfileRequired
+		}
+		return nil, err
+	}
+	mp, err := s.modelRepo.FindByRiskCategory(ctx, rp.RiskCategory)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrModelPortfolioNotFound
+		}
+		return nil, err
+	}
+
+	var candidates []model.RecommendedSIP
+	rec := &model.Recommendation{
+		RiskCategory:  rp.RiskCategory,
+		ModelName:     mp.Name,
+		MonthlyBudget: budget,
+		Skipped:       []model.RecommendedSIP{},
+	}
+	for _, a := range mp.Allocations {
+		r := model.RecommendedSIP{SchemeCode: a.SchemeCode, SchemeName: a.SchemeName, Category: a.Category, WeightPct: a.WeightPct}
+		sc, err := s.mfRepo.FindByCode(ctx, a.SchemeCode)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		if err != nil || !sc.IsActive {
+			rec.Skipped = append(rec.Skipped, r)
+			continue
+		}
+		r.SchemeName, r.MinSIP = sc.SchemeName, sc.MinSIP
+		candidates = append(candidates, r)
+	}
+
+	rec.SIPs, rec.Skipped = splitBudget(budget, candidates, rec.Skipped)
+	if len(rec.SIPs) == 0 {
+		return nil, ErrBudgetTooLow
+	}
+	return rec, nil
+}
+
+// splitBudget shares budget by weight in whole rupees. While any share falls
+// below its scheme's MinSIP the lightest such scheme is dropped and the rest
+// reweighted, so the budget concentrates in the model's core holdings.
+// Rounding leftovers go to the heaviest scheme.
+func splitBudget(budget float64, sips, skipped []model.RecommendedSIP) ([]model.RecommendedSIP, []model.RecommendedSIP) {
+	for {
+		total := 0.0
+		for _, r := range sips {
+			total += r.WeightPct
+		}
+		drop := -1
+		for i, r := range sips {
+			if budget*r.WeightPct/total < r.MinSIP && (drop < 0 || r.WeightPct < sips[drop].WeightPct) {
+				drop = i
+			}
+		}
+		if drop < 0 {
+			out := make([]model.RecommendedSIP, len(sips))
+			spent, heaviest := 0.0, 0
+			for i, r := range sips {
+				r.Amount = math.Floor(budget * r.WeightPct / total)
+				r.WeightPct = roundAmount(r.WeightPct / total * 100)
+				spent += r.Amount
+				if r.WeightPct > out[heaviest].WeightPct {
+					heaviest = i
+				}
+				out[i] = r
+			}
+			if len(out) > 0 {
+				out[heaviest].Amount += math.Floor(budget - spent)
+			}
+			return out, skipped
+		}
+		skipped = append(skipped, sips[drop])
+		sips = append(sips[:drop:drop], sips[drop+1:]...)
+	}
+}
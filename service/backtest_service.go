@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"github.com/banking-superapp/wealth-service/service/finmath"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const maxBacktestSchemes = 5
+
+type BacktestService interface {
+	Backtest(ctx context.Context, req *model.BacktestRequest) ([]model.BacktestResult, error)
+}
+
+type backtestService struct {
+	mfRepo  repository.MFSchemeRepo
+	navRepo repository.NAVRepo
+}
+
+func NewBacktestService(mr repository.MFSchemeRepo, nr repository.NAVRepo) BacktestService {
+	return &backtestService{mr, nr}
+}
+
+func (s *backtestService) Backtest(ctx context.Context, req *model.BacktestRequest) ([]model.BacktestResult, error) {
+	codes := dedupe(req.SchemeCodes)
+	if len(codes) == 0 || len(codes) > maxBacktestSchemes || req.Amount <= 0 || req.StartDate.IsZero() ||
+		!validStepUp(req.StepUpPct) || (!req.EndDate.IsZero() && !req.EndDate.After(req.StartDate)) {
+		return nil, ErrInvalidRequest
+	}
+	switch req.Mode {
+	case "", "sip":
+		if req.Frequency == "" {
+			req.Frequency = "monthly"
+		}
+		if req.Frequency != "monthly" && req.Frequency != "weekly" {
+			return nil, ErrInvalidRequest
+		}
+	case "lumpsum":
+	default:
+		return nil, ErrInvalidRequest
+	}
+
+	results := make([]model.BacktestResult, 0, len(codes))
+	for _, code := range codes {
+		sc, err := s.mfRepo.FindByCode(ctx, code)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, ErrSchemeNotFound
+			}
+			return nil, err
+		}
+		points, err := s.navRepo.FindRange(ctx, code, req.StartDate, req.EndDate)
+		if err != nil {
+			return nil, err
+		}
+		// History must cover the start date, not merely begin somewhere after it.
+		if len(points) == 0 || points[0].Date.Sub(req.StartDate) > 7*24*time.Hour {
+			return nil, ErrInsufficientHistory
+		}
+		res := backtest(points, req)
+		res.SchemeCode, res.SchemeName = sc.SchemeCode, sc.SchemeName
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+// backtest replays purchases on the scheduled dates, executing each at the
+// first NAV on or after its date, and values the holding at the last NAV.
+func backtest(points []model.NAVPoint, req *model.BacktestRequest) model.BacktestResult {
+	end := points[len(points)-1].Date
+	res := model.BacktestResult{From: points[0].Date, To: end, Instalments: []model.BacktestInstalment{}}
+
+	var flows []finmath.CashFlow
+	var units, invested float64
+	amount := req.Amount
+	for n, due := 0, req.StartDate; !due.After(end); n++ {
+		i := sort.Search(len(points), func(i int) bool { return !points[i].Date.Before(due) })
+		if i == len(points) {
+			break
+		}
+		p := points[i]
+		u := roundUnits(amount / p.NAV)
+		units += u
+		invested += amount
+		res.Instalments = append(res.Instalments, model.BacktestInstalment{
+			ScheduledDate: due,
+			ExecutedDate:  p.Date,
+			Amount:        roundAmount(amount),
+			NAV:           p.NAV,
+			Units:         u,
+			TotalUnits:    roundUnits(units),
+			TotalInvested: roundAmount(invested),
+			Value:         roundAmount(units * p.NAV),
+		})
+		flows = append(flows, finmath.CashFlow{Date: p.Date, Amount: -amount})
+
+		if req.Mode == "lumpsum" {
+			break
+		}
+		if req.Frequency == "weekly" {
+			due = req.StartDate.AddDate(0, 0, 7*(n+1))
+		} else {
+			due = addMonthsClamped(req.StartDate, n+1)
+		}
+		if req.StepUpPct > 0 && instalmentsPerYear(req.Frequency) > 0 && (n+1)%instalmentsPerYear(req.Frequency) == 0 {
+			amount *= 1 + req.StepUpPct/100
+		}
+	}
+
+	last := points[len(points)-1]
+	res.TotalInvested = roundAmount(invested)
+	res.TotalUnits = roundUnits(units)
+	res.FinalNAV = last.NAV
+	res.FinalValue = roundAmount(units * last.NAV)
+	res.Gains = roundAmount(res.FinalValue - res.TotalInvested)
+	if invested > 0 {
+		res.AbsoluteReturnPct = roundAmount(res.Gains / invested * 100)
+	}
+	flows = append(flows, finmath.CashFlow{Date: last.Date, Amount: res.FinalValue})
+	if x, err := finmath.XIRR(flows); err == nil {
+		x = roundAmount(x)
+		res.XIRR = &x
+	}
+
+	if len(res.Instalments) > 0 {
+		first := res.Instalments[0].ExecutedDate
+		held := points[sort.Search(len(points), func(i int) bool { return !points[i].Date.Before(first) }):]
+		res.MaxDrawdown = drawdownOf(held)
+		res.WorstLoss = worstLoss(held, res.Instalments)
+	}
+	return res
+}
+
+func instalmentsPerYear(frequency string) int {
+	if frequency == "weekly" {
+		return 52
+	}
+	return 12
+}
+
+// addMonthsClamped adds months keeping the start day where possible, so a
+// SIP dated the 31st runs on the last day of shorter months.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
+
+func drawdownOf(points []model.NAVPoint) model.Drawdown {
+	var (
+		dd        model.Drawdown
+		peak      = points[0]
+		worstPeak model.NAVPoint
+	)
+	for _, p := range points {
+		if p.NAV > peak.NAV {
+			peak = p
+		}
+		if pct := (p.NAV/peak.NAV - 1) * 100; pct < dd.Pct {
+			dd.Pct, dd.PeakDate, dd.TroughDate = pct, peak.Date, p.Date
+			worstPeak = peak
+		}
+	}
+	if dd.Pct < 0 {
+		for _, p := range points {
+			if p.Date.After(dd.TroughDate) && p.NAV >= worstPeak.NAV {
+				d := p.Date
+				dd.RecoveredDate = &d
+				break
+			}
+		}
+	}
+	dd.Pct = roundAmount(dd.Pct)
+	return dd
+}
+
+// worstLoss walks the NAV series holding the units bought up to each day and
+// finds the deepest point below the cumulative amount invested.
+func worstLoss(points []model.NAVPoint, instalments []model.BacktestInstalment) model.UnrealisedLoss {
+	var loss model.UnrealisedLoss
+	k := -1
+	for _, p := range points {
+		for k+1 < len(instalments) && !instalments[k+1].ExecutedDate.After(p.Date) {
+			k++
+		}
+		if k < 0 {
+			continue
+		}
+		inv := instalments[k].TotalInvested
+		diff := instalments[k].TotalUnits*p.NAV - inv
+		if diff < loss.Amount {
+			loss = model.UnrealisedLoss{Amount: roundAmount(diff), Pct: roundAmount(diff / inv * 100), Date: p.Date}
+		}
+	}
+	return loss
+}
@@ -0,0 +1,479 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"github.com/banking-superapp/wealth-service/service/finmath"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrBenchmarkNotFound = errors.New("benchmark not found")
+	ErrNoSchemeBenchmark = errors.New("scheme has no benchmark linked")
+)
+
+// benchmarkPeriods are the trailing windows schemes are compared over.
+var benchmarkPeriods = []string{"1Y", "3Y", "5Y"}
+
+// benchmarkCodePattern keeps index codes such as NIFTY50_TRI apart from the
+// AMFI codes, NPS scheme codes and ISINs that share nav_history.
+var (
+	benchmarkCodePattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]{2,31}$`)
+	reservedCodePattern  = regexp.MustCompile(`^(SM\d+|IN[EF][A-Z0-9]{9})$`)
+)
+
+type BenchmarkService interface {
+	Save(ctx context.Context, code string, req *model.BenchmarkRequest) (*model.Benchmark, error)
+	List(ctx context.Context) ([]model.Benchmark, error)
+	ImportValues(ctx context.Context, data []byte) (*model.BenchmarkImportResult, error)
+	LinkScheme(ctx context.Context, schemeCode, benchmarkCode string) error
+	CompareScheme(ctx context.Context, schemeCode string) (*model.SchemeBenchmarkComparison, error)
+	ComparePortfolio(ctx context.Context, userID, benchmarkCode string) (*model.PortfolioBenchmarkComparison, error)
+}
+
+type benchmarkService struct {
+	benchRepo repository.BenchmarkRepo
+	navRepo   repository.NAVRepo
+	mfRepo    repository.MFSchemeRepo
+	factRepo  repository.FactsheetRepo
+	portRepo  repository.PortfolioRepo
+	txnRepo   repository.TransactionRepo
+	sipRepo   repository.SIPRepo
+}
+
+func NewBenchmarkService(br repository.BenchmarkRepo, nr repository.NAVRepo, mr repository.MFSchemeRepo, fr repository.FactsheetRepo, pr repository.PortfolioRepo, tr repository.TransactionRepo, sr repository.SIPRepo) BenchmarkService {
+	return &benchmarkService{br, nr, mr, fr, pr, tr, sr}
+}
+
+func (s *benchmarkService) Save(ctx context.Context, code string, req *model.BenchmarkRequest) (*model.Benchmark, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	name := strings.TrimSpace(req.Name)
+	if !benchmarkCodePattern.MatchString(code) || reservedCodePattern.MatchString(code) || name == "" {
+		return nil, ErrInvalidRequest
+	}
+	var aliases []string
+	for _, a := range req.Aliases {
+		if a = strings.TrimSpace(a); a != "" {
+			aliases = append(aliases, a)
+		}
+	}
+	b := &model.Benchmark{Code: code, Name: name, Aliases: aliases, AssetClass: strings.ToLower(strings.TrimSpace(req.AssetClass))}
+	if err := s.benchRepo.Upsert(ctx, b); err != nil {
+		return nil, err
+	}
+	return s.benchRepo.FindByCode(ctx, code)
+}
+
+func (s *benchmarkService) List(ctx context.Context) ([]model.Benchmark, error) {
+	list, err := s.benchRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = []model.Benchmark{}
+	}
+	return list, nil
+}
+
+// ImportValues loads daily index values for registered benchmarks into
+// nav_history and moves each benchmark's latest value forward.
+func (s *benchmarkService) ImportValues(ctx context.Context, data []byte) (*model.BenchmarkImportResult, error) {
+	var records []struct {
+		Code  string  `json:"code"`
+		Date  string  `json:"date"`
+		Value float64 `json:"value"`
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	known, err := benchmarksByCode(ctx, s.benchRepo)
+	if err != nil {
+		return nil, err
+	}
+	res := &model.BenchmarkImportResult{}
+	var points []model.NAVPoint
+	latest := make(map[string]model.NAVPoint)
+	for i, r := range records {
+		code := strings.ToUpper(strings.TrimSpace(r.Code))
+		date, err := time.Parse(importDateLayout, r.Date)
+		if err != nil || r.Value <= 0 {
+			res.Errors = append(res.Errors, fmt.Sprintf("record %d: invalid date or value", i+1))
+			continue
+		}
+		if _, ok := known[code]; !ok {
+			res.Errors = append(res.Errors, fmt.Sprintf("record %d: unknown benchmark %q", i+1, r.Code))
+			continue
+		}
+		p := model.NAVPoint{SchemeCode: code, Date: date, NAV: r.Value}
+		points = append(points, p)
+		if l, ok := latest[code]; !ok || date.After(l.Date) {
+			latest[code] = p
+		}
+	}
+	if err := s.navRepo.UpsertMany(ctx, points); err != nil {
+		return nil, err
+	}
+	res.Points = len(points)
+	for code, p := range latest {
+		updated, err := s.benchRepo.UpdateValue(ctx, code, p.NAV, p.Date)
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			res.Benchmarks++
+		}
+	}
+	return res, nil
+}
+
+func (s *benchmarkService) LinkScheme(ctx context.Context, schemeCode, benchmarkCode string) error {
+	if _, err := s.scheme(ctx, schemeCode); err != nil {
+		return err
+	}
+	benchmarkCode = strings.ToUpper(strings.TrimSpace(benchmarkCode))
+	if _, err := s.benchmark(ctx, benchmarkCode); err != nil {
+		return err
+	}
+	return s.mfRepo.SetBenchmark(ctx, schemeCode, benchmarkCode)
+}
+
+// CompareScheme sets the scheme's trailing returns beside its benchmark's,
+// both measured to the last date the two series share.
+func (s *benchmarkService) CompareScheme(ctx context.Context, schemeCode string) (*model.SchemeBenchmarkComparison, error) {
+	sc, err := s.scheme(ctx, schemeCode)
+	if err != nil {
+		return nil, err
+	}
+	known, err := benchmarksByCode(ctx, s.benchRepo)
+	if err != nil {
+		return nil, err
+	}
+	b, err := resolveBenchmark(ctx, s.factRepo, sc, known)
+	if err != nil {
+		return nil, err
+	}
+
+	// A few spare days so the 5Y start has a value on or before it.
+	from := time.Now().AddDate(-5, 0, -7)
+	navs, err := s.navRepo.FindRange(ctx, sc.SchemeCode, from, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.navRepo.FindRange(ctx, b.Code, from, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if len(navs) < 2 || len(values) < 2 {
+		return nil, ErrInsufficientHistory
+	}
+	asOf := navs[len(navs)-1].Date
+	if last := values[len(values)-1].Date; last.Before(asOf) {
+		asOf = last
+	}
+
+	cmp := &model.SchemeBenchmarkComparison{
+		SchemeCode:    sc.SchemeCode,
+		SchemeName:    sc.SchemeName,
+		BenchmarkCode: b.Code,
+		BenchmarkName: b.Name,
+		AsOf:          asOf,
+	}
+	for _, period := range benchmarkPeriods {
+		start, _ := periodStart(period, asOf)
+		row := model.BenchmarkPeriod{Period: period}
+		row.SchemeReturn = trailingReturn(navs, start, asOf)
+		row.BenchmarkReturn = trailingReturn(values, start, asOf)
+		if row.SchemeReturn != nil && row.BenchmarkReturn != nil {
+			excess := roundAmount(*row.SchemeReturn - *row.BenchmarkReturn)
+			row.ExcessReturn = &excess
+		}
+		cmp.Periods = append(cmp.Periods, row)
+	}
+	return cmp, nil
+}
+
+// trailingReturn is the annualised return from the value on or before from
+// to the value on or before to, or nil when the series starts after from.
+func trailingReturn(points []model.NAVPoint, from, to time.Time) *float64 {
+	start, ok := valueOn(points, from)
+	if !ok {
+		return nil
+	}
+	end, _ := valueOn(points, to)
+	r := roundAmount(annualisedReturn(start.NAV, end.NAV, from, to))
+	return &r
+}
+
+// valueOn is the last point dated on or before d.
+func valueOn(points []model.NAVPoint, d time.Time) (model.NAVPoint, bool) {
+	i := sort.Search(len(points), func(i int) bool { return points[i].Date.After(d) })
+	if i == 0 {
+		return model.NAVPoint{}, false
+	}
+	return points[i-1], true
+}
+
+// ComparePortfolio replays each fund holding's cash flows (ledger entries
+// and SIP instalments run) into its benchmark, or into benchmarkCode for
+// every fund when one is given: each investment buys index units at that
+// day's value and each redemption or payout sells them. The XIRR of the
+// same flows against the index holding's value today is the
+// direct-equivalent return. Funds with no benchmark, flows before the
+// benchmark's history, or less recorded investment than their cost are
+// excluded.
+func (s *benchmarkService) ComparePortfolio(ctx context.Context, userID, benchmarkCode string) (*model.PortfolioBenchmarkComparison, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	known, err := benchmarksByCode(ctx, s.benchRepo)
+	if err != nil {
+		return nil, err
+	}
+	var override *model.Benchmark
+	if benchmarkCode != "" {
+		b, ok := known[strings.ToUpper(strings.TrimSpace(benchmarkCode))]
+		if !ok {
+			return nil, ErrBenchmarkNotFound
+		}
+		override = b
+	}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if p == nil {
+		p = &model.Portfolio{}
+	}
+	txns, err := s.txnRepo.FindByUserID(ctx, oid, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	byScheme := make(map[string][]model.Transaction)
+	for _, t := range txns {
+		byScheme[t.SchemeCode] = append(byScheme[t.SchemeCode], t)
+	}
+	sips, err := s.sipRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	sipsByScheme := make(map[string][]model.SIP)
+	for _, sip := range sips {
+		sipsByScheme[sip.SchemeCode] = append(sipsByScheme[sip.SchemeCode], sip)
+	}
+
+	now := time.Now()
+	today := dateOf(now)
+	cmp := &model.PortfolioBenchmarkComparison{AsOf: today, Schemes: []model.SchemeBenchmarkOutcome{}, Note: fundFlowsNote}
+	series := make(map[string][]model.NAVPoint)
+	var flowsAll []finmath.CashFlow
+	for i := range p.Holdings {
+		h := &p.Holdings[i]
+		if !isFund(h) {
+			continue
+		}
+		b := override
+		if b == nil {
+			sc, err := s.mfRepo.FindByCode(ctx, h.SchemeCode)
+			if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, err
+			}
+			if sc != nil {
+				if b, err = resolveBenchmark(ctx, s.factRepo, sc, known); err != nil && !errors.Is(err, ErrNoSchemeBenchmark) {
+					return nil, err
+				}
+			}
+		}
+		if b == nil {
+			cmp.Excluded = append(cmp.Excluded, fmt.Sprintf("%s: no benchmark", h.SchemeName))
+			continue
+		}
+		values, ok := series[b.Code]
+		if !ok {
+			if values, err = s.navRepo.FindRange(ctx, b.Code, time.Time{}, time.Time{}); err != nil {
+				return nil, err
+			}
+			series[b.Code] = values
+		}
+		out, flows, ok := directEquivalent(byScheme[h.SchemeCode], sipsByScheme[h.SchemeCode], values, today)
+		if !ok {
+			cmp.Excluded = append(cmp.Excluded, fmt.Sprintf("%s: no recorded investments within %s's history", h.SchemeName, b.Name))
+			continue
+		}
+		if out.Invested < h.InvestedValue-1 {
+			// Money went in that we have no date for, so neither XIRR
+			// would be the holding's.
+			cmp.Excluded = append(cmp.Excluded, fmt.Sprintf("%s: only ₹%.2f of ₹%.2f invested is on record", h.SchemeName, out.Invested, h.InvestedValue))
+			continue
+		}
+		out.SchemeCode, out.SchemeName, out.BenchmarkCode = h.SchemeCode, h.SchemeName, b.Code
+		out.CurrentValue = h.CurrentValue
+		out.XIRR = closingXIRR(flows, today, h.CurrentValue)
+		out.BenchmarkXIRR = closingXIRR(flows, today, out.BenchmarkValue)
+		cmp.Schemes = append(cmp.Schemes, out)
+
+		cmp.Invested += out.Invested
+		cmp.CurrentValue += out.CurrentValue
+		cmp.BenchmarkValue += out.BenchmarkValue
+		flowsAll = append(flowsAll, flows...)
+	}
+	cmp.Invested = roundAmount(cmp.Invested)
+	cmp.CurrentValue = roundAmount(cmp.CurrentValue)
+	cmp.BenchmarkValue = roundAmount(cmp.BenchmarkValue)
+	if len(cmp.Schemes) > 0 {
+		cmp.XIRR = closingXIRR(flowsAll, today, cmp.CurrentValue)
+		cmp.BenchmarkXIRR = closingXIRR(flowsAll, today, cmp.BenchmarkValue)
+	}
+	return cmp, nil
+}
+
+// fundFlowsNote tells the reader where fund cash flows come from while
+// lump-sum orders are settled outside this service.
+const fundFlowsNote = "Lump-sum purchases and redemptions are not yet recorded in the ledger. " +
+	"Fund cash flows are NFO allotments, IDCW payouts and SIP instalments already run; " +
+	"funds with money invested outside these are excluded."
+
+// directEquivalent turns one scheme's ledger entries and the instalments
+// its SIPs have run into investor cash flows and mirrors each in index
+// units at that day's value. The outcome has Invested and BenchmarkValue
+// set; ok is false when there is no investment or a flow predates the
+// index series.
+func directEquivalent(txns []model.Transaction, sips []model.SIP, values []model.NAVPoint, today time.Time) (out model.SchemeBenchmarkOutcome, flows []finmath.CashFlow, ok bool) {
+	for _, t := range txns {
+		switch t.Type {
+		case model.TxnPurchase, model.TxnNFOAllotment:
+			flows = append(flows, finmath.CashFlow{Date: t.Date, Amount: -math.Abs(t.Amount)})
+		case model.TxnRedemption, model.TxnIDCWPayout:
+			flows = append(flows, finmath.CashFlow{Date: t.Date, Amount: math.Abs(t.NetAmount)})
+		}
+		// SIP instalments come from the SIPs below; reinvestments and
+		// scheme events move no cash.
+	}
+	for _, sip := range sips {
+		for _, d := range sipRunDates(sip, today) {
+			flows = append(flows, finmath.CashFlow{Date: d, Amount: -sip.Amount})
+		}
+	}
+	units := 0.0
+	for _, f := range flows {
+		v, found := valueOn(values, f.Date)
+		if !found {
+			return out, nil, false
+		}
+		if f.Amount < 0 {
+			out.Invested -= f.Amount
+		}
+		units -= f.Amount / v.NAV
+	}
+	if out.Invested == 0 {
+		return out, nil, false
+	}
+	last, _ := valueOn(values, today)
+	out.Invested = roundAmount(out.Invested)
+	out.BenchmarkValue = roundAmount(units * last.NAV)
+	return out, flows, true
+}
+
+// closingXIRR is the XIRR of flows closed out at value on date, or nil when
+// it has no solution.
+func closingXIRR(flows []finmath.CashFlow, date time.Time, value float64) *float64 {
+	all := make([]finmath.CashFlow, 0, len(flows)+1)
+	all = append(all, flows...)
+	x, err := finmath.XIRR(append(all, finmath.CashFlow{Date: date, Amount: value}))
+	if err != nil {
+		return nil
+	}
+	x = roundAmount(x)
+	return &x
+}
+
+// resolveBenchmark finds the scheme's benchmark: the one linked explicitly,
+// else the one whose name or an alias matches the latest factsheet's
+// benchmark.
+func resolveBenchmark(ctx context.Context, factRepo repository.FactsheetRepo, sc *model.MFScheme, known map[string]*model.Benchmark) (*model.Benchmark, error) {
+	if sc.BenchmarkCode != "" {
+		if b, ok := known[sc.BenchmarkCode]; ok {
+			return b, nil
+		}
+		return nil, ErrNoSchemeBenchmark
+	}
+	fs, err := factRepo.FindLatest(ctx, sc.SchemeCode)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoSchemeBenchmark
+		}
+		return nil, err
+	}
+	want := benchmarkKey(fs.Benchmark)
+	if want == "" {
+		return nil, ErrNoSchemeBenchmark
+	}
+	for _, b := range known {
+		if benchmarkKey(b.Name) == want {
+			return b, nil
+		}
+		for _, a := range b.Aliases {
+			if benchmarkKey(a) == want {
+				return b, nil
+			}
+		}
+	}
+	return nil, ErrNoSchemeBenchmark
+}
+
+// benchmarkKey folds case and punctuation so "NIFTY 50 - TRI" matches
+// "Nifty 50 TRI".
+func benchmarkKey(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func benchmarksByCode(ctx context.Context, repo repository.BenchmarkRepo) (map[string]*model.Benchmark, error) {
+	list, err := repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]*model.Benchmark, len(list))
+	for i := range list {
+		out[list[i].Code] = &list[i]
+	}
+	return out, nil
+}
+
+func (s *benchmarkService) benchmark(ctx context.Context, code string) (*model.Benchmark, error) {
+	b, err := s.benchRepo.FindByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrBenchmarkNotFound
+		}
+		return nil, err
+	}
+	return b, nil
+}
+
+func (s *benchmarkService) scheme(ctx context.Context, code string) (*model.MFScheme, error) {
+	sc, err := s.mfRepo.FindByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrSchemeNotFound
+		}
+		return nil, err
+	}
+	return sc, nil
+}
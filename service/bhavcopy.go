@@ -0,0 +1,155 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+)
+
+// bhavcopyColumns lists the headers each field appears under in the NSE and
+// BSE cash market bhavcopies, both the legacy layouts and the common UDiFF
+// one. Headers are matched case-insensitively.
+var bhavcopyColumns = map[string][]string{
+	"isin":   {"isin", "isin_code"},
+	"symbol": {"tckrsymb", "symbol", "sc_code"},
+	"name":   {"fininstrmnm", "sc_name"},
+	"series": {"sctysrs", "series"},
+	"close":  {"clspric", "close"},
+	"prev":   {"prvsclsgpric", "prevclose"},
+	"date":   {"traddt", "timestamp", "trading_date"},
+}
+
+// bhavcopyDateLayouts are the trade date formats the exchanges have used.
+var bhavcopyDateLayouts = []string{"2006-01-02", "02-Jan-2006", "02-Jan-06", "02-01-2006", "02/01/2006", "02/01/06", "20060102"}
+
+// nseEquitySeries are the NSE series traded as ordinary shares and ETFs;
+// other series (bonds, rights entitlements, warrants) are skipped.
+var nseEquitySeries = map[string]bool{"EQ": true, "BE": true, "BZ": true, "SM": true, "ST": true}
+
+// parseBhavcopy reads an end-of-day bhavcopy from the given exchange (NSE or
+// BSE). Only rows with an equity (INE) or mutual fund unit (INF, i.e. ETF)
+// ISIN are kept; BSE does not list series, so its file is taken as is.
+func parseBhavcopy(exchange string, data []byte) ([]model.Security, int, []string, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.TrimLeadingSpace = true
+	r.FieldsPerRecord = -1
+	header, err := r.Read()
+	if err != nil {
+		return nil, 0, nil, fmt.Errorf("%w: missing header row", ErrUnsupportedFormat)
+	}
+	idx := make(map[string]int, len(header))
+	for i, h := range header {
+		idx[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	col := make(map[string]int, len(bhavcopyColumns))
+	for field, names := range bhavcopyColumns {
+		for _, n := range names {
+			if i, ok := idx[n]; ok {
+				col[field] = i
+				break
+			}
+		}
+	}
+	for _, required := range []string{"isin", "symbol", "close", "date"} {
+		if _, ok := col[required]; !ok {
+			return nil, 0, nil, fmt.Errorf("%w: missing %s column", ErrUnsupportedFormat, required)
+		}
+	}
+
+	var (
+		secs    []model.Security
+		errs    []string
+		skipped int
+	)
+	for line := 2; ; line++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		get := func(field string) string {
+			if i, ok := col[field]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		isin := strings.ToUpper(get("isin"))
+		series := strings.ToUpper(get("series"))
+		if len(isin) != 12 || !(strings.HasPrefix(isin, "INE") || strings.HasPrefix(isin, "INF")) ||
+			(exchange == "NSE" && series != "" && !nseEquitySeries[series]) {
+			skipped++
+			continue
+		}
+		closePrice, err := strconv.ParseFloat(get("close"), 64)
+		if err != nil || closePrice <= 0 {
+			errs = append(errs, fmt.Sprintf("line %d: invalid close price", line))
+			continue
+		}
+		date, ok := parseBhavcopyDate(get("date"))
+		if !ok {
+			errs = append(errs, fmt.Sprintf("line %d: invalid trade date", line))
+			continue
+		}
+		prev, _ := strconv.ParseFloat(get("prev"), 64)
+
+		s := model.Security{
+			ISIN:      isin,
+			Name:      get("name"),
+			Kind:      model.SecurityStock,
+			Category:  "equity",
+			Close:     closePrice,
+			PrevClose: prev,
+			PriceDate: date,
+			Exchange:  exchange,
+		}
+		if exchange == "NSE" {
+			s.NSESymbol = get("symbol")
+		} else {
+			s.BSECode = get("symbol")
+		}
+		if s.Name == "" {
+			s.Name = get("symbol")
+		}
+		if strings.HasPrefix(isin, "INF") {
+			s.Kind = model.SecurityETF
+			s.Category = etfCategory(s.Name + " " + get("symbol"))
+		}
+		secs = append(secs, s)
+	}
+	return secs, skipped, errs, nil
+}
+
+func parseBhavcopyDate(v string) (time.Time, bool) {
+	for _, layout := range bhavcopyDateLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// etfCategory places an ETF in the class of what it tracks, judged by its
+// name: gold and liquid ETFs are common enough to matter for allocation;
+// everything else is taken as equity.
+func etfCategory(name string) string {
+	name = strings.ToUpper(name)
+	switch {
+	case strings.Contains(name, "GOLD"):
+		return "gold"
+	case strings.Contains(name, "LIQUID"):
+		return "liquid"
+	case strings.Contains(name, "GILT"), strings.Contains(name, "BOND"), strings.Contains(name, "SDL"):
+		return "debt"
+	}
+	return "equity"
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"math"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service/finmath"
+)
+
+const (
+	maxCalculatorYears = 60
+	defaultSWPYears    = 40
+)
+
+// Projection is the common calculator response: totals plus the year-by-year
+// schedule they were read from.
+type Projection struct {
+	Invested    float64           `json:"invested"`
+	FutureValue float64           `json:"future_value"`
+	Gains       float64           `json:"gains"`
+	MonthlySIP  float64           `json:"monthly_sip,omitempty"`
+	Schedule    []finmath.YearRow `json:"schedule"`
+}
+
+type CalculatorService interface {
+	SIP(req *model.SIPCalculatorRequest) (*Projection, error)
+	Lumpsum(req *model.LumpsumCalculatorRequest) (*Projection, error)
+	RequiredSIP(req *model.RequiredSIPRequest) (*Projection, error)
+	Retirement(req *model.RetirementCalculatorRequest) (*finmath.RetirementResult, error)
+	SWP(req *model.SWPCalculatorRequest) (*finmath.SWPResult, error)
+}
+
+type calculatorService struct{}
+
+func NewCalculatorService() CalculatorService { return &calculatorService{} }
+
+func (s *calculatorService) SIP(req *model.SIPCalculatorRequest) (*Projection, error) {
+	if req.MonthlyAmount <= 0 || !validYears(req.Years) || !validRate(req.ReturnPct) || !validStepUp(req.StepUpPct) {
+		return nil, ErrInvalidRequest
+	}
+	return projection(finmath.SIPSchedule(req.MonthlyAmount, req.ReturnPct, req.StepUpPct, req.Years)), nil
+}
+
+func (s *calculatorService) Lumpsum(req *model.LumpsumCalculatorRequest) (*Projection, error) {
+	if req.Amount <= 0 || !validYears(req.Years) || !validRate(req.ReturnPct) {
+		return nil, ErrInvalidRequest
+	}
+	return projection(finmath.LumpsumSchedule(req.Amount, req.ReturnPct, req.Years)), nil
+}
+
+// RequiredSIP rounds the instalment up to the next rupee and returns the
+// schedule of that SIP, so the final value meets or just exceeds the target.
+func (s *calculatorService) RequiredSIP(req *model.RequiredSIPRequest) (*Projection, error) {
+	if req.TargetAmount <= 0 || !validYears(req.Years) || !validRate(req.ReturnPct) || !validStepUp(req.StepUpPct) {
+		return nil, ErrInvalidRequest
+	}
+	sip := math.Ceil(finmath.RequiredSIP(req.TargetAmount, req.ReturnPct, req.StepUpPct, req.Years))
+	p := projection(finmath.SIPSchedule(sip, req.ReturnPct, req.StepUpPct, req.Years))
+	p.MonthlySIP = sip
+	return p, nil
+}
+
+func (s *calculatorService) Retirement(req *model.RetirementCalculatorRequest) (*finmath.RetirementResult, error) {
+	if req.CurrentAge < 18 || req.RetirementAge <= req.CurrentAge || req.LifeExpectancy <= req.RetirementAge ||
+		req.LifeExpectancy-req.CurrentAge > maxCalculatorYears+20 ||
+		req.MonthlyExpenses <= 0 || req.CurrentSavings < 0 ||
+		!validRate(req.InflationPct) || !validRate(req.PreReturnPct) || !validRate(req.PostReturnPct) || !validStepUp(req.StepUpPct) {
+		return nil, ErrInvalidRequest
+	}
+	res := finmath.Retirement(finmath.RetirementInput{
+		CurrentAge:      req.CurrentAge,
+		RetirementAge:   req.RetirementAge,
+		LifeExpectancy:  req.LifeExpectancy,
+		MonthlyExpenses: req.MonthlyExpenses,
+		InflationPct:    req.InflationPct,
+		PreReturnPct:    req.PreReturnPct,
+		PostReturnPct:   req.PostReturnPct,
+		CurrentSavings:  req.CurrentSavings,
+		StepUpPct:       req.StepUpPct,
+	})
+	return &res, nil
+}
+
+func (s *calculatorService) SWP(req *model.SWPCalculatorRequest) (*finmath.SWPResult, error) {
+	years := req.MaxYears
+	if years == 0 {
+		years = defaultSWPYears
+	}
+	if req.Corpus <= 0 || req.MonthlyWithdrawal <= 0 || !validYears(years) || !validRate(req.ReturnPct) || !validStepUp(req.StepUpPct) {
+		return nil, ErrInvalidRequest
+	}
+	res := finmath.SWP(req.Corpus, req.MonthlyWithdrawal, req.ReturnPct, req.StepUpPct, years)
+	return &res, nil
+}
+
+func projection(rows []finmath.YearRow) *Projection {
+	last := rows[len(rows)-1]
+	return &Projection{
+		Invested:    last.Invested,
+		FutureValue: last.Value,
+		Gains:       roundAmount(last.Value - last.Invested),
+		Schedule:    rows,
+	}
+}
+
+func validYears(y int) bool        { return y >= 1 && y <= maxCalculatorYears }
+func validRate(pct float64) bool   { return pct > -50 && pct <= 50 }
+func validStepUp(pct float64) bool { return pct >= 0 && pct <= 50 }
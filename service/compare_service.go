@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	maxCompareSchemes = 3
+	growthBase        = 10000.0
+)
+
+var ErrInsufficientHistory = errors.New("not enough NAV history")
+
+type CompareService interface {
+	Compare(ctx context.Context, codes []string, period string) (*model.FundComparison, error)
+}
+
+type compareService struct {
+	mfRepo   repository.MFSchemeRepo
+	navRepo  repository.NAVRepo
+	factRepo repository.FactsheetRepo
+}
+
+func NewCompareService(mr repository.MFSchemeRepo, nr repository.NAVRepo, fr repository.FactsheetRepo) CompareService {
+	return &compareService{mr, nr, fr}
+}
+
+// Compare lines schemes up over the dates all of them have NAVs for within
+// the requested period, so returns and the growth series are like for like.
+func (s *compareService) Compare(ctx context.Context, codes []string, period string) (*model.FundComparison, error) {
+	codes = dedupe(codes)
+	if len(codes) < 2 || len(codes) > maxCompareSchemes {
+		return nil, ErrInvalidRequest
+	}
+	if period == "" {
+		period = "3Y"
+	}
+	from, ok := periodStart(strings.ToUpper(period), time.Now())
+	if !ok {
+		return nil, ErrInvalidRequest
+	}
+
+	schemes := make([]*model.MFScheme, len(codes))
+	series := make([][]model.NAVPoint, len(codes))
+	var start, end time.Time
+	for i, code := range codes {
+		sc, err := s.mfRepo.FindByCode(ctx, code)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, ErrSchemeNotFound
+			}
+			return nil, err
+		}
+		points, err := s.navRepo.FindRange(ctx, code, from, time.Time{})
+		if err != nil {
+			return nil, err
+		}
+		if len(points) < 2 {
+			return nil, ErrInsufficientHistory
+		}
+		if first := points[0].Date; i == 0 || first.After(start) {
+			start = first
+		}
+		if last := points[len(points)-1].Date; i == 0 || last.Before(end) {
+			end = last
+		}
+		schemes[i], series[i] = sc, points
+	}
+	if !end.After(start) {
+		return nil, ErrInsufficientHistory
+	}
+	for i := range series {
+		series[i] = clipSeries(series[i], start, end)
+		if len(series[i]) < 2 {
+			return nil, ErrInsufficientHistory
+		}
+	}
+
+	cmp := &model.FundComparison{From: start, To: end, Growth: growthSeries(codes, series)}
+	for i, sc := range schemes {
+		pts := series[i]
+		row := model.SchemeComparison{
+			SchemeCode:   sc.SchemeCode,
+			SchemeName:   sc.SchemeName,
+			Category:     sc.Category,
+			SubCategory:  sc.SubCategory,
+			Plan:         sc.Plan,
+			Risk:         sc.Risk,
+			Returns1Y:    sc.Returns1Y,
+			Returns3Y:    sc.Returns3Y,
+			Returns5Y:    sc.Returns5Y,
+			PeriodReturn: roundAmount(annualisedReturn(pts[0].NAV, pts[len(pts)-1].NAV, start, end)),
+			RiskMetrics:  riskMetrics(pts),
+			ExitLoadPct:  sc.ExitLoadPct,
+			ExitLoadDays: sc.ExitLoadDays,
+			MinSIP:       sc.MinSIP,
+			MinLumpsum:   sc.MinLumpsum,
+		}
+		fs, err := s.factRepo.FindLatest(ctx, sc.SchemeCode)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		if fs != nil {
+			row.ExpenseRatio, row.AUM = &fs.ExpenseRatio, &fs.AUM
+		}
+		cmp.Schemes = append(cmp.Schemes, row)
+	}
+	return cmp, nil
+}
+
+func clipSeries(points []model.NAVPoint, from, to time.Time) []model.NAVPoint {
+	out := points[:0]
+	for _, p := range points {
+		if !p.Date.Before(from) && !p.Date.After(to) {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// growthSeries values growthBase invested in each scheme on the first common
+// date. Dates on which only some schemes published a NAV carry the others'
+// last known value forward.
+func growthSeries(codes []string, series [][]model.NAVPoint) []model.GrowthPoint {
+	dateSet := make(map[time.Time]bool)
+	for _, pts := range series {
+		for _, p := range pts {
+			dateSet[p.Date] = true
+		}
+	}
+	dates := make([]time.Time, 0, len(dateSet))
+	for d := range dateSet {
+		dates = append(dates, d)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	idx := make([]int, len(series))
+	out := make([]model.GrowthPoint, 0, len(dates))
+	for _, d := range dates {
+		gp := model.GrowthPoint{Date: d, Values: make(map[string]float64, len(codes))}
+		for i, pts := range series {
+			for idx[i]+1 < len(pts) && !pts[idx[i]+1].Date.After(d) {
+				idx[i]++
+			}
+			gp.Values[codes[i]] = roundAmount(growthBase * pts[idx[i]].NAV / pts[0].NAV)
+		}
+		out = append(out, gp)
+	}
+	return out
+}
+
+func dedupe(codes []string) []string {
+	seen := make(map[string]bool, len(codes))
+	out := make([]string, 0, len(codes))
+	for _, c := range codes {
+		c = strings.TrimSpace(c)
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrActionNotFound  = errors.New("corporate action not found")
+	ErrActionProcessed = errors.New("corporate action already processed")
+	ErrActionNotDue    = errors.New("corporate action effective date has not arrived")
+)
+
+type CorporateActionService interface {
+	Register(ctx context.Context, req *model.CorporateActionRequest) (*model.CorporateAction, error)
+	List(ctx context.Context, status string) ([]model.CorporateAction, error)
+	Process(ctx context.Context, id string) (*model.CorporateAction, error)
+	ProcessDue(ctx context.Context, asOf time.Time) (int, error)
+}
+
+type corporateActionService struct {
+	mfRepo     repository.MFSchemeRepo
+	sipRepo    repository.SIPRepo
+	portRepo   repository.PortfolioRepo
+	txnRepo    repository.TransactionRepo
+	actionRepo repository.CorporateActionRepo
+	goalRepo   repository.GoalRepo
+	watchRepo  repository.WatchlistRepo
+	tx         repository.Transactor
+}
+
+func NewCorporateActionService(mr repository.MFSchemeRepo, sr repository.SIPRepo, pr repository.PortfolioRepo, tr repository.TransactionRepo, ar repository.CorporateActionRepo, gr repository.GoalRepo, wr repository.WatchlistRepo, tx repository.Transactor) CorporateActionService {
+	return &corporateActionService{mr, sr, pr, tr, ar, gr, wr, tx}
+}
+
+func (s *corporateActionService) Register(ctx context.Context, req *model.CorporateActionRequest) (*model.CorporateAction, error) {
+	if _, err := s.scheme(ctx, req.FromCode); err != nil {
+		return nil, err
+	}
+
+	a := &model.CorporateAction{
+		Type:          req.Type,
+		FromCode:      req.FromCode,
+		ToCode:        req.ToCode,
+		Ratio:         1,
+		EffectiveDate: req.EffectiveDate,
+		Status:        "pending",
+	}
+	switch req.Type {
+	case model.ActionRename:
+		if req.NewName == "" {
+			return nil, ErrInvalidRequest
+		}
+		a.ToCode = req.FromCode
+		a.NewName = req.NewName
+	case model.ActionMerger, model.ActionCodeChange:
+		if req.ToCode == "" || req.ToCode == req.FromCode {
+			return nil, ErrInvalidRequest
+		}
+		if _, err := s.scheme(ctx, req.ToCode); err != nil {
+			return nil, err
+		}
+		if req.Type == model.ActionMerger {
+			switch {
+			case req.Ratio > 0:
+				a.Ratio = req.Ratio
+			case req.FromNAV > 0 && req.ToNAV > 0:
+				a.Ratio = req.FromNAV / req.ToNAV
+			default:
+				return nil, ErrInvalidRequest
+			}
+		}
+	default:
+		return nil, ErrInvalidRequest
+	}
+	if a.EffectiveDate.IsZero() {
+		a.EffectiveDate = time.Now()
+	}
+
+	if err := s.actionRepo.Create(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *corporateActionService) List(ctx context.Context, status string) ([]model.CorporateAction, error) {
+	return s.actionRepo.FindByStatus(ctx, status)
+}
+
+func (s *corporateActionService) Process(ctx context.Context, id string) (*model.CorporateAction, error) {
+	return s.process(ctx, id, time.Now())
+}
+
+// process applies an action across all users once its effective date has
+// arrived. Each user's ledger rows, holding and statement entry change in
+// one transaction that re-reads their portfolio, and every step is safe to
+// repeat: migrated rows, SIPs and holdings no longer match the old code,
+// and the statement entry is keyed by the action.
+func (s *corporateActionService) process(ctx context.Context, id string, asOf time.Time) (*model.CorporateAction, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrActionNotFound
+	}
+	a, err := s.actionRepo.FindByID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrActionNotFound
+		}
+		return nil, err
+	}
+	if a.Status == "processed" {
+		return nil, ErrActionProcessed
+	}
+	if asOf.Before(a.EffectiveDate) {
+		return nil, ErrActionNotDue
+	}
+
+	from, err := s.scheme(ctx, a.FromCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary *model.CorporateActionSummary
+	if a.Type == model.ActionRename {
+		summary, err = s.rename(ctx, a, from)
+	} else {
+		summary, err = s.merge(ctx, a, from)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	marked, err := s.actionRepo.MarkProcessed(ctx, a.ID, summary)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, ErrActionProcessed
+	}
+	return s.actionRepo.FindByID(ctx, a.ID)
+}
+
+func (s *corporateActionService) rename(ctx context.Context, a *model.CorporateAction, scheme *model.MFScheme) (*model.CorporateActionSummary, error) {
+	if err := s.mfRepo.Rename(ctx, scheme.SchemeCode, a.NewName); err != nil {
+		return nil, err
+	}
+
+	holders, err := s.portRepo.FindHolders(ctx, scheme.SchemeCode)
+	if err != nil {
+		return nil, err
+	}
+	summary := &model.CorporateActionSummary{}
+	note := fmt.Sprintf("%s renamed to %s", scheme.SchemeName, a.NewName)
+	for _, holder := range holders {
+		renamed := false
+		err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			renamed = false
+			p, err := s.portRepo.FindByUserID(ctx, holder.UserID)
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			i := findHolding(p, scheme.SchemeCode)
+			if i < 0 {
+				return nil // sold or switched out since the holders were listed
+			}
+			if err := s.recordEvent(ctx, p.UserID, a, scheme, model.TxnSchemeRename, a.NewName, note); err != nil {
+				return err
+			}
+			p.Holdings[i].SchemeName = a.NewName
+			renamed = true
+			return s.portRepo.ReplaceFundHoldings(ctx, p, scheme.SchemeCode)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if renamed {
+			summary.Holdings++
+		}
+	}
+
+	if summary.SIPs, err = s.sipRepo.MigrateScheme(ctx, scheme.SchemeCode, scheme.SchemeCode, a.NewName); err != nil {
+		return nil, err
+	}
+	if summary.Watchlist, err = s.watchRepo.MigrateScheme(ctx, scheme.SchemeCode, scheme.SchemeCode, a.NewName, 1); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// merge moves the old scheme into the surviving one. Units are converted at
+// the action's ratio; invested value carries over unchanged because a scheme
+// merger is not a transfer for capital gains purposes. Users with ledger
+// entries in the old scheme but no holding have their entries migrated too.
+func (s *corporateActionService) merge(ctx context.Context, a *model.CorporateAction, from *model.MFScheme) (*model.CorporateActionSummary, error) {
+	to, err := s.scheme(ctx, a.ToCode)
+	if err != nil {
+		return nil, err
+	}
+
+	holders, err := s.portRepo.FindHolders(ctx, from.SchemeCode)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]bson.ObjectID, 0, len(holders))
+	seen := make(map[bson.ObjectID]bool, len(holders))
+	for _, p := range holders {
+		seen[p.UserID] = true
+		users = append(users, p.UserID)
+	}
+	inLedger, err := s.txnRepo.UserIDsInScheme(ctx, from.SchemeCode)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range inLedger {
+		if !seen[id] {
+			seen[id] = true
+			users = append(users, id)
+		}
+	}
+
+	txnType := model.TxnSchemeMerger
+	if a.Type == model.ActionCodeChange {
+		txnType = model.TxnSchemeCodeChange
+	}
+	summary := &model.CorporateActionSummary{}
+	for _, userID := range users {
+		var migrated int64
+		moved := false
+		err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			moved = false
+			var err error
+			if migrated, err = s.txnRepo.MigrateScheme(ctx, userID, from.SchemeCode, to.SchemeCode, to.SchemeName, a.Ratio); err != nil {
+				return err
+			}
+			p, err := s.portRepo.FindByUserID(ctx, userID)
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			idx := findHolding(p, from.SchemeCode)
+			if idx < 0 {
+				return nil
+			}
+			old := p.Holdings[idx]
+			units := roundUnits(old.Units * a.Ratio)
+
+			note := fmt.Sprintf("%s (%s) merged into %s (%s): %.3f units became %.3f units",
+				from.SchemeName, from.SchemeCode, to.SchemeName, to.SchemeCode, old.Units, units)
+			if a.Type == model.ActionCodeChange {
+				note = fmt.Sprintf("Scheme code changed from %s to %s", from.SchemeCode, to.SchemeCode)
+			}
+			if err := s.recordEvent(ctx, p.UserID, a, to, txnType, to.SchemeName, note); err != nil {
+				return err
+			}
+
+			p.Holdings = append(p.Holdings[:idx], p.Holdings[idx+1:]...)
+			creditUnits(p, to, units, old.InvestedValue)
+			moved = true
+			return s.portRepo.ReplaceFundHoldings(ctx, p, from.SchemeCode, to.SchemeCode)
+		})
+		if err != nil {
+			return nil, err
+		}
+		summary.Transactions += migrated
+		if moved {
+			summary.Holdings++
+		}
+	}
+
+	if summary.SIPs, err = s.sipRepo.MigrateScheme(ctx, from.SchemeCode, to.SchemeCode, to.SchemeName); err != nil {
+		return nil, err
+	}
+	if summary.GoalLinks, err = s.goalRepo.MigrateLinks(ctx, from.SchemeCode, to.SchemeCode); err != nil {
+		return nil, err
+	}
+	if summary.Watchlist, err = s.watchRepo.MigrateScheme(ctx, from.SchemeCode, to.SchemeCode, to.SchemeName, a.Ratio); err != nil {
+		return nil, err
+	}
+
+	if err := s.mfRepo.SetActive(ctx, from.SchemeCode, false); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// recordEvent adds a zero-unit ledger entry so the action appears on the
+// user's statement. It is skipped if the action was already recorded.
+func (s *corporateActionService) recordEvent(ctx context.Context, userID bson.ObjectID, a *model.CorporateAction, scheme *model.MFScheme, txnType, name, note string) error {
+	ref := "ca:" + a.ID.Hex()
+	done, err := s.txnRepo.ExistsByReference(ctx, userID, ref)
+	if err != nil || done {
+		return err
+	}
+	txn := &model.Transaction{
+		UserID:     userID,
+		SchemeCode: scheme.SchemeCode,
+		SchemeName: name,
+		AMC:        scheme.AMC,
+		Type:       txnType,
+		Date:       a.EffectiveDate,
+		Reference:  ref,
+		Note:       note,
+	}
+	if a.FromCode != scheme.SchemeCode {
+		txn.OriginalSchemeCode = a.FromCode
+	}
+	return s.txnRepo.Create(ctx, txn)
+}
+
+func (s *corporateActionService) ProcessDue(ctx context.Context, asOf time.Time) (int, error) {
+	due, err := s.actionRepo.FindDue(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+	processed := 0
+	for _, a := range due {
+		if _, err := s.process(ctx, a.ID.Hex(), asOf); err != nil {
+			log.Printf("corporate action %s (%s %s): %v", a.ID.Hex(), a.Type, a.FromCode, err)
+			continue
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+func (s *corporateActionService) scheme(ctx context.Context, code string) (*model.MFScheme, error) {
+	sc, err := s.mfRepo.FindByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrSchemeNotFound
+		}
+		return nil, err
+	}
+	return sc, nil
+}
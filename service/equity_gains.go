@@ -0,0 +1,173 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+)
+
+// lot is shares acquired together at one cost per share.
+type lot struct {
+	date     time.Time
+	qty      float64
+	unitCost float64
+}
+
+// equityGains replays one ISIN's date-ordered ledger entries and matches
+// sales between from and to against purchase lots, first in first out.
+// Splits restate the open lots; bonus shares form a new lot at nil cost
+// acquired on the ex-date. Lots are costed at actual cost: the pre-2018 fair
+// market value is not applied. It also returns the quantity sold in the
+// period with no lot to match.
+func equityGains(txns []model.Transaction, from, to time.Time) ([]model.CapitalGain, float64) {
+	var (
+		lots      []lot
+		out       []model.CapitalGain
+		unmatched float64
+	)
+	for _, t := range txns {
+		switch t.Type {
+		case model.TxnEquityBuy:
+			if t.Units > 0 {
+				lots = append(lots, lot{date: t.Date, qty: t.Units, unitCost: t.NetAmount / t.Units})
+			}
+		case model.TxnEquityBonus:
+			lots = append(lots, lot{date: t.Date, qty: t.Units})
+		case model.TxnEquitySplit:
+			held := 0.0
+			for _, l := range lots {
+				held += l.qty
+			}
+			if held <= 0 {
+				continue
+			}
+			f := (held + t.Units) / held
+			for i := range lots {
+				lots[i].qty *= f
+				lots[i].unitCost /= f
+			}
+		case model.TxnEquitySell:
+			need := -t.Units
+			if need <= 0 {
+				continue
+			}
+			perShare := t.NetAmount / need
+			inPeriod := !t.Date.Before(from) && !t.Date.After(to)
+			for need > 1e-9 && len(lots) > 0 {
+				l := &lots[0]
+				q := math.Min(l.qty, need)
+				if inPeriod {
+					out = append(out, capitalGain(t, *l, q, perShare))
+				}
+				l.qty -= q
+				need -= q
+				if l.qty <= 1e-9 {
+					lots = lots[1:]
+				}
+			}
+			if inPeriod && need > 1e-9 {
+				unmatched += need
+			}
+		}
+	}
+	return out, unmatched
+}
+
+func capitalGain(sale model.Transaction, l lot, qty, perShare float64) model.CapitalGain {
+	g := model.CapitalGain{
+		ISIN:        sale.SchemeCode,
+		Name:        sale.SchemeName,
+		Quantity:    roundUnits(qty),
+		BuyDate:     l.date,
+		SellDate:    sale.Date,
+		Cost:        roundAmount(qty * l.unitCost),
+		Proceeds:    roundAmount(qty * perShare),
+		Term:        model.TermShort,
+		HoldingDays: int(sale.Date.Sub(l.date).Hours() / 24),
+	}
+	g.Gain = roundAmount(g.Proceeds - g.Cost)
+	if sale.Date.After(l.date.AddDate(0, equityLongTermMonths, 0)) {
+		g.Term = model.TermLong
+	}
+	return g
+}
+
+// summariseGains totals the report's entries and estimates the tax. Gains
+// are bucketed by the rates in force on the sale date. Short-term losses are
+// set off against short-term then long-term gains, long-term losses against
+// long-term gains only, and the exemption applies last; each is set against
+// the higher-rate gains first. What remains of the losses carries forward.
+func summariseGains(r *model.CapitalGainsReport, fyStart int) {
+	var (
+		st, lt         [2]float64 // gains at [old, current] rates
+		stLoss, ltLoss float64
+	)
+	for _, g := range r.Entries {
+		k := 1
+		if g.SellDate.Before(equityRateChange) {
+			k = 0
+		}
+		switch {
+		case g.Term == model.TermShort && g.Gain < 0:
+			stLoss -= g.Gain
+		case g.Gain < 0:
+			ltLoss -= g.Gain
+		case g.Term == model.TermShort:
+			st[k] += g.Gain
+		default:
+			lt[k] += g.Gain
+		}
+		if g.Term == model.TermShort {
+			r.ShortTermGain += g.Gain
+		} else {
+			r.LongTermGain += g.Gain
+		}
+	}
+	stLoss = setOff(&st, stLoss)
+	stLoss = setOff(&lt, stLoss)
+	ltLoss = setOff(&lt, ltLoss)
+
+	exemption := equityLTCGExemption
+	if fyStart < equityExemptionFrom {
+		exemption = equityLTCGExemptionOld
+	}
+	r.LTCGExemption = roundAmount(exemption - setOff(&lt, exemption))
+
+	r.ShortTermGain = roundAmount(r.ShortTermGain)
+	r.LongTermGain = roundAmount(r.LongTermGain)
+	r.TaxableShortTerm = roundAmount(st[0] + st[1])
+	r.TaxableLongTerm = roundAmount(lt[0] + lt[1])
+	r.ShortTermLossCF = roundAmount(stLoss)
+	r.LongTermLossCF = roundAmount(ltLoss)
+	r.EstimatedTax = roundAmount(st[0]*equitySTCGRateOld + st[1]*equitySTCGRate +
+		lt[0]*equityLTCGRateOld + lt[1]*equityLTCGRate)
+}
+
+// setOff reduces gains by amount, current-rate bucket first, and returns the
+// part of amount left unabsorbed.
+func setOff(gains *[2]float64, amount float64) float64 {
+	for _, k := range []int{1, 0} {
+		d := math.Min(gains[k], amount)
+		gains[k] -= d
+		amount -= d
+	}
+	return amount
+}
+
+// parseFinancialYear reads "2024-25" as the year starting April 2024; an
+// empty string is the financial year containing now.
+func parseFinancialYear(fy string, now time.Time) (start, end time.Time, label string, err error) {
+	if fy == "" {
+		start, end = financialYear(now)
+	} else {
+		y, err1 := strconv.Atoi(fy[:min(4, len(fy))])
+		if err1 != nil || len(fy) != 7 || fy[4] != '-' || fy[5:] != fmt.Sprintf("%02d", (y+1)%100) {
+			return start, end, "", ErrInvalidRequest
+		}
+		start, end = financialYear(time.Date(y, time.April, 1, 0, 0, 0, 0, time.UTC))
+	}
+	return start, end, fmt.Sprintf("%d-%02d", start.Year(), (start.Year()+1)%100), nil
+}
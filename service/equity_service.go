@@ -0,0 +1,479 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrSecurityNotFound      = errors.New("security not found")
+	ErrInsufficientQuantity  = errors.New("sell quantity exceeds shares held")
+	ErrEquityActionNotFound  = errors.New("equity corporate action not found")
+	ErrEquityActionProcessed = errors.New("equity corporate action already processed")
+	ErrEquityActionNotDue    = errors.New("equity corporate action ex-date has not arrived")
+)
+
+type EquityService interface {
+	ImportBhavcopy(ctx context.Context, exchange string, data []byte) (*model.BhavcopyImportResult, error)
+	Trade(ctx context.Context, userID string, req *model.EquityTradeRequest) (*model.Holding, error)
+	ListHoldings(ctx context.Context, userID string) ([]model.Holding, error)
+	CapitalGains(ctx context.Context, userID, fy string) (*model.CapitalGainsReport, error)
+	RegisterAction(ctx context.Context, req *model.EquityActionRequest) (*model.EquityAction, error)
+	ListActions(ctx context.Context, status string) ([]model.EquityAction, error)
+	ProcessAction(ctx context.Context, id string) (*model.EquityAction, error)
+	ProcessDueActions(ctx context.Context, asOf time.Time) (int, error)
+}
+
+type equityService struct {
+	secRepo    repository.SecurityRepo
+	navRepo    repository.NAVRepo
+	portRepo   repository.PortfolioRepo
+	txnRepo    repository.TransactionRepo
+	actionRepo repository.EquityActionRepo
+	tx         repository.Transactor
+}
+
+func NewEquityService(sr repository.SecurityRepo, nr repository.NAVRepo, pr repository.PortfolioRepo, tr repository.TransactionRepo, ar repository.EquityActionRepo, tx repository.Transactor) EquityService {
+	return &equityService{sr, nr, pr, tr, ar, tx}
+}
+
+// ImportBhavcopy loads an exchange's end-of-day file: closes go to NAV
+// history, each security's latest close moves forward (an older file never
+// overwrites a newer price) and equity holdings are revalued.
+func (s *equityService) ImportBhavcopy(ctx context.Context, exchange string, data []byte) (*model.BhavcopyImportResult, error) {
+	exchange = strings.ToUpper(exchange)
+	if exchange != "NSE" && exchange != "BSE" {
+		return nil, ErrUnsupportedFormat
+	}
+	secs, skipped, errs, err := parseBhavcopy(exchange, data)
+	if err != nil {
+		return nil, err
+	}
+	res := &model.BhavcopyImportResult{Exchange: exchange, Skipped: skipped, Errors: errs}
+
+	points := make([]model.NAVPoint, 0, len(secs))
+	isins := make([]string, 0, len(secs))
+	for _, sec := range secs {
+		points = append(points, model.NAVPoint{SchemeCode: sec.ISIN, Date: sec.PriceDate, NAV: sec.Close})
+		isins = append(isins, sec.ISIN)
+	}
+	if err := s.navRepo.UpsertMany(ctx, points); err != nil {
+		return nil, err
+	}
+	stored, err := s.secRepo.FindByISINs(ctx, isins)
+	if err != nil {
+		return nil, err
+	}
+	storedDate := make(map[string]time.Time, len(stored))
+	for _, sec := range stored {
+		storedDate[sec.ISIN] = sec.PriceDate
+	}
+	latest := make(map[string]model.Security, len(secs))
+	var fresh []model.Security
+	for _, sec := range secs {
+		if storedDate[sec.ISIN].After(sec.PriceDate) {
+			continue
+		}
+		fresh = append(fresh, sec)
+		latest[sec.ISIN] = sec
+	}
+	if err := s.secRepo.UpsertMany(ctx, fresh); err != nil {
+		return nil, err
+	}
+	res.Securities = len(fresh)
+
+	portfolios, err := s.portRepo.FindByAssetType(ctx, model.AssetEquity)
+	if err != nil {
+		return nil, err
+	}
+	for i := range portfolios {
+		p := &portfolios[i]
+		for j := range p.Holdings {
+			if sec, ok := latest[p.Holdings[j].AssetID]; ok && p.Holdings[j].Equity != nil {
+				revalueEquity(&p.Holdings[j], &sec)
+			}
+		}
+		recomputeTotals(p)
+		if err := s.portRepo.Upsert(ctx, p); err != nil {
+			return nil, err
+		}
+		res.PortfoliosRevalued++
+	}
+	return res, nil
+}
+
+// Trade records a buy or sell in the ledger and updates the holding at
+// average cost. Charges add to the cost of a buy and come off the proceeds
+// of a sale. The portfolio is read, checked and written in the transaction
+// that records the trade, so concurrent sales cannot oversell a holding.
+func (s *equityService) Trade(ctx context.Context, userID string, req *model.EquityTradeRequest) (*model.Holding, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	date := req.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+	if (req.Side != "buy" && req.Side != "sell") || req.Quantity <= 0 || req.Quantity != math.Trunc(req.Quantity) ||
+		req.Price <= 0 || req.Charges < 0 || date.After(time.Now()) {
+		return nil, ErrInvalidRequest
+	}
+	sec, err := s.security(ctx, req.ISIN, req.Symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	var out model.Holding
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		p, err := s.portRepo.FindByUserID(ctx, oid)
+		if err != nil {
+			if !errors.Is(err, mongo.ErrNoDocuments) {
+				return err
+			}
+			p = &model.Portfolio{UserID: oid, Holdings: []model.Holding{}}
+		}
+		i := findAsset(p, model.AssetEquity, sec.ISIN)
+		if req.Side == "sell" && (i < 0 || p.Holdings[i].Equity.Quantity < req.Quantity) {
+			return ErrInsufficientQuantity
+		}
+		if i < 0 {
+			p.Holdings = append(p.Holdings, model.Holding{
+				AssetType:  model.AssetEquity,
+				AssetID:    sec.ISIN,
+				Category:   sec.Category,
+				SchemeName: sec.Name,
+				Equity:     &model.Equity{ISIN: sec.ISIN, Symbol: symbolOf(sec), Kind: sec.Kind},
+			})
+			i = len(p.Holdings) - 1
+		}
+		h := &p.Holdings[i]
+		eq := h.Equity
+
+		amount := roundAmount(req.Quantity * req.Price)
+		txn := &model.Transaction{
+			UserID:     oid,
+			SchemeCode: sec.ISIN,
+			SchemeName: sec.Name,
+			Type:       model.TxnEquityBuy,
+			Date:       date,
+			Units:      req.Quantity,
+			NAV:        req.Price,
+			Amount:     amount,
+			NetAmount:  roundAmount(amount + req.Charges),
+			Note:       eq.Symbol,
+		}
+		if req.Side == "sell" {
+			txn.Type = model.TxnEquitySell
+			txn.Units = -req.Quantity
+			txn.NetAmount = roundAmount(amount - req.Charges)
+		}
+
+		if req.Side == "buy" {
+			h.InvestedValue = roundAmount(h.InvestedValue + txn.NetAmount)
+			eq.Quantity += req.Quantity
+			eq.AvgCost = roundAmount(h.InvestedValue / eq.Quantity)
+		} else {
+			eq.Quantity -= req.Quantity
+			h.InvestedValue = roundAmount(eq.Quantity * eq.AvgCost)
+		}
+		revalueEquity(h, sec)
+		out = *h
+		if eq.Quantity == 0 {
+			p.Holdings = append(p.Holdings[:i], p.Holdings[i+1:]...)
+		}
+		recomputeTotals(p)
+		if err := s.portRepo.Upsert(ctx, p); err != nil {
+			return err
+		}
+		return s.txnRepo.Create(ctx, txn)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *equityService) ListHoldings(ctx context.Context, userID string) ([]model.Holding, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	out := []model.Holding{}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return out, nil
+		}
+		return nil, err
+	}
+	for _, h := range p.Holdings {
+		if h.AssetType == model.AssetEquity {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+// CapitalGains reports the financial year's realised gains on shares and
+// ETFs from the ledger. Every ETF is taxed as equity here; gold and debt
+// ETFs are taxed differently and their figures are indicative only.
+func (s *equityService) CapitalGains(ctx context.Context, userID, fy string) (*model.CapitalGainsReport, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	from, to, label, err := parseFinancialYear(fy, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	txns, err := s.txnRepo.FindByUserID(ctx, oid, time.Time{}, to)
+	if err != nil {
+		return nil, err
+	}
+	byISIN := make(map[string][]model.Transaction)
+	var order []string
+	for _, t := range txns {
+		switch t.Type {
+		case model.TxnEquityBuy, model.TxnEquitySell, model.TxnEquitySplit, model.TxnEquityBonus:
+			if _, ok := byISIN[t.SchemeCode]; !ok {
+				order = append(order, t.SchemeCode)
+			}
+			byISIN[t.SchemeCode] = append(byISIN[t.SchemeCode], t)
+		}
+	}
+
+	r := &model.CapitalGainsReport{FinancialYear: label, From: from, To: to, Entries: []model.CapitalGain{}}
+	for _, isin := range order {
+		gains, unmatched := equityGains(byISIN[isin], from, to)
+		r.Entries = append(r.Entries, gains...)
+		if unmatched > 0 {
+			r.UnmatchedSales = append(r.UnmatchedSales, fmt.Sprintf("%s: %g shares sold without recorded purchases", isin, unmatched))
+		}
+	}
+	summariseGains(r, from.Year())
+	return r, nil
+}
+
+func (s *equityService) RegisterAction(ctx context.Context, req *model.EquityActionRequest) (*model.EquityAction, error) {
+	if (req.Type != model.EquitySplit && req.Type != model.EquityBonus) || req.NewShares <= 0 || req.ForShares <= 0 ||
+		(req.Type == model.EquitySplit && req.NewShares <= req.ForShares) {
+		return nil, ErrInvalidRequest
+	}
+	sec, err := s.security(ctx, req.ISIN, "")
+	if err != nil {
+		return nil, err
+	}
+	a := &model.EquityAction{
+		ISIN:      sec.ISIN,
+		Name:      sec.Name,
+		Type:      req.Type,
+		NewShares: req.NewShares,
+		ForShares: req.ForShares,
+		ExDate:    req.ExDate,
+		Status:    "pending",
+	}
+	if a.ExDate.IsZero() {
+		a.ExDate = time.Now()
+	}
+	if err := s.actionRepo.Create(ctx, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (s *equityService) ListActions(ctx context.Context, status string) ([]model.EquityAction, error) {
+	return s.actionRepo.FindByStatus(ctx, status)
+}
+
+// ProcessAction credits split or bonus shares to everyone who held the
+// security the day before the ex-date; fractional entitlements are settled
+// in cash by the company and are not credited. Invested value is unchanged,
+// so average cost falls, and the held price is adjusted until the next
+// bhavcopy. Users already credited (by the action's ledger reference) are
+// skipped, so processing can be retried. Each holder is checked, re-read
+// and credited in one transaction with their ledger entry. Actions are not
+// processed before their ex-date.
+func (s *equityService) ProcessAction(ctx context.Context, id string) (*model.EquityAction, error) {
+	return s.processAction(ctx, id, time.Now())
+}
+
+func (s *equityService) processAction(ctx context.Context, id string, asOf time.Time) (*model.EquityAction, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrEquityActionNotFound
+	}
+	a, err := s.actionRepo.FindByID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEquityActionNotFound
+		}
+		return nil, err
+	}
+	if a.Status == "processed" {
+		return nil, ErrEquityActionProcessed
+	}
+	if asOf.Before(a.ExDate) {
+		return nil, ErrEquityActionNotDue
+	}
+
+	holders, err := s.portRepo.FindAssetHolders(ctx, model.AssetEquity, a.ISIN)
+	if err != nil {
+		return nil, err
+	}
+	ref := "eca:" + a.ID.Hex()
+	txnType, verb := model.TxnEquitySplit, "Split"
+	if a.Type == model.EquityBonus {
+		txnType, verb = model.TxnEquityBonus, "Bonus"
+	}
+	summary := &model.EquityActionSummary{}
+	for _, holder := range holders {
+		userID := holder.UserID
+		var added float64
+		err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			added = 0
+			done, err := s.txnRepo.ExistsByReference(ctx, userID, ref)
+			if err != nil || done {
+				return err
+			}
+			p, err := s.portRepo.FindByUserID(ctx, userID)
+			if err != nil {
+				if errors.Is(err, mongo.ErrNoDocuments) {
+					return nil
+				}
+				return err
+			}
+			i := findAsset(p, model.AssetEquity, a.ISIN)
+			if i < 0 {
+				return nil // sold out since the holders were listed
+			}
+			h := &p.Holdings[i]
+			moved, err := s.txnRepo.SumUnitsAfter(ctx, userID, a.ISIN, endOfDay(a.ExDate.AddDate(0, 0, -1)))
+			if err != nil {
+				return err
+			}
+			held := h.Equity.Quantity - moved
+			if held <= 0 {
+				return nil
+			}
+			entitled := math.Floor(held*a.NewShares/a.ForShares + 1e-9)
+			n := entitled
+			if a.Type == model.EquitySplit {
+				n = entitled - held
+			}
+			if n <= 0 {
+				return nil
+			}
+			txn := &model.Transaction{
+				UserID:     userID,
+				SchemeCode: a.ISIN,
+				SchemeName: a.Name,
+				Type:       txnType,
+				Date:       a.ExDate,
+				Units:      n,
+				Reference:  ref,
+				Note:       fmt.Sprintf("%s %g:%g on %g shares", verb, a.NewShares, a.ForShares, held),
+			}
+			// A price from before the ex-date is for the old share count;
+			// one from a later bhavcopy already reflects the action.
+			if h.Equity.PriceDate.Before(a.ExDate) {
+				h.CurrentNAV = roundAmount(h.CurrentNAV * held / (held + n))
+			}
+			h.Equity.Quantity += n
+			h.Equity.AvgCost = roundAmount(h.InvestedValue / h.Equity.Quantity)
+			h.Units = h.Equity.Quantity
+			revalue(h)
+			recomputeTotals(p)
+			if err := s.portRepo.Upsert(ctx, p); err != nil {
+				return err
+			}
+			if err := s.txnRepo.Create(ctx, txn); err != nil {
+				return err
+			}
+			added = n
+			return nil
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			continue // credited by a concurrent run
+		}
+		if err != nil {
+			return nil, err
+		}
+		if added > 0 {
+			summary.Holdings++
+			summary.SharesAdded += added
+		}
+	}
+
+	marked, err := s.actionRepo.MarkProcessed(ctx, a.ID, summary)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, ErrEquityActionProcessed
+	}
+	return s.actionRepo.FindByID(ctx, a.ID)
+}
+
+func (s *equityService) ProcessDueActions(ctx context.Context, asOf time.Time) (int, error) {
+	due, err := s.actionRepo.FindDue(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+	processed := 0
+	for _, a := range due {
+		if _, err := s.processAction(ctx, a.ID.Hex(), asOf); err != nil {
+			log.Printf("equity action %s (%s %s): %v", a.ID.Hex(), a.Type, a.ISIN, err)
+			continue
+		}
+		processed++
+	}
+	return processed, nil
+}
+
+// security looks a security up by ISIN, or by exchange symbol when no ISIN
+// is given.
+func (s *equityService) security(ctx context.Context, isin, symbol string) (*model.Security, error) {
+	var (
+		sec *model.Security
+		err error
+	)
+	if isin = strings.ToUpper(strings.TrimSpace(isin)); isin != "" {
+		sec, err = s.secRepo.FindByISIN(ctx, isin)
+	} else {
+		sec, err = s.secRepo.FindBySymbol(ctx, strings.ToUpper(strings.TrimSpace(symbol)))
+	}
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrSecurityNotFound
+		}
+		return nil, err
+	}
+	return sec, nil
+}
+
+// revalueEquity values a holding at the security's latest close.
+func revalueEquity(h *model.Holding, sec *model.Security) {
+	h.Units = h.Equity.Quantity
+	h.CurrentNAV = sec.Close
+	h.Category = sec.Category
+	h.Equity.PriceDate = sec.PriceDate
+	revalue(h)
+}
+
+func symbolOf(sec *model.Security) string {
+	if sec.NSESymbol != "" {
+		return sec.NSESymbol
+	}
+	return sec.BSECode
+}
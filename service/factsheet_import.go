@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+)
+
+const importDateLayout = "2006-01-02"
+
+// factsheetRecord is the wire shape shared by the JSON and CSV imports. Dates
+// are plain YYYY-MM-DD strings as published in AMC factsheets.
+type factsheetRecord struct {
+	SchemeCode       string                   `json:"scheme_code"`
+	AsOf             string                   `json:"as_of"`
+	ExpenseRatio     float64                  `json:"expense_ratio"`
+	AUM              float64                  `json:"aum"`
+	FundManagers     []string                 `json:"fund_managers"`
+	Benchmark        string                   `json:"benchmark"`
+	InceptionDate    string                   `json:"inception_date"`
+	TopHoldings      []model.FactsheetHolding `json:"top_holdings"`
+	SectorAllocation map[string]float64       `json:"sector_allocation"`
+}
+
+func (r *factsheetRecord) toFactsheet() (*model.Factsheet, error) {
+	if r.SchemeCode == "" {
+		return nil, fmt.Errorf("scheme_code is required")
+	}
+	asOf, err := time.Parse(importDateLayout, r.AsOf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid as_of %q", r.AsOf)
+	}
+	fs := &model.Factsheet{
+		SchemeCode:       r.SchemeCode,
+		AsOf:             time.Date(asOf.Year(), asOf.Month(), 1, 0, 0, 0, 0, time.UTC),
+		ExpenseRatio:     r.ExpenseRatio,
+		AUM:              r.AUM,
+		FundManagers:     r.FundManagers,
+		Benchmark:        r.Benchmark,
+		TopHoldings:      r.TopHoldings,
+		SectorAllocation: r.SectorAllocation,
+	}
+	if r.InceptionDate != "" {
+		if fs.InceptionDate, err = time.Parse(importDateLayout, r.InceptionDate); err != nil {
+			return nil, fmt.Errorf("invalid inception_date %q", r.InceptionDate)
+		}
+	}
+	if fs.FundManagers == nil {
+		fs.FundManagers = []string{}
+	}
+	if fs.TopHoldings == nil {
+		fs.TopHoldings = []model.FactsheetHolding{}
+	}
+	if fs.SectorAllocation == nil {
+		fs.SectorAllocation = map[string]float64{}
+	}
+	return fs, nil
+}
+
+func parseFactsheetJSON(data []byte) ([]model.Factsheet, []string, error) {
+	var records []factsheetRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	var (
+		sheets []model.Factsheet
+		errs   []string
+	)
+	for i := range records {
+		fs, err := records[i].toFactsheet()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("record %d: %v", i+1, err))
+			continue
+		}
+		sheets = append(sheets, *fs)
+	}
+	return sheets, errs, nil
+}
+
+// parseFactsheetCSV reads one factsheet per row. List columns use ";" between
+// entries and "|" between fields:
+//
+//	fund_managers:     "A Shah;R Iyer"
+//	top_holdings:      "INE040A01034|HDFC Bank|Financial Services|9.8;..."
+//	sector_allocation: "Financial Services|32.1;IT|12.4"
+func parseFactsheetCSV(data []byte) ([]model.Factsheet, []string, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	header, err := r.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: missing header row", ErrUnsupportedFormat)
+	}
+	col := make(map[string]int, len(header))
+	for i, h := range header {
+		col[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+	for _, required := range []string{"scheme_code", "as_of"} {
+		if _, ok := col[required]; !ok {
+			return nil, nil, fmt.Errorf("%w: missing column %s", ErrUnsupportedFormat, required)
+		}
+	}
+
+	var (
+		sheets []model.Factsheet
+		errs   []string
+	)
+	for line := 2; ; line++ {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+
+		rec := factsheetRecord{
+			SchemeCode:    get("scheme_code"),
+			AsOf:          get("as_of"),
+			Benchmark:     get("benchmark"),
+			InceptionDate: get("inception_date"),
+			FundManagers:  splitList(get("fund_managers")),
+		}
+		if rec.ExpenseRatio, err = parseOptionalFloat(get("expense_ratio")); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: invalid expense_ratio", line))
+			continue
+		}
+		if rec.AUM, err = parseOptionalFloat(get("aum")); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: invalid aum", line))
+			continue
+		}
+		if rec.TopHoldings, err = parseHoldingList(get("top_holdings")); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		if rec.SectorAllocation, err = parseWeightMap(get("sector_allocation")); err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+
+		fs, err := rec.toFactsheet()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		sheets = append(sheets, *fs)
+	}
+	return sheets, errs, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ";") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func parseOptionalFloat(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+func parseHoldingList(s string) ([]model.FactsheetHolding, error) {
+	var out []model.FactsheetHolding
+	for _, entry := range splitList(s) {
+		f := strings.Split(entry, "|")
+		if len(f) != 4 {
+			return nil, fmt.Errorf("invalid top_holdings entry %q", entry)
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(f[3]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid top_holdings weight %q", f[3])
+		}
+		out = append(out, model.FactsheetHolding{
+			ISIN:   strings.TrimSpace(f[0]),
+			Name:   strings.TrimSpace(f[1]),
+			Sector: strings.TrimSpace(f[2]),
+			Weight: w,
+		})
+	}
+	return out, nil
+}
+
+func parseWeightMap(s string) (map[string]float64, error) {
+	out := make(map[string]float64)
+	for _, entry := range splitList(s) {
+		f := strings.Split(entry, "|")
+		if len(f) != 2 {
+			return nil, fmt.Errorf("invalid sector_allocation entry %q", entry)
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(f[1]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sector_allocation weight %q", f[1])
+		}
+		out[strings.TrimSpace(f[0])] = w
+	}
+	return out, nil
+}
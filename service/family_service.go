@@ -0,0 +1,585 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrFamilyNotFound       = errors.New("family group not found")
+	ErrAlreadyInFamily      = errors.New("already in a family group")
+	ErrNotFamilyOwner       = errors.New("only the family group owner can do this")
+	ErrFamilyMemberNotFound = errors.New("family member not found")
+	ErrFamilyOwnerLeaving   = errors.New("the owner cannot leave while other members remain")
+	ErrConsentNotFound      = errors.New("consent not found")
+	ErrFamilyAccessDenied   = errors.New("no current consent from this family member")
+)
+
+const (
+	// familyConsentMaxDays bounds how far ahead a consent may expire.
+	familyConsentMaxDays = 365
+	familyAccessLogLimit = 100
+	// familyTopHoldings is the number of holdings in combined analytics,
+	// matching the single-user analytics.
+	familyTopHoldings = 5
+)
+
+// Actions recorded in the access log.
+const (
+	familyActionPortfolio       = "portfolio.view"
+	familyActionFamilyPortfolio = "family_portfolio.view"
+	familyActionCreateSIP       = "sip.create"
+)
+
+type FamilyService interface {
+	Create(ctx context.Context, userID string, req *model.FamilyGroupRequest) (*model.FamilyGroup, error)
+	Get(ctx context.Context, userID string) (*model.FamilyView, error)
+	Invite(ctx context.Context, userID string, req *model.FamilyInviteRequest) (*model.FamilyGroup, error)
+	Accept(ctx context.Context, userID, groupID string) (*model.FamilyGroup, error)
+	RemoveMember(ctx context.Context, userID, memberID string) error
+	GrantConsent(ctx context.Context, userID string, req *model.FamilyConsentRequest) (*model.FamilyConsent, error)
+	RevokeConsent(ctx context.Context, userID, consentID string) error
+	Portfolio(ctx context.Context, userID string) (*model.FamilyPortfolio, error)
+	MemberPortfolio(ctx context.Context, userID, memberID string) (*model.Portfolio, error)
+	CreateMemberSIP(ctx context.Context, userID, memberID string, req *model.CreateSIPRequest) (*model.SIP, error)
+	AccessLog(ctx context.Context, userID string) ([]model.FamilyAccessLog, error)
+}
+
+type familyService struct {
+	familyRepo  repository.FamilyRepo
+	memberRepo  repository.FamilyMembershipRepo
+	consentRepo repository.FamilyConsentRepo
+	logRepo     repository.FamilyAccessLogRepo
+	wealthSvc   WealthService
+	goalSvc     GoalService
+	tx          repository.Transactor
+}
+
+func NewFamilyService(fr repository.FamilyRepo, mr repository.FamilyMembershipRepo, cr repository.FamilyConsentRepo, lr repository.FamilyAccessLogRepo, ws WealthService, gs GoalService, tx repository.Transactor) FamilyService {
+	return &familyService{fr, mr, cr, lr, ws, gs, tx}
+}
+
+// Create starts a group with the caller as owner. The group and the
+// owner's membership are written together, so a caller who joins another
+// group meanwhile gets ErrAlreadyInFamily.
+func (s *familyService) Create(ctx context.Context, userID string, req *model.FamilyGroupRequest) (*model.FamilyGroup, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, ErrInvalidRequest
+	}
+	if _, err := s.groupOf(ctx, oid); err == nil {
+		return nil, ErrAlreadyInFamily
+	} else if !errors.Is(err, ErrFamilyNotFound) {
+		return nil, err
+	}
+	now := time.Now()
+	g := &model.FamilyGroup{
+		Name:    name,
+		OwnerID: oid,
+		Members: []model.FamilyMember{{UserID: oid, Status: model.FamilyMemberActive, InvitedAt: now, JoinedAt: &now}},
+	}
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.familyRepo.Create(ctx, g); err != nil {
+			return err
+		}
+		return s.memberRepo.Create(ctx, &model.FamilyMembership{UserID: oid, GroupID: g.ID, JoinedAt: now})
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrAlreadyInFamily
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (s *familyService) Get(ctx context.Context, userID string) (*model.FamilyView, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	g, err := s.groupOf(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	consents, err := s.consentRepo.FindActiveByGroup(ctx, g.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	v := &model.FamilyView{Group: *g, ConsentsGiven: []model.FamilyConsent{}, ConsentsReceived: []model.FamilyConsent{}}
+	for _, c := range consents {
+		switch oid {
+		case c.GrantorID:
+			v.ConsentsGiven = append(v.ConsentsGiven, c)
+		case c.GranteeID:
+			v.ConsentsReceived = append(v.ConsentsReceived, c)
+		}
+	}
+	return v, nil
+}
+
+// Invite adds a pending member; the invitee joins by accepting.
+func (s *familyService) Invite(ctx context.Context, userID string, req *model.FamilyInviteRequest) (*model.FamilyGroup, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	invitee, err := bson.ObjectIDFromHex(req.UserID)
+	if err != nil || invitee == oid {
+		return nil, ErrInvalidRequest
+	}
+	g, err := s.groupOf(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	if g.OwnerID != oid {
+		return nil, ErrNotFamilyOwner
+	}
+	if _, err := s.groupOf(ctx, invitee); err == nil {
+		return nil, ErrAlreadyInFamily
+	} else if !errors.Is(err, ErrFamilyNotFound) {
+		return nil, err
+	}
+	m := model.FamilyMember{UserID: invitee, Name: strings.TrimSpace(req.Name), Status: model.FamilyMemberInvited, InvitedAt: time.Now()}
+	added, err := s.familyRepo.AddMember(ctx, g.ID, m)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, ErrAlreadyInFamily
+	}
+	return s.familyRepo.FindByID(ctx, g.ID)
+}
+
+// Accept activates the caller's invitation. The membership record written
+// with it is unique per user, so of two invitations accepted at once only
+// one succeeds.
+func (s *familyService) Accept(ctx context.Context, userID, groupID string) (*model.FamilyGroup, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	gid, err := bson.ObjectIDFromHex(groupID)
+	if err != nil {
+		return nil, ErrFamilyNotFound
+	}
+	if _, err := s.groupOf(ctx, oid); err == nil {
+		return nil, ErrAlreadyInFamily
+	} else if !errors.Is(err, ErrFamilyNotFound) {
+		return nil, err
+	}
+	now := time.Now()
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		ok, err := s.familyRepo.ActivateMember(ctx, gid, oid, now)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrFamilyNotFound
+		}
+		return s.memberRepo.Create(ctx, &model.FamilyMembership{UserID: oid, GroupID: gid, JoinedAt: now})
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return nil, ErrAlreadyInFamily
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.familyRepo.FindByID(ctx, gid)
+}
+
+// RemoveMember lets the owner remove a member or withdraw an invitation, and
+// any member leave. Consents the departing member gave or received in the
+// group are revoked. The owner can leave only as the last active member,
+// which dissolves the group.
+func (s *familyService) RemoveMember(ctx context.Context, userID, memberID string) error {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrUnauthorized
+	}
+	target, err := bson.ObjectIDFromHex(memberID)
+	if err != nil {
+		return ErrFamilyMemberNotFound
+	}
+	g, err := s.groupOf(ctx, oid)
+	if err != nil {
+		return err
+	}
+	if target != oid && g.OwnerID != oid {
+		return ErrNotFamilyOwner
+	}
+	if target == g.OwnerID {
+		for _, m := range g.Members {
+			if m.UserID != target && m.Status == model.FamilyMemberActive {
+				return ErrFamilyOwnerLeaving
+			}
+		}
+		return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			if _, err := s.consentRepo.RevokeInvolving(ctx, g.ID, target, time.Now()); err != nil {
+				return err
+			}
+			if err := s.memberRepo.DeleteByGroup(ctx, g.ID); err != nil {
+				return err
+			}
+			return s.familyRepo.Delete(ctx, g.ID)
+		})
+	}
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		removed, err := s.familyRepo.RemoveMember(ctx, g.ID, target)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return ErrFamilyMemberNotFound
+		}
+		if err := s.memberRepo.Delete(ctx, g.ID, target); err != nil {
+			return err
+		}
+		_, err = s.consentRepo.RevokeInvolving(ctx, g.ID, target, time.Now())
+		return err
+	})
+}
+
+// GrantConsent lets another active member of the caller's group access the
+// caller's data until ExpiresAt. It replaces any consent already in force
+// for that member. The revoke and the grant are one transaction that also
+// touches the group, so concurrent grants conflict and at most one consent
+// per pair is ever in force.
+func (s *familyService) GrantConsent(ctx context.Context, userID string, req *model.FamilyConsentRequest) (*model.FamilyConsent, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	grantee, err := bson.ObjectIDFromHex(req.GranteeID)
+	now := time.Now()
+	if err != nil || grantee == oid || (req.Scope != model.FamilyScopeView && req.Scope != model.FamilyScopeManage) ||
+		!req.ExpiresAt.After(now) || req.ExpiresAt.After(now.AddDate(0, 0, familyConsentMaxDays)) {
+		return nil, ErrInvalidRequest
+	}
+	g, err := s.groupOf(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	if !activeMember(g, grantee) {
+		return nil, ErrFamilyMemberNotFound
+	}
+	c := &model.FamilyConsent{
+		GroupID:   g.ID,
+		GrantorID: oid,
+		GranteeID: grantee,
+		Scope:     req.Scope,
+		GrantedAt: now,
+		ExpiresAt: req.ExpiresAt,
+	}
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.familyRepo.Touch(ctx, g.ID); err != nil {
+			return err
+		}
+		if _, err := s.consentRepo.RevokePair(ctx, g.ID, oid, grantee, now); err != nil {
+			return err
+		}
+		return s.consentRepo.Create(ctx, c)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// RevokeConsent withdraws a consent the caller gave.
+func (s *familyService) RevokeConsent(ctx context.Context, userID, consentID string) error {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrUnauthorized
+	}
+	cid, err := bson.ObjectIDFromHex(consentID)
+	if err != nil {
+		return ErrConsentNotFound
+	}
+	c, err := s.consentRepo.FindByID(ctx, cid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrConsentNotFound
+		}
+		return err
+	}
+	if c.GrantorID != oid {
+		return ErrConsentNotFound
+	}
+	if _, err := s.consentRepo.Revoke(ctx, cid, time.Now()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Portfolio combines the caller's holdings, analytics and goals with those
+// of every member who currently lets the caller view their data. Each
+// member included is authorized and logged individually.
+func (s *familyService) Portfolio(ctx context.Context, userID string) (*model.FamilyPortfolio, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	g, err := s.groupOf(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	consents, err := s.consentRepo.FindActiveByGroup(ctx, g.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	granted := make(map[bson.ObjectID]bool)
+	for _, c := range consents {
+		if c.GranteeID == oid {
+			granted[c.GrantorID] = true
+		}
+	}
+
+	fp := &model.FamilyPortfolio{
+		GroupID:  g.ID,
+		Members:  []model.FamilyMemberValue{},
+		Holdings: []model.FamilyHolding{},
+		Goals:    []model.FamilyGoal{},
+	}
+	holdings := make(map[string]*model.FamilyHolding)
+	var order []string
+	all := []model.Holding{}
+	category := make(map[string]float64)
+	asset := make(map[string]float64)
+	for _, m := range g.Members {
+		if m.Status != model.FamilyMemberActive {
+			continue
+		}
+		mv := model.FamilyMemberValue{UserID: m.UserID, Name: m.Name, Self: m.UserID == oid}
+		if !mv.Self {
+			if !granted[m.UserID] {
+				fp.Excluded = append(fp.Excluded, m.UserID)
+				continue
+			}
+			c, err := s.authorize(ctx, g, oid, m.UserID, model.FamilyScopeView, familyActionFamilyPortfolio)
+			if errors.Is(err, ErrFamilyAccessDenied) {
+				fp.Excluded = append(fp.Excluded, m.UserID) // lapsed since the lookup
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			mv.Scope, mv.ExpiresAt = c.Scope, &c.ExpiresAt
+		}
+
+		member := m.UserID.Hex()
+		p, err := s.wealthSvc.GetPortfolio(ctx, member)
+		if err != nil {
+			return nil, err
+		}
+		a, err := s.wealthSvc.GetPortfolioAnalytics(ctx, member)
+		if err != nil {
+			return nil, err
+		}
+		goals, err := s.goalSvc.List(ctx, member)
+		if err != nil {
+			return nil, err
+		}
+
+		mv.CurrentValue, mv.TotalInvested, mv.GainLoss = roundAmount(a.CurrentValue), roundAmount(a.TotalInvested), roundAmount(a.TotalGainLoss)
+		fp.Members = append(fp.Members, mv)
+		fp.Analytics.CurrentValue += a.CurrentValue
+		fp.Analytics.TotalInvested += a.TotalInvested
+		fp.Analytics.TotalGainLoss += a.TotalGainLoss
+		for k, pct := range a.CategoryBreakdown {
+			category[k] += pct / 100 * a.CurrentValue
+		}
+		for k, pct := range a.AssetBreakdown {
+			asset[k] += pct / 100 * a.CurrentValue
+		}
+		for _, gp := range goals {
+			fp.Goals = append(fp.Goals, model.FamilyGoal{UserID: m.UserID, GoalProgress: gp})
+		}
+		for i := range p.Holdings {
+			h := &p.Holdings[i]
+			all = append(all, *h)
+			key, code, units := familyHoldingKey(h, m.UserID)
+			agg, ok := holdings[key]
+			if !ok {
+				agg = &model.FamilyHolding{AssetType: h.AssetType, Code: code, Name: h.SchemeName}
+				if isFund(h) {
+					agg.AssetType = model.AssetMutualFund
+				}
+				holdings[key] = agg
+				order = append(order, key)
+			}
+			agg.Units += units
+			agg.CurrentValue += h.CurrentValue
+			agg.InvestedValue += h.InvestedValue
+			agg.GainLoss += h.GainLoss
+			if n := len(agg.HeldBy); n == 0 || agg.HeldBy[n-1] != m.UserID {
+				agg.HeldBy = append(agg.HeldBy, m.UserID)
+			}
+		}
+	}
+
+	for _, key := range order {
+		h := holdings[key]
+		h.Units, h.CurrentValue = roundUnits(h.Units), roundAmount(h.CurrentValue)
+		h.InvestedValue, h.GainLoss = roundAmount(h.InvestedValue), roundAmount(h.GainLoss)
+		fp.Holdings = append(fp.Holdings, *h)
+	}
+	sort.SliceStable(fp.Holdings, func(i, j int) bool { return fp.Holdings[i].CurrentValue > fp.Holdings[j].CurrentValue })
+
+	an := &fp.Analytics
+	an.CategoryBreakdown, an.AssetBreakdown = map[string]float64{}, map[string]float64{}
+	for _, b := range []struct{ in, out map[string]float64 }{{category, an.CategoryBreakdown}, {asset, an.AssetBreakdown}} {
+		for k, v := range b.in {
+			b.out[k] = 0
+			if an.CurrentValue > 0 {
+				b.out[k] = roundAmount(v / an.CurrentValue * 100)
+			}
+		}
+	}
+	if an.TotalInvested > 0 {
+		an.ReturnPct = roundAmount(an.TotalGainLoss / an.TotalInvested * 100)
+	}
+	an.CurrentValue, an.TotalInvested, an.TotalGainLoss = roundAmount(an.CurrentValue), roundAmount(an.TotalInvested), roundAmount(an.TotalGainLoss)
+	sort.SliceStable(all, func(i, j int) bool { return all[i].CurrentValue > all[j].CurrentValue })
+	an.TopHoldings = all[:min(familyTopHoldings, len(all))]
+	return fp, nil
+}
+
+// MemberPortfolio returns another member's portfolio under a view consent,
+// or the caller's own.
+func (s *familyService) MemberPortfolio(ctx context.Context, userID, memberID string) (*model.Portfolio, error) {
+	subject, err := s.access(ctx, userID, memberID, model.FamilyScopeView, familyActionPortfolio)
+	if err != nil {
+		return nil, err
+	}
+	return s.wealthSvc.GetPortfolio(ctx, subject.Hex())
+}
+
+// CreateMemberSIP starts a SIP for another member under a manage consent.
+func (s *familyService) CreateMemberSIP(ctx context.Context, userID, memberID string, req *model.CreateSIPRequest) (*model.SIP, error) {
+	subject, err := s.access(ctx, userID, memberID, model.FamilyScopeManage, familyActionCreateSIP)
+	if err != nil {
+		return nil, err
+	}
+	return s.wealthSvc.CreateSIP(ctx, subject.Hex(), req)
+}
+
+// AccessLog lists recent accesses to the caller's data by family members.
+func (s *familyService) AccessLog(ctx context.Context, userID string) ([]model.FamilyAccessLog, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	logs, err := s.logRepo.FindBySubject(ctx, oid, familyAccessLogLimit)
+	if err != nil {
+		return nil, err
+	}
+	if logs == nil {
+		logs = []model.FamilyAccessLog{}
+	}
+	return logs, nil
+}
+
+// access resolves the caller and the member they want to act on. Acting on
+// one's own data needs no consent; anything else goes through authorize.
+func (s *familyService) access(ctx context.Context, userID, memberID, scope, action string) (bson.ObjectID, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return bson.ObjectID{}, ErrUnauthorized
+	}
+	subject, err := bson.ObjectIDFromHex(memberID)
+	if err != nil {
+		return bson.ObjectID{}, ErrFamilyMemberNotFound
+	}
+	if subject == oid {
+		return oid, nil
+	}
+	g, err := s.groupOf(ctx, oid)
+	if err != nil {
+		return bson.ObjectID{}, err
+	}
+	if !activeMember(g, subject) {
+		return bson.ObjectID{}, ErrFamilyMemberNotFound
+	}
+	if _, err := s.authorize(ctx, g, oid, subject, scope, action); err != nil {
+		return bson.ObjectID{}, err
+	}
+	return subject, nil
+}
+
+// authorize checks that actor holds a consent from subject, in force now
+// and covering scope, and records the attempt in the access log whether or
+// not it is allowed. Manage consents cover view access.
+func (s *familyService) authorize(ctx context.Context, g *model.FamilyGroup, actor, subject bson.ObjectID, scope, action string) (*model.FamilyConsent, error) {
+	c, err := s.consentRepo.FindActive(ctx, g.ID, subject, actor, time.Now())
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if c != nil && scope == model.FamilyScopeManage && c.Scope != model.FamilyScopeManage {
+		c = nil
+	}
+	entry := &model.FamilyAccessLog{
+		GroupID:   g.ID,
+		ActorID:   actor,
+		SubjectID: subject,
+		Action:    action,
+		Scope:     scope,
+		Allowed:   c != nil,
+	}
+	if c != nil {
+		entry.ConsentID = &c.ID
+	}
+	if err := s.logRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	if c == nil {
+		return nil, ErrFamilyAccessDenied
+	}
+	return c, nil
+}
+
+// groupOf returns the group the user is an active member of.
+func (s *familyService) groupOf(ctx context.Context, userID bson.ObjectID) (*model.FamilyGroup, error) {
+	g, err := s.familyRepo.FindByMember(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrFamilyNotFound
+		}
+		return nil, err
+	}
+	return g, nil
+}
+
+// familyHoldingKey groups holdings for the combined view. Funds and
+// securities are keyed by scheme code or ISIN so that members' holdings add
+// up; deposits, gold and NPS stay one entry per member holding.
+func familyHoldingKey(h *model.Holding, owner bson.ObjectID) (key, code string, units float64) {
+	switch {
+	case isFund(h):
+		return model.AssetMutualFund + "|" + h.SchemeCode, h.SchemeCode, h.Units
+	case h.Equity != nil:
+		return model.AssetEquity + "|" + h.Equity.ISIN, h.Equity.ISIN, h.Equity.Quantity
+	}
+	return h.AssetType + "|" + h.AssetID + "|" + owner.Hex(), h.AssetID, h.Units
+}
+
+func activeMember(g *model.FamilyGroup, userID bson.ObjectID) bool {
+	for _, m := range g.Members {
+		if m.UserID == userID {
+			return m.Status == model.FamilyMemberActive
+		}
+	}
+	return false
+}
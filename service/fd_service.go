@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// fdReminderDays is how far ahead of maturity the reminder goes out.
+const fdReminderDays = 7
+
+var ErrDepositNotFound = errors.New("fixed deposit not found")
+
+type FixedDepositService interface {
+	Add(ctx context.Context, userID string, req *model.FixedDepositRequest) (*model.Holding, error)
+	List(ctx context.Context, userID string) ([]model.Holding, error)
+	Remove(ctx context.Context, userID, id string) error
+	SendMaturityReminders(ctx context.Context, now time.Time) (int, error)
+}
+
+type fixedDepositService struct {
+	portRepo  repository.PortfolioRepo
+	notifRepo repository.NotificationRepo
+	tx        repository.Transactor
+}
+
+func NewFixedDepositService(pr repository.PortfolioRepo, nr repository.NotificationRepo, tx repository.Transactor) FixedDepositService {
+	return &fixedDepositService{pr, nr, tx}
+}
+
+func (s *fixedDepositService) Add(ctx context.Context, userID string, req *model.FixedDepositRequest) (*model.Holding, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	fd := &model.FixedDeposit{
+		ID:            bson.NewObjectID(),
+		Bank:          strings.TrimSpace(req.Bank),
+		AccountNumber: maskAccount(req.AccountNumber),
+		Principal:     roundAmount(req.Principal),
+		RatePct:       req.RatePct,
+		Compounding:   req.Compounding,
+		Payout:        req.Payout,
+		StartDate:     req.StartDate,
+		MaturityDate:  req.MaturityDate,
+		Status:        model.FDActive,
+	}
+	if fd.Compounding == "" {
+		fd.Compounding = "quarterly"
+	}
+	if fd.Payout == "" {
+		fd.Payout = model.FDPayoutCumulative
+	}
+	if fd.StartDate.IsZero() {
+		fd.StartDate = time.Now()
+	}
+	if fd.MaturityDate.IsZero() && req.TenureMonths > 0 {
+		fd.MaturityDate = fd.StartDate.AddDate(0, req.TenureMonths, 0)
+	}
+	validPayout := fd.Payout == model.FDPayoutCumulative || fd.Payout == "monthly" || fd.Payout == "quarterly"
+	if fd.Bank == "" || fd.Principal <= 0 || fd.RatePct <= 0 || fd.RatePct > 20 ||
+		fdPeriods[fd.Compounding] == 0 || !validPayout || !fd.MaturityDate.After(fd.StartDate) {
+		return nil, ErrInvalidRequest
+	}
+	maturity, _ := accrueDeposit(fd, fd.MaturityDate)
+	fd.MaturityAmount = roundAmount(maturity)
+
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		p = &model.Portfolio{UserID: oid, Holdings: []model.Holding{}}
+	}
+	p.Holdings = append(p.Holdings, model.Holding{
+		AssetType:  model.AssetFixedDeposit,
+		AssetID:    fd.ID.Hex(),
+		Category:   "debt",
+		SchemeName: fmt.Sprintf("%s FD %s", fd.Bank, fd.AccountNumber),
+		FD:         fd,
+	})
+	revalueDeposits(p, time.Now())
+	if err := s.portRepo.Upsert(ctx, p); err != nil {
+		return nil, err
+	}
+	h := p.Holdings[len(p.Holdings)-1]
+	return &h, nil
+}
+
+func (s *fixedDepositService) List(ctx context.Context, userID string) ([]model.Holding, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	deposits := []model.Holding{}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return deposits, nil
+		}
+		return nil, err
+	}
+	revalueDeposits(p, time.Now())
+	for _, h := range p.Holdings {
+		if h.AssetType == model.AssetFixedDeposit {
+			deposits = append(deposits, h)
+		}
+	}
+	return deposits, nil
+}
+
+// Remove drops a deposit from the portfolio, for when it has been closed or
+// paid out.
+func (s *fixedDepositService) Remove(ctx context.Context, userID, id string) error {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrUnauthorized
+	}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrDepositNotFound
+		}
+		return err
+	}
+	i := findAsset(p, model.AssetFixedDeposit, id)
+	if i < 0 {
+		return ErrDepositNotFound
+	}
+	p.Holdings = append(p.Holdings[:i], p.Holdings[i+1:]...)
+	revalueDeposits(p, time.Now())
+	return s.portRepo.Upsert(ctx, p)
+}
+
+// SendMaturityReminders notifies users of deposits maturing within
+// fdReminderDays. Each deposit is reminded once; one that has already
+// matured when first seen, such as a back-dated deposit, still gets its
+// reminder. The notification and the deposit's reminder flag are written
+// together, and a failing deposit is logged and skipped.
+func (s *fixedDepositService) SendMaturityReminders(ctx context.Context, now time.Time) (int, error) {
+	by := now.AddDate(0, 0, fdReminderDays)
+	portfolios, err := s.portRepo.FindWithMaturingDeposits(ctx, by)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for i := range portfolios {
+		p := &portfolios[i]
+		for j := range p.Holdings {
+			fd := p.Holdings[j].FD
+			if fd == nil || fd.ReminderSent || fd.MaturityDate.After(by) {
+				continue
+			}
+			reminded, err := s.remind(ctx, p.UserID, fd, now)
+			if err != nil {
+				log.Printf("fd reminders: deposit %s: %v", fd.ID.Hex(), err)
+				continue
+			}
+			if reminded {
+				sent++
+			}
+		}
+	}
+	return sent, nil
+}
+
+// remind notifies the user of one deposit's maturity and flags it as
+// reminded in one transaction, reporting false when another run got there
+// first.
+func (s *fixedDepositService) remind(ctx context.Context, userID bson.ObjectID, fd *model.FixedDeposit, now time.Time) (bool, error) {
+	verb := "matures"
+	if fd.MaturityDate.Before(now) {
+		verb = "matured"
+	}
+	var reminded bool
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if reminded, err = s.portRepo.MarkDepositReminded(ctx, userID, fd.ID.Hex()); err != nil || !reminded {
+			return err
+		}
+		return s.notifRepo.Create(ctx, &model.NotificationEvent{
+			UserID: userID,
+			Type:   model.EventFDMaturing,
+			Title:  "Fixed deposit maturing",
+			Body: fmt.Sprintf("Your %s fixed deposit %s of ₹%.2f %s on %s with ₹%.2f due.",
+				fd.Bank, fd.AccountNumber, fd.Principal, verb, fd.MaturityDate.Format("02 Jan 2006"), fd.MaturityAmount),
+			Data: map[string]interface{}{
+				"fd_id":           fd.ID.Hex(),
+				"maturity_date":   fd.MaturityDate,
+				"maturity_amount": fd.MaturityAmount,
+			},
+		})
+	})
+	return reminded && err == nil, err
+}
+
+// maskAccount keeps the last four characters of an account number.
+func maskAccount(acct string) string {
+	acct = strings.TrimSpace(acct)
+	if len(acct) <= 4 {
+		return acct
+	}
+	return strings.Repeat("X", len(acct)-4) + acct[len(acct)-4:]
+}
@@ -0,0 +1,67 @@
+// Package finmath holds the financial formulas shared by calculators, goal
+// projections and analytics. Rates are annual effective rates in percent
+// (the way fund returns are quoted); monthly flows compound at the
+// equivalent monthly rate and are paid at the start of the month.
+package finmath
+
+import "math"
+
+// MonthlyRate converts an annual effective rate in percent to the equivalent
+// monthly rate as a fraction.
+func MonthlyRate(annualPct float64) float64 {
+	return math.Pow(1+annualPct/100, 1.0/12) - 1
+}
+
+// FutureValue grows pv for a number of months at an annual rate.
+func FutureValue(pv, annualPct float64, months int) float64 {
+	return pv * math.Pow(1+annualPct/100, float64(months)/12)
+}
+
+// SIPFutureValue is the value of n level monthly instalments.
+func SIPFutureValue(monthly, annualPct float64, months int) float64 {
+	i := MonthlyRate(annualPct)
+	if i == 0 {
+		return monthly * float64(months)
+	}
+	return monthly * (math.Pow(1+i, float64(months)) - 1) / i * (1 + i)
+}
+
+// StepUpSIPFutureValue is the value of a monthly SIP that rises by stepUpPct
+// at the start of every year.
+func StepUpSIPFutureValue(monthly, annualPct, stepUpPct float64, years int) float64 {
+	var value float64
+	for y := 0; y < years; y++ {
+		value = FutureValue(value, annualPct, 12) + SIPFutureValue(monthly, annualPct, 12)
+		monthly *= 1 + stepUpPct/100
+	}
+	return value
+}
+
+// RequiredSIP is the starting monthly SIP that reaches target in the given
+// years. Future value is linear in the instalment, so it is the target over
+// the value of a ₹1 SIP with the same step-up.
+func RequiredSIP(target, annualPct, stepUpPct float64, years int) float64 {
+	unit := StepUpSIPFutureValue(1, annualPct, stepUpPct, years)
+	if unit <= 0 || target <= 0 {
+		return 0
+	}
+	return target / unit
+}
+
+// CompoundValue grows principal at a nominal annual rate compounded
+// periodsPerYear times a year, the way bank deposits are quoted. Part periods
+// accrue at the same periodic rate.
+func CompoundValue(principal, nominalPct float64, periodsPerYear int, years float64) float64 {
+	return principal * math.Pow(1+nominalPct/100/float64(periodsPerYear), float64(periodsPerYear)*years)
+}
+
+// CAGR is the compound annual growth rate in percent between two values.
+func CAGR(start, end, years float64) float64 {
+	if start <= 0 || end <= 0 || years <= 0 {
+		return 0
+	}
+	return (math.Pow(end/start, 1/years) - 1) * 100
+}
+
+// Round2 rounds to paise.
+func Round2(v float64) float64 { return math.Round(v*100) / 100 }
@@ -0,0 +1,104 @@
+package finmath
+
+import (
+	"math"
+	"testing"
+)
+
+func approx(t *testing.T, name string, got, want, tol float64) {
+	t.Helper()
+	if math.Abs(got-want) > tol {
+		t.Errorf("%s = %.6f, want %.6f", name, got, want)
+	}
+}
+
+func TestFutureValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		pv, rate float64
+		months   int
+		want     float64
+	}{
+		{"five years at 12%", 100000, 12, 60, 176234.16832},
+		{"zero rate", 50000, 0, 36, 50000},
+		{"part year", 100000, 21, 6, 110000},
+		{"no time", 75000, 10, 0, 75000},
+	}
+	for _, tt := range tests {
+		approx(t, tt.name, FutureValue(tt.pv, tt.rate, tt.months), tt.want, 1e-4)
+	}
+}
+
+func TestSIPFutureValue(t *testing.T) {
+	tests := []struct {
+		name          string
+		monthly, rate float64
+		months        int
+		want          float64
+	}{
+		{"ten years at 12%", 10000, 12, 120, 2240358.895595},
+		{"one month earns a month", 10000, 12, 1, 10094.887929},
+		{"zero rate", 1000, 0, 12, 12000},
+		{"no instalments", 5000, 12, 0, 0},
+	}
+	for _, tt := range tests {
+		approx(t, tt.name, SIPFutureValue(tt.monthly, tt.rate, tt.months), tt.want, 1e-4)
+	}
+}
+
+func TestStepUpSIPFutureValue(t *testing.T) {
+	tests := []struct {
+		name                  string
+		monthly, rate, stepUp float64
+		years                 int
+		want                  float64
+	}{
+		{"10% step-up for ten years", 10000, 12, 10, 10, 3268898.481908},
+		{"no step-up matches level SIP", 10000, 12, 0, 10, 2240358.895595},
+		{"zero rate sums instalments", 1000, 0, 10, 2, 12000 + 13200},
+	}
+	for _, tt := range tests {
+		approx(t, tt.name, StepUpSIPFutureValue(tt.monthly, tt.rate, tt.stepUp, tt.years), tt.want, 1e-4)
+	}
+}
+
+func TestRequiredSIP(t *testing.T) {
+	tests := []struct {
+		name                 string
+		target, rate, stepUp float64
+		years                int
+		want                 float64
+	}{
+		{"inverts level SIP", 2240358.895595, 12, 0, 10, 10000},
+		{"inverts step-up SIP", 3268898.481908, 12, 10, 10, 10000},
+		{"zero rate", 1200000, 0, 0, 10, 10000},
+		{"no target", 0, 12, 0, 10, 0},
+		{"no time", 100000, 12, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		approx(t, tt.name, RequiredSIP(tt.target, tt.rate, tt.stepUp, tt.years), tt.want, 1e-6)
+	}
+}
+
+func TestCompoundValue(t *testing.T) {
+	// 7% compounded quarterly for a year is 7.1859% effective.
+	approx(t, "quarterly", CompoundValue(100000, 7, 4, 1), 107185.90, 0.01)
+	approx(t, "half year", CompoundValue(100000, 8, 4, 0.5), 104040, 1e-6)
+}
+
+func TestCAGR(t *testing.T) {
+	tests := []struct {
+		name              string
+		start, end, years float64
+		want              float64
+	}{
+		{"doubles in five years", 100, 200, 5, 14.869835},
+		{"one year", 100, 112, 1, 12},
+		{"loss", 100, 81, 2, -10},
+		{"no start value", 0, 100, 3, 0},
+		{"no time", 100, 150, 0, 0},
+	}
+	for _, tt := range tests {
+		approx(t, tt.name, CAGR(tt.start, tt.end, tt.years), tt.want, 1e-6)
+	}
+}
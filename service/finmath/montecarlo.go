@@ -0,0 +1,190 @@
+package finmath
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+var ErrNotPositiveDefinite = errors.New("correlation matrix is not positive definite")
+
+// ReturnSampler draws one month of simple returns, one per asset class, into
+// out.
+type ReturnSampler interface {
+	Sample(rng *rand.Rand, out []float64)
+}
+
+// NewNormalSampler draws correlated log-normal monthly returns from annual
+// expected returns and volatilities (percent) and a correlation matrix.
+func NewNormalSampler(returnPct, volPct []float64, corr [][]float64) (ReturnSampler, error) {
+	n := len(returnPct)
+	chol, err := cholesky(corr)
+	if err != nil {
+		return nil, err
+	}
+	s := &normalSampler{mu: make([]float64, n), sigma: make([]float64, n), chol: chol, z: make([]float64, n)}
+	for i := 0; i < n; i++ {
+		sm := volPct[i] / 100 / math.Sqrt(12)
+		s.sigma[i] = sm
+		s.mu[i] = math.Log(1+returnPct[i]/100)/12 - sm*sm/2
+	}
+	return s, nil
+}
+
+type normalSampler struct {
+	mu, sigma []float64
+	chol      [][]float64
+	z         []float64
+}
+
+func (s *normalSampler) Sample(rng *rand.Rand, out []float64) {
+	for i := range s.z {
+		s.z[i] = rng.NormFloat64()
+	}
+	for i := range out {
+		var c float64
+		for j := 0; j <= i; j++ {
+			c += s.chol[i][j] * s.z[j]
+		}
+		out[i] = math.Exp(s.mu[i]+s.sigma[i]*c) - 1
+	}
+}
+
+// NewBootstrapSampler resamples whole historical months, keeping the
+// cross-asset correlation of each month intact.
+func NewBootstrapSampler(months [][]float64) ReturnSampler {
+	return &bootstrapSampler{months: months}
+}
+
+type bootstrapSampler struct{ months [][]float64 }
+
+func (s *bootstrapSampler) Sample(rng *rand.Rand, out []float64) {
+	copy(out, s.months[rng.Intn(len(s.months))])
+}
+
+type SimulationInput struct {
+	Values      []float64 // starting value per asset class
+	MonthlySIPs []float64 // monthly contribution per asset class
+	Months      int
+	Simulations int
+	Seed        int64
+	Target      float64 // optional; success is ending at or above it
+}
+
+type PercentileBand struct {
+	Month    int     `json:"month"`
+	Invested float64 `json:"invested"`
+	P10      float64 `json:"p10"`
+	P50      float64 `json:"p50"`
+	P90      float64 `json:"p90"`
+}
+
+type SimulationResult struct {
+	Bands       []PercentileBand `json:"bands"` // one per year, plus the final month
+	Final       PercentileBand   `json:"final"`
+	SuccessProb *float64         `json:"success_probability,omitempty"`
+}
+
+// Simulate runs seeded Monte Carlo paths. Contributions are invested at the
+// start of each month and the month's sampled return is then applied per
+// asset class. The same seed always yields the same result.
+func Simulate(in SimulationInput, sampler ReturnSampler) SimulationResult {
+	rng := rand.New(rand.NewSource(in.Seed))
+	n := len(in.Values)
+
+	var checkpoints []int
+	for m := 12; m < in.Months; m += 12 {
+		checkpoints = append(checkpoints, m)
+	}
+	checkpoints = append(checkpoints, in.Months)
+	samples := make([][]float64, len(checkpoints))
+	for i := range samples {
+		samples[i] = make([]float64, in.Simulations)
+	}
+
+	var monthly, start float64
+	for k := 0; k < n; k++ {
+		monthly += in.MonthlySIPs[k]
+		start += in.Values[k]
+	}
+
+	values := make([]float64, n)
+	rets := make([]float64, n)
+	successes := 0
+	for sim := 0; sim < in.Simulations; sim++ {
+		copy(values, in.Values)
+		c := 0
+		for m := 1; m <= in.Months; m++ {
+			sampler.Sample(rng, rets)
+			for k := 0; k < n; k++ {
+				values[k] = (values[k] + in.MonthlySIPs[k]) * (1 + rets[k])
+			}
+			if m == checkpoints[c] {
+				var total float64
+				for _, v := range values {
+					total += v
+				}
+				samples[c][sim] = total
+				c++
+			}
+		}
+		if in.Target > 0 && samples[len(samples)-1][sim] >= in.Target {
+			successes++
+		}
+	}
+
+	res := SimulationResult{Bands: make([]PercentileBand, len(checkpoints))}
+	for i, m := range checkpoints {
+		sort.Float64s(samples[i])
+		res.Bands[i] = PercentileBand{
+			Month:    m,
+			Invested: Round2(start + monthly*float64(m)),
+			P10:      Round2(percentile(samples[i], 10)),
+			P50:      Round2(percentile(samples[i], 50)),
+			P90:      Round2(percentile(samples[i], 90)),
+		}
+	}
+	res.Final = res.Bands[len(res.Bands)-1]
+	if in.Target > 0 {
+		p := Round2(float64(successes) / float64(in.Simulations) * 100)
+		res.SuccessProb = &p
+	}
+	return res
+}
+
+// percentile interpolates linearly within sorted data.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := p / 100 * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(pos-float64(lo))
+}
+
+func cholesky(a [][]float64) ([][]float64, error) {
+	n := len(a)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 {
+					return nil, ErrNotPositiveDefinite
+				}
+				l[i][j] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, nil
+}
@@ -0,0 +1,145 @@
+package finmath
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestSimulateSeeded(t *testing.T) {
+	sampler, err := NewNormalSampler([]float64{12, 7}, []float64{18, 5}, [][]float64{{1, 0.2}, {0.2, 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := SimulationInput{
+		Values:      []float64{100000, 50000},
+		MonthlySIPs: []float64{10000, 5000},
+		Months:      60,
+		Simulations: 500,
+		Seed:        42,
+		Target:      1200000,
+	}
+	first := Simulate(in, sampler)
+	second := Simulate(in, sampler)
+	if !reflect.DeepEqual(first, second) {
+		t.Fatalf("same seed gave different results:\n%+v\n%+v", first, second)
+	}
+
+	in.Seed = 43
+	if other := Simulate(in, sampler); reflect.DeepEqual(first.Final, other.Final) {
+		t.Errorf("different seeds gave identical final percentiles %+v", other.Final)
+	}
+
+	if len(first.Bands) != 5 || first.Final.Month != 60 {
+		t.Fatalf("bands = %+v, want yearly checkpoints ending at month 60", first.Bands)
+	}
+	if f := first.Final; !(f.P10 <= f.P50 && f.P50 <= f.P90) {
+		t.Errorf("final percentiles out of order: %+v", f)
+	}
+	approx(t, "invested", first.Final.Invested, 150000+15000*60, 0)
+	if first.SuccessProb == nil {
+		t.Error("success probability missing with a target")
+	}
+}
+
+// zeroSampler returns no growth, so every path ends at the amount invested.
+type zeroSampler struct{}
+
+func (zeroSampler) Sample(_ *rand.Rand, out []float64) {
+	for i := range out {
+		out[i] = 0
+	}
+}
+
+func TestSimulateDeterministicPaths(t *testing.T) {
+	res := Simulate(SimulationInput{
+		Values:      []float64{1000},
+		MonthlySIPs: []float64{100},
+		Months:      18,
+		Simulations: 10,
+		Target:      2800,
+	}, zeroSampler{})
+	want := []PercentileBand{
+		{Month: 12, Invested: 2200, P10: 2200, P50: 2200, P90: 2200},
+		{Month: 18, Invested: 2800, P10: 2800, P50: 2800, P90: 2800},
+	}
+	if !reflect.DeepEqual(res.Bands, want) {
+		t.Errorf("bands = %+v, want %+v", res.Bands, want)
+	}
+	if res.SuccessProb == nil || *res.SuccessProb != 100 {
+		t.Errorf("success probability = %v, want 100", res.SuccessProb)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	data := []float64{10, 20, 30, 40, 50}
+	tests := []struct {
+		name   string
+		sorted []float64
+		p      float64
+		want   float64
+	}{
+		{"minimum", data, 0, 10},
+		{"interpolated low", data, 10, 14},
+		{"median", data, 50, 30},
+		{"interpolated high", data, 90, 46},
+		{"maximum", data, 100, 50},
+		{"between two", []float64{1, 2}, 50, 1.5},
+		{"single value", []float64{7}, 90, 7},
+		{"empty", nil, 50, 0},
+	}
+	for _, tt := range tests {
+		approx(t, tt.name, percentile(tt.sorted, tt.p), tt.want, 1e-12)
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	tests := []struct {
+		name string
+		a    [][]float64
+		want [][]float64
+	}{
+		{
+			name: "textbook matrix",
+			a:    [][]float64{{4, 12, -16}, {12, 37, -43}, {-16, -43, 98}},
+			want: [][]float64{{2, 0, 0}, {6, 1, 0}, {-8, 5, 3}},
+		},
+		{
+			name: "correlation",
+			a:    [][]float64{{1, 0.6, 0.3}, {0.6, 1, 0.5}, {0.3, 0.5, 1}},
+			want: [][]float64{
+				{1, 0, 0},
+				{0.6, 0.8, 0},
+				{0.3, 0.4, math.Sqrt(0.75)},
+			},
+		},
+		{
+			name: "identity",
+			a:    [][]float64{{1, 0}, {0, 1}},
+			want: [][]float64{{1, 0}, {0, 1}},
+		},
+	}
+	for _, tt := range tests {
+		l, err := cholesky(tt.a)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		for i := range tt.want {
+			for j := range tt.want[i] {
+				approx(t, tt.name, l[i][j], tt.want[i][j], 1e-12)
+			}
+		}
+	}
+}
+
+func TestCholeskyNotPositiveDefinite(t *testing.T) {
+	if _, err := cholesky([][]float64{{1, 2}, {2, 1}}); !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("err = %v, want ErrNotPositiveDefinite", err)
+	}
+	if _, err := NewNormalSampler([]float64{10, 10}, []float64{15, 15}, [][]float64{{1, 1}, {1, 1}}); !errors.Is(err, ErrNotPositiveDefinite) {
+		t.Errorf("perfect correlation: err = %v, want ErrNotPositiveDefinite", err)
+	}
+}
@@ -0,0 +1,82 @@
+package finmath
+
+import "math"
+
+type RetirementInput struct {
+	CurrentAge      int
+	RetirementAge   int
+	LifeExpectancy  int
+	MonthlyExpenses float64 // in today's money
+	InflationPct    float64
+	PreReturnPct    float64
+	PostReturnPct   float64
+	CurrentSavings  float64
+	StepUpPct       float64 // yearly increase in the SIP
+}
+
+type RetirementResult struct {
+	MonthlyExpensesAtRetirement float64   `json:"monthly_expenses_at_retirement"`
+	CorpusRequired              float64   `json:"corpus_required"`
+	SavingsAtRetirement         float64   `json:"savings_at_retirement"`
+	Shortfall                   float64   `json:"shortfall"`
+	RequiredMonthlySIP          float64   `json:"required_monthly_sip"`
+	Schedule                    []YearRow `json:"schedule"`
+}
+
+// Retirement sizes the corpus that funds inflation-linked monthly expenses
+// from retirement to life expectancy, then the SIP that builds it on top of
+// existing savings. The schedule follows the plan through both phases.
+func Retirement(in RetirementInput) RetirementResult {
+	accYears := in.RetirementAge - in.CurrentAge
+	retYears := in.LifeExpectancy - in.RetirementAge
+	expense := FutureValue(in.MonthlyExpenses, in.InflationPct, accYears*12)
+
+	// Present value at retirement of withdrawals at the start of each month,
+	// stepped up for inflation once a year.
+	ip := MonthlyRate(in.PostReturnPct)
+	var corpus float64
+	w := expense
+	for y := 0; y < retYears; y++ {
+		for m := 0; m < 12; m++ {
+			corpus += w / math.Pow(1+ip, float64(y*12+m))
+		}
+		w *= 1 + in.InflationPct/100
+	}
+
+	savings := FutureValue(in.CurrentSavings, in.PreReturnPct, accYears*12)
+	res := RetirementResult{
+		MonthlyExpensesAtRetirement: Round2(expense),
+		CorpusRequired:              Round2(corpus),
+		SavingsAtRetirement:         Round2(savings),
+		Shortfall:                   Round2(math.Max(0, corpus-savings)),
+	}
+	sip := RequiredSIP(corpus-savings, in.PreReturnPct, in.StepUpPct, accYears)
+	res.RequiredMonthlySIP = math.Ceil(sip)
+
+	i := MonthlyRate(in.PreReturnPct)
+	value, invested := in.CurrentSavings, in.CurrentSavings
+	monthly := res.RequiredMonthlySIP
+	for y := 1; y <= accYears; y++ {
+		var paid float64
+		for m := 0; m < 12; m++ {
+			value = (value + monthly) * (1 + i)
+			paid += monthly
+		}
+		invested += paid
+		res.Schedule = append(res.Schedule, YearRow{
+			Year: y, Age: in.CurrentAge + y, Phase: "accumulation",
+			Contribution: Round2(paid), Invested: Round2(invested), Value: Round2(value),
+		})
+		monthly *= 1 + in.StepUpPct/100
+	}
+
+	drawdown := SWP(value, expense, in.PostReturnPct, in.InflationPct, retYears)
+	for _, row := range drawdown.Schedule {
+		row.Year += accYears
+		row.Age = in.RetirementAge + row.Year - accYears
+		row.Phase = "retirement"
+		row.Invested = Round2(invested)
+		res.Schedule = append(res.Schedule, row)
+	}
+	return res
+}
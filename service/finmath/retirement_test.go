@@ -0,0 +1,105 @@
+package finmath
+
+import "testing"
+
+func TestRetirement(t *testing.T) {
+	tests := []struct {
+		name string
+		in   RetirementInput
+		want RetirementResult
+	}{
+		{
+			// With no growth or inflation the corpus is simply twenty years
+			// of expenses, saved over thirty years.
+			name: "no growth",
+			in: RetirementInput{
+				CurrentAge: 30, RetirementAge: 60, LifeExpectancy: 80,
+				MonthlyExpenses: 50000,
+			},
+			want: RetirementResult{
+				MonthlyExpensesAtRetirement: 50000,
+				CorpusRequired:              12000000,
+				Shortfall:                   12000000,
+				RequiredMonthlySIP:          33334,
+			},
+		},
+		{
+			name: "inflation, growth and existing savings",
+			in: RetirementInput{
+				CurrentAge: 30, RetirementAge: 60, LifeExpectancy: 80,
+				MonthlyExpenses: 50000, InflationPct: 6,
+				PreReturnPct: 12, PostReturnPct: 8,
+				CurrentSavings: 500000, StepUpPct: 10,
+			},
+			want: RetirementResult{
+				MonthlyExpensesAtRetirement: 287174.56,
+				CorpusRequired:              56045946.29,
+				SavingsAtRetirement:         14979961.06,
+				Shortfall:                   41065985.23,
+				RequiredMonthlySIP:          5143,
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := Retirement(tt.in)
+		approx(t, tt.name+": expenses", got.MonthlyExpensesAtRetirement, tt.want.MonthlyExpensesAtRetirement, 0.01)
+		approx(t, tt.name+": corpus", got.CorpusRequired, tt.want.CorpusRequired, 0.01)
+		approx(t, tt.name+": savings", got.SavingsAtRetirement, tt.want.SavingsAtRetirement, 0.01)
+		approx(t, tt.name+": shortfall", got.Shortfall, tt.want.Shortfall, 0.01)
+		approx(t, tt.name+": sip", got.RequiredMonthlySIP, tt.want.RequiredMonthlySIP, 0)
+
+		years := tt.in.LifeExpectancy - tt.in.CurrentAge
+		if len(got.Schedule) != years {
+			t.Errorf("%s: %d schedule rows, want %d", tt.name, len(got.Schedule), years)
+			continue
+		}
+		acc := tt.in.RetirementAge - tt.in.CurrentAge
+		if row := got.Schedule[acc-1]; row.Phase != "accumulation" || row.Age != tt.in.RetirementAge || row.Value < tt.want.CorpusRequired {
+			t.Errorf("%s: last accumulation row = %+v, want value at least the corpus", tt.name, row)
+		}
+		if row := got.Schedule[years-1]; row.Phase != "retirement" || row.Age != tt.in.LifeExpectancy || row.Value < 0 {
+			t.Errorf("%s: last retirement row = %+v", tt.name, row)
+		}
+	}
+}
+
+func TestSWP(t *testing.T) {
+	tests := []struct {
+		name                          string
+		corpus, monthly, rate, stepUp float64
+		maxYears                      int
+		months                        int
+		depleted                      bool
+		withdrawn, final              float64
+	}{
+		{"no growth", 100000, 10000, 0, 0, 5, 10, true, 100000, 0},
+		{"outlives the plan", 100000, 500, 12, 0, 2, 24, false, 12000, 111907.51},
+		// The level withdrawal that exhausts ₹10 lakh over ten years at 12%.
+		{"annuity", 1000000, 13863.172612, 12, 0, 10, 120, false, 1663580.71, 0},
+		// 12,000 + 24,000 + 48,000 in three years leaves 16,000 for two
+		// monthly withdrawals of 8,000.
+		{"doubling withdrawals", 100000, 1000, 0, 100, 5, 38, true, 100000, 0},
+	}
+	for _, tt := range tests {
+		got := SWP(tt.corpus, tt.monthly, tt.rate, tt.stepUp, tt.maxYears)
+		if got.MonthsLasted != tt.months {
+			t.Errorf("%s: lasted %d months, want %d", tt.name, got.MonthsLasted, tt.months)
+		}
+		if tt.depleted && !got.Depleted {
+			t.Errorf("%s: corpus not depleted", tt.name)
+		}
+		approx(t, tt.name+": withdrawn", got.TotalWithdrawn, tt.withdrawn, 0.01)
+		approx(t, tt.name+": final", got.FinalValue, tt.final, 0.01)
+	}
+}
+
+func TestSIPSchedule(t *testing.T) {
+	rows := SIPSchedule(10000, 12, 10, 10)
+	if len(rows) != 10 {
+		t.Fatalf("%d rows, want 10", len(rows))
+	}
+	last := rows[9]
+	approx(t, "value", last.Value, 3268898.48, 0.01)
+	approx(t, "contribution", last.Contribution, 282953.72, 0.01)
+	approx(t, "invested", last.Invested, 1912490.95, 0.01)
+}
@@ -0,0 +1,94 @@
+package finmath
+
+// YearRow is one year of a projection. Invested and Withdrawn are cumulative;
+// Value is the balance at the end of the year.
+type YearRow struct {
+	Year         int     `json:"year"`
+	Age          int     `json:"age,omitempty"`
+	Phase        string  `json:"phase,omitempty"` // accumulation | retirement
+	Contribution float64 `json:"contribution"`    // paid in during the year
+	Withdrawal   float64 `json:"withdrawal"`      // taken out during the year
+	Invested     float64 `json:"invested"`
+	Withdrawn    float64 `json:"withdrawn"`
+	Value        float64 `json:"value"`
+}
+
+// SIPSchedule simulates a monthly SIP, stepped up each year, month by month.
+func SIPSchedule(monthly, annualPct, stepUpPct float64, years int) []YearRow {
+	i := MonthlyRate(annualPct)
+	rows := make([]YearRow, 0, years)
+	var value, invested float64
+	for y := 1; y <= years; y++ {
+		var paid float64
+		for m := 0; m < 12; m++ {
+			value = (value + monthly) * (1 + i)
+			paid += monthly
+		}
+		invested += paid
+		rows = append(rows, YearRow{Year: y, Contribution: Round2(paid), Invested: Round2(invested), Value: Round2(value)})
+		monthly *= 1 + stepUpPct/100
+	}
+	return rows
+}
+
+// LumpsumSchedule grows a one-time investment year by year.
+func LumpsumSchedule(amount, annualPct float64, years int) []YearRow {
+	rows := make([]YearRow, 0, years)
+	for y := 1; y <= years; y++ {
+		row := YearRow{
+			Year:     y,
+			Invested: Round2(amount),
+			Value:    Round2(FutureValue(amount, annualPct, y*12)),
+		}
+		if y == 1 {
+			row.Contribution = row.Invested
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// SWPResult describes a systematic withdrawal plan run until the corpus is
+// exhausted or maxYears pass.
+type SWPResult struct {
+	MonthsLasted   int       `json:"months_lasted"`
+	Depleted       bool      `json:"depleted"`
+	TotalWithdrawn float64   `json:"total_withdrawn"`
+	FinalValue     float64   `json:"final_value"`
+	Schedule       []YearRow `json:"schedule"`
+}
+
+// SWP withdraws monthly at the start of each month, raising the withdrawal by
+// stepUpPct every year, while the remainder earns annualPct.
+func SWP(corpus, monthly, annualPct, stepUpPct float64, maxYears int) SWPResult {
+	i := MonthlyRate(annualPct)
+	res := SWPResult{}
+	value := corpus
+	for y := 1; y <= maxYears && !res.Depleted; y++ {
+		var taken float64
+		for m := 0; m < 12; m++ {
+			w := monthly
+			if w >= value {
+				w, res.Depleted = value, true
+			}
+			value -= w
+			taken += w
+			res.MonthsLasted++
+			if res.Depleted {
+				break
+			}
+			value *= 1 + i
+		}
+		res.TotalWithdrawn += taken
+		res.Schedule = append(res.Schedule, YearRow{
+			Year:       y,
+			Withdrawal: Round2(taken),
+			Withdrawn:  Round2(res.TotalWithdrawn),
+			Value:      Round2(value),
+		})
+		monthly *= 1 + stepUpPct/100
+	}
+	res.TotalWithdrawn = Round2(res.TotalWithdrawn)
+	res.FinalValue = Round2(value)
+	return res
+}
@@ -0,0 +1,88 @@
+package finmath
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+)
+
+var ErrNoSolution = errors.New("xirr: no solution")
+
+// CashFlow is a dated flow from the investor's side: investments are
+// negative, redemptions and the closing value positive.
+type CashFlow struct {
+	Date   time.Time
+	Amount float64
+}
+
+// XIRR returns the annualised internal rate of return in percent for
+// irregular cash flows, using Newton's method with a bisection fallback.
+func XIRR(flows []CashFlow) (float64, error) {
+	if len(flows) < 2 {
+		return 0, ErrNoSolution
+	}
+	flows = append([]CashFlow(nil), flows...)
+	sort.SliceStable(flows, func(i, j int) bool { return flows[i].Date.Before(flows[j].Date) })
+	var hasNeg, hasPos bool
+	for _, f := range flows {
+		hasNeg = hasNeg || f.Amount < 0
+		hasPos = hasPos || f.Amount > 0
+	}
+	if !hasNeg || !hasPos {
+		return 0, ErrNoSolution
+	}
+
+	t0 := flows[0].Date
+	years := make([]float64, len(flows))
+	for i, f := range flows {
+		years[i] = f.Date.Sub(t0).Hours() / 24 / 365
+	}
+	npv := func(r float64) (v, dv float64) {
+		for i, f := range flows {
+			d := math.Pow(1+r, years[i])
+			v += f.Amount / d
+			dv -= years[i] * f.Amount / (d * (1 + r))
+		}
+		return v, dv
+	}
+
+	r := 0.1
+	for k := 0; k < 100; k++ {
+		v, dv := npv(r)
+		if math.Abs(v) < 1e-7 {
+			return r * 100, nil
+		}
+		if dv == 0 {
+			break
+		}
+		next := r - v/dv
+		if next <= -0.999999 || math.IsNaN(next) || math.IsInf(next, 0) {
+			break
+		}
+		if math.Abs(next-r) < 1e-10 {
+			return next * 100, nil
+		}
+		r = next
+	}
+
+	lo, hi := -0.999999, 10.0
+	vlo, _ := npv(lo)
+	vhi, _ := npv(hi)
+	if vlo*vhi > 0 {
+		return 0, ErrNoSolution
+	}
+	for k := 0; k < 200; k++ {
+		mid := (lo + hi) / 2
+		vm, _ := npv(mid)
+		if math.Abs(vm) < 1e-7 || hi-lo < 1e-12 {
+			return mid * 100, nil
+		}
+		if vlo*vm < 0 {
+			hi = mid
+		} else {
+			lo, vlo = mid, vm
+		}
+	}
+	return (lo + hi) / 2 * 100, nil
+}
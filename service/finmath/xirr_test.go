@@ -0,0 +1,88 @@
+package finmath
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestXIRR(t *testing.T) {
+	tests := []struct {
+		name  string
+		flows []CashFlow
+		want  float64
+	}{
+		{
+			name:  "one year at 10%",
+			flows: []CashFlow{{day("2023-01-01"), -1000}, {day("2024-01-01"), 1100}},
+			want:  10,
+		},
+		{
+			// The worked example from the spreadsheet XIRR documentation.
+			name: "irregular flows",
+			flows: []CashFlow{
+				{day("2008-01-01"), -10000},
+				{day("2008-03-01"), 2750},
+				{day("2008-10-30"), 4250},
+				{day("2009-02-15"), 3250},
+				{day("2009-04-01"), 2750},
+			},
+			want: 37.3362535,
+		},
+		{
+			name: "unsorted flows",
+			flows: []CashFlow{
+				{day("2024-01-01"), 1100},
+				{day("2023-01-01"), -1000},
+			},
+			want: 10,
+		},
+		{
+			name:  "loss",
+			flows: []CashFlow{{day("2023-01-01"), -1000}, {day("2024-01-01"), 800}},
+			want:  -20,
+		},
+	}
+	for _, tt := range tests {
+		got, err := XIRR(tt.flows)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		approx(t, tt.name, got, tt.want, 1e-5)
+	}
+}
+
+func TestXIRRNoSolution(t *testing.T) {
+	tests := []struct {
+		name  string
+		flows []CashFlow
+	}{
+		{"single flow", []CashFlow{{day("2023-01-01"), -1000}}},
+		{"only investments", []CashFlow{{day("2023-01-01"), -1000}, {day("2024-01-01"), -500}}},
+		{"only receipts", []CashFlow{{day("2023-01-01"), 1000}, {day("2024-01-01"), 500}}},
+		{
+			// NPV = -100 + 300x - 250x² with x = 1/(1+r) is negative for
+			// every rate, so neither Newton nor bisection can converge.
+			name: "no real root",
+			flows: []CashFlow{
+				{day("2023-01-01"), -100},
+				{day("2024-01-01"), 300},
+				{day("2024-12-31"), -250},
+			},
+		},
+	}
+	for _, tt := range tests {
+		if _, err := XIRR(tt.flows); !errors.Is(err, ErrNoSolution) {
+			t.Errorf("%s: err = %v, want ErrNoSolution", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service/finmath"
+)
+
+var fdPeriods = map[string]int{"monthly": 12, "quarterly": 4, "half_yearly": 2, "yearly": 1}
+
+// accrueDeposit values a deposit as at the given time. Cumulative deposits
+// compound to maturity; payout deposits pay simple interest each payout
+// period and are worth the principal plus interest accrued since the last
+// payout. Interest stops at maturity.
+func accrueDeposit(fd *model.FixedDeposit, at time.Time) (value, paid float64) {
+	end := at
+	if end.After(fd.MaturityDate) {
+		end = fd.MaturityDate
+	}
+	years := math.Max(end.Sub(fd.StartDate).Hours()/24/365, 0)
+	if fd.Payout == model.FDPayoutCumulative {
+		return finmath.CompoundValue(fd.Principal, fd.RatePct, fdPeriods[fd.Compounding], years), 0
+	}
+	per := float64(fdPeriods[fd.Payout])
+	done := math.Floor(years * per)
+	annual := fd.Principal * fd.RatePct / 100
+	return fd.Principal + annual*(years-done/per), annual / per * done
+}
+
+// revalueDeposit refreshes a fixed_deposit holding to the given time.
+func revalueDeposit(h *model.Holding, at time.Time) {
+	value, paid := accrueDeposit(h.FD, at)
+	h.CurrentValue = roundAmount(value)
+	h.InvestedValue = h.FD.Principal
+	h.GainLoss = roundAmount(h.CurrentValue - h.InvestedValue)
+	h.FD.InterestPaid = roundAmount(paid)
+	if !at.Before(h.FD.MaturityDate) {
+		h.FD.Status = model.FDMatured
+	}
+}
+
+// revalueDeposits brings every deposit in p up to date. Fund holdings only
+// move when NAVs do, so they are left alone.
+func revalueDeposits(p *model.Portfolio, at time.Time) {
+	for i := range p.Holdings {
+		if p.Holdings[i].FD != nil {
+			revalueDeposit(&p.Holdings[i], at)
+		}
+	}
+	recomputeTotals(p)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/banking-superapp/wealth-service/model"
+)
+
+var (
+	planOrder   = map[string]int{model.PlanDirect: 0, model.PlanRegular: 1}
+	optionOrder = map[string]int{model.OptionGrowth: 0, model.OptionIDCWPayout: 1, model.OptionIDCWReinvest: 2}
+)
+
+// fundKey returns the grouping key for a scheme. Legacy schemes imported
+// before plan/option modelling have no fund code and stand alone.
+func fundKey(s *model.MFScheme) string {
+	if s.FundCode != "" {
+		return s.FundCode
+	}
+	return s.SchemeCode
+}
+
+// groupFunds folds scheme variants into funds, ordered by fund name, with
+// each fund's variants ordered direct before regular and growth first.
+func groupFunds(schemes []model.MFScheme) []model.Fund {
+	byKey := make(map[string]*model.Fund)
+	var keys []string
+	for i := range schemes {
+		sc := &schemes[i]
+		key := fundKey(sc)
+		f, ok := byKey[key]
+		if !ok {
+			name := sc.FundName
+			if name == "" {
+				name = sc.SchemeName
+			}
+			f = &model.Fund{
+				FundCode:    key,
+				FundName:    name,
+				AMC:         sc.AMC,
+				Category:    sc.Category,
+				SubCategory: sc.SubCategory,
+				Risk:        sc.Risk,
+			}
+			byKey[key] = f
+			keys = append(keys, key)
+		}
+		f.Variants = append(f.Variants, model.FundVariant{
+			SchemeCode: sc.SchemeCode,
+			SchemeName: sc.SchemeName,
+			Plan:       sc.Plan,
+			Option:     sc.Option,
+			ISIN:       sc.ISIN,
+			NAV:        sc.NAV,
+			NAVDate:    sc.NAVDate,
+			Returns1Y:  sc.Returns1Y,
+			Returns3Y:  sc.Returns3Y,
+			Returns5Y:  sc.Returns5Y,
+			MinSIP:     sc.MinSIP,
+			MinLumpsum: sc.MinLumpsum,
+		})
+	}
+
+	funds := make([]model.Fund, 0, len(keys))
+	for _, k := range keys {
+		f := byKey[k]
+		sort.SliceStable(f.Variants, func(i, j int) bool {
+			a, b := f.Variants[i], f.Variants[j]
+			if planOrder[a.Plan] != planOrder[b.Plan] {
+				return planOrder[a.Plan] < planOrder[b.Plan]
+			}
+			return optionOrder[a.Option] < optionOrder[b.Option]
+		})
+		funds = append(funds, *f)
+	}
+	sort.SliceStable(funds, func(i, j int) bool {
+		return strings.ToLower(funds[i].FundName) < strings.ToLower(funds[j].FundName)
+	})
+	return funds
+}
@@ -0,0 +1,406 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"github.com/banking-superapp/wealth-service/service/finmath"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	defaultInflationPct = 6.0
+	// Goals closer than this are funded from debt regardless of risk profile.
+	shortHorizonMonths = 36
+)
+
+// expectedReturns are long-run category return assumptions, % p.a.
+var expectedReturns = map[string]float64{
+	"equity": 12,
+	"hybrid": 9.5,
+	"debt":   7,
+	"liquid": 6,
+	"gold":   8,
+}
+
+// defaultMix is used for users who have not taken the risk questionnaire.
+var defaultMix = map[string]int{"equity": 50, "debt": 40, "hybrid": 10}
+
+var (
+	ErrGoalNotFound = errors.New("goal not found")
+	ErrLinkConflict = errors.New("investment already linked to another goal")
+)
+
+type GoalService interface {
+	Create(ctx context.Context, userID string, req *model.GoalRequest) (*model.GoalProgress, error)
+	List(ctx context.Context, userID string) ([]model.GoalProgress, error)
+	Get(ctx context.Context, userID, goalID string) (*model.GoalProgress, error)
+	Update(ctx context.Context, userID, goalID string, req *model.GoalRequest) (*model.GoalProgress, error)
+	Link(ctx context.Context, userID, goalID string, req *model.LinkGoalRequest) (*model.GoalProgress, error)
+	Delete(ctx context.Context, userID, goalID string) error
+}
+
+type goalService struct {
+	goalRepo repository.GoalRepo
+	sipRepo  repository.SIPRepo
+	portRepo repository.PortfolioRepo
+	riskRepo repository.RiskProfileRepo
+	mfRepo   repository.MFSchemeRepo
+}
+
+func NewGoalService(gr repository.GoalRepo, sr repository.SIPRepo, pr repository.PortfolioRepo, rr repository.RiskProfileRepo, mr repository.MFSchemeRepo) GoalService {
+	return &goalService{gr, sr, pr, rr, mr}
+}
+
+func (s *goalService) Create(ctx context.Context, userID string, req *model.GoalRequest) (*model.GoalProgress, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	g := &model.Goal{
+		UserID:         oid,
+		LinkedSIPs:     []bson.ObjectID{},
+		LinkedHoldings: []model.GoalHoldingLink{},
+	}
+	if err := applyGoalRequest(g, req); err != nil {
+		return nil, err
+	}
+	if err := s.goalRepo.Create(ctx, g); err != nil {
+		return nil, err
+	}
+	return s.progress(ctx, g)
+}
+
+func (s *goalService) List(ctx context.Context, userID string) ([]model.GoalProgress, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	goals, err := s.goalRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]model.GoalProgress, 0, len(goals))
+	for i := range goals {
+		p, err := s.progress(ctx, &goals[i])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *p)
+	}
+	return out, nil
+}
+
+func (s *goalService) Get(ctx context.Context, userID, goalID string) (*model.GoalProgress, error) {
+	g, err := s.find(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+	return s.progress(ctx, g)
+}
+
+func (s *goalService) Update(ctx context.Context, userID, goalID string, req *model.GoalRequest) (*model.GoalProgress, error) {
+	g, err := s.find(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyGoalRequest(g, req); err != nil {
+		return nil, err
+	}
+	if err := s.goalRepo.Update(ctx, g); err != nil {
+		return nil, err
+	}
+	return s.progress(ctx, g)
+}
+
+// Link replaces the goal's linked SIPs and holdings. A SIP can fund one goal
+// only, and a holding cannot be earmarked beyond 100% across goals.
+func (s *goalService) Link(ctx context.Context, userID, goalID string, req *model.LinkGoalRequest) (*model.GoalProgress, error) {
+	g, err := s.find(ctx, userID, goalID)
+	if err != nil {
+		return nil, err
+	}
+
+	sips, err := s.sipRepo.FindByUserID(ctx, g.UserID)
+	if err != nil {
+		return nil, err
+	}
+	owned := make(map[bson.ObjectID]bool, len(sips))
+	for _, sp := range sips {
+		owned[sp.ID] = true
+	}
+	portfolio, err := s.portRepo.FindByUserID(ctx, g.UserID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	others, err := s.goalRepo.FindByUserID(ctx, g.UserID)
+	if err != nil {
+		return nil, err
+	}
+	takenSIPs := make(map[bson.ObjectID]bool)
+	allocated := make(map[string]float64)
+	for _, o := range others {
+		if o.ID == g.ID {
+			continue
+		}
+		for _, id := range o.LinkedSIPs {
+			takenSIPs[id] = true
+		}
+		for _, l := range o.LinkedHoldings {
+			allocated[l.SchemeCode] += l.AllocationPct
+		}
+	}
+
+	linkedSIPs := make([]bson.ObjectID, 0, len(req.SIPIDs))
+	for _, id := range req.SIPIDs {
+		sid, err := bson.ObjectIDFromHex(id)
+		if err != nil || !owned[sid] {
+			return nil, ErrInvalidRequest
+		}
+		if takenSIPs[sid] {
+			return nil, ErrLinkConflict
+		}
+		linkedSIPs = append(linkedSIPs, sid)
+	}
+	links := make([]model.GoalHoldingLink, 0, len(req.Holdings))
+	for _, l := range req.Holdings {
+		if l.AllocationPct <= 0 || l.AllocationPct > 100 || portfolio == nil || findHolding(portfolio, l.SchemeCode) < 0 {
+			return nil, ErrInvalidRequest
+		}
+		if allocated[l.SchemeCode]+l.AllocationPct > 100 {
+			return nil, ErrLinkConflict
+		}
+		allocated[l.SchemeCode] += l.AllocationPct
+		links = append(links, l)
+	}
+
+	g.LinkedSIPs, g.LinkedHoldings = linkedSIPs, links
+	if err := s.goalRepo.Update(ctx, g); err != nil {
+		return nil, err
+	}
+	return s.progress(ctx, g)
+}
+
+func (s *goalService) Delete(ctx context.Context, userID, goalID string) error {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrUnauthorized
+	}
+	gid, err := bson.ObjectIDFromHex(goalID)
+	if err != nil {
+		return ErrGoalNotFound
+	}
+	deleted, err := s.goalRepo.Delete(ctx, oid, gid)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrGoalNotFound
+	}
+	return nil
+}
+
+func (s *goalService) find(ctx context.Context, userID, goalID string) (*model.Goal, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	gid, err := bson.ObjectIDFromHex(goalID)
+	if err != nil {
+		return nil, ErrGoalNotFound
+	}
+	g, err := s.goalRepo.FindByID(ctx, oid, gid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrGoalNotFound
+		}
+		return nil, err
+	}
+	return g, nil
+}
+
+// progress projects the goal's linked holdings and SIPs to the target date at
+// their categories' expected returns and sizes the extra monthly SIP needed
+// to close any gap. The extra SIP is assumed to follow the user's
+// recommended mix, or debt for short horizons.
+func (s *goalService) progress(ctx context.Context, g *model.Goal) (*model.GoalProgress, error) {
+	months := monthsUntil(time.Now(), g.TargetDate)
+	p := &model.GoalProgress{
+		Goal:            *g,
+		MonthsRemaining: months,
+		InflatedTarget:  roundAmount(finmath.FutureValue(g.TargetAmount, g.InflationPct, months)),
+	}
+
+	categories := make(map[string]string)
+	category := func(code string) (string, error) {
+		if c, ok := categories[code]; ok {
+			return c, nil
+		}
+		sc, err := s.mfRepo.FindByCode(ctx, code)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return "", err
+		}
+		c := ""
+		if sc != nil {
+			c = sc.Category
+		}
+		categories[code] = c
+		return c, nil
+	}
+
+	var projected, weighted, weight float64
+	if len(g.LinkedHoldings) > 0 {
+		portfolio, err := s.portRepo.FindByUserID(ctx, g.UserID)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		for _, l := range g.LinkedHoldings {
+			if portfolio == nil {
+				break
+			}
+			i := findHolding(portfolio, l.SchemeCode)
+			if i < 0 {
+				continue
+			}
+			c, err := category(l.SchemeCode)
+			if err != nil {
+				return nil, err
+			}
+			value := portfolio.Holdings[i].CurrentValue * l.AllocationPct / 100
+			r := expectedReturn(c)
+			p.CurrentValue += value
+			projected += finmath.FutureValue(value, r, months)
+			weighted += r * value
+			weight += value
+		}
+	}
+	if len(g.LinkedSIPs) > 0 {
+		sips, err := s.sipRepo.FindByUserID(ctx, g.UserID)
+		if err != nil {
+			return nil, err
+		}
+		linked := make(map[bson.ObjectID]bool, len(g.LinkedSIPs))
+		for _, id := range g.LinkedSIPs {
+			linked[id] = true
+		}
+		for _, sp := range sips {
+			if !linked[sp.ID] || sp.Status != "active" {
+				continue
+			}
+			c, err := category(sp.SchemeCode)
+			if err != nil {
+				return nil, err
+			}
+			monthly := monthlyEquivalent(sp.Amount, sp.Frequency)
+			r := expectedReturn(c)
+			p.MonthlySIP += monthly
+			projected += finmath.SIPFutureValue(monthly, r, months)
+			weighted += r * monthly * float64(months)
+			weight += monthly * float64(months)
+		}
+	}
+	if weight > 0 {
+		p.ExpectedReturn = roundAmount(weighted / weight)
+	}
+
+	suggested, err := s.suggestedReturn(ctx, g.UserID, months)
+	if err != nil {
+		return nil, err
+	}
+	p.SuggestedReturn = roundAmount(suggested)
+	p.CurrentValue = roundAmount(p.CurrentValue)
+	p.MonthlySIP = roundAmount(p.MonthlySIP)
+	p.ProjectedCorpus = roundAmount(projected)
+	p.Gap = roundAmount(math.Max(0, p.InflatedTarget-p.ProjectedCorpus))
+	p.OnTrack = p.Gap == 0
+	if p.InflatedTarget > 0 {
+		p.ProgressPct = roundAmount(p.ProjectedCorpus / p.InflatedTarget * 100)
+	}
+	if p.Gap > 0 && months > 0 {
+		p.AdditionalMonthlySIP = math.Ceil(p.Gap / finmath.SIPFutureValue(1, suggested, months))
+	}
+	return p, nil
+}
+
+func (s *goalService) suggestedReturn(ctx context.Context, userID bson.ObjectID, months int) (float64, error) {
+	if months < shortHorizonMonths {
+		return expectedReturns["debt"], nil
+	}
+	mix := defaultMix
+	rp, err := s.riskRepo.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, err
+	}
+	if rp != nil && len(rp.RecommendedMix) > 0 {
+		mix = rp.RecommendedMix
+	}
+	return mixReturn(mix), nil
+}
+
+func applyGoalRequest(g *model.Goal, req *model.GoalRequest) error {
+	if req.Name == "" || req.TargetAmount <= 0 || !req.TargetDate.After(time.Now()) {
+		return ErrInvalidRequest
+	}
+	g.Name = req.Name
+	g.TargetAmount = req.TargetAmount
+	g.TargetDate = req.TargetDate
+	g.InflationPct = defaultInflationPct
+	if req.InflationPct != nil {
+		if *req.InflationPct < 0 || *req.InflationPct > 20 {
+			return ErrInvalidRequest
+		}
+		g.InflationPct = *req.InflationPct
+	}
+	switch req.Priority {
+	case "":
+		g.Priority = "medium"
+	case "high", "medium", "low":
+		g.Priority = req.Priority
+	default:
+		return ErrInvalidRequest
+	}
+	return nil
+}
+
+func expectedReturn(category string) float64 {
+	if r, ok := expectedReturns[category]; ok {
+		return r
+	}
+	return expectedReturns["debt"]
+}
+
+// mixReturn is the expected return of an allocation given in percent.
+func mixReturn(mix map[string]int) float64 {
+	var total, weighted float64
+	for c, pct := range mix {
+		weighted += expectedReturn(c) * float64(pct)
+		total += float64(pct)
+	}
+	if total == 0 {
+		return expectedReturns["debt"]
+	}
+	return weighted / total
+}
+
+func monthlyEquivalent(amount float64, frequency string) float64 {
+	if frequency == "weekly" {
+		return amount * 52 / 12
+	}
+	return amount
+}
+
+func monthsUntil(from, to time.Time) int {
+	if !to.After(from) {
+		return 0
+	}
+	m := (to.Year()-from.Year())*12 + int(to.Month()-from.Month())
+	if to.Day() < from.Day() {
+		m--
+	}
+	return max(m, 0)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+)
+
+const (
+	sgbInterestPct    = 2.5
+	sgbTenureYears    = 8
+	sgbLockInYears    = 5
+	sgbPayoutsPerYear = 2
+)
+
+// revalueGold prices a gold holding at the given price per gram, falling
+// back to the purchase price before any price has been loaded. Grams and
+// price sit in Units and CurrentNAV so the holding values like a fund.
+func revalueGold(h *model.Holding, price *model.GoldPrice, now time.Time) {
+	g := h.Gold
+	h.Units = g.Grams
+	h.InvestedValue = roundAmount(g.Grams * g.PricePerGram)
+	h.CurrentNAV = g.PricePerGram
+	if price != nil {
+		h.CurrentNAV = price.PricePerGram
+		g.PriceDate = price.Date
+	}
+	revalue(h)
+	if g.Kind == model.GoldSGB {
+		g.InterestPaid, g.NextExitWindow = sgbSchedule(g, h.InvestedValue, now)
+	}
+}
+
+// sgbSchedule returns the interest paid on a tranche up to now and the next
+// date it can be redeemed, which is nil once the bond has matured.
+func sgbSchedule(g *model.Gold, issueValue float64, now time.Time) (float64, *time.Time) {
+	maturity := g.PurchaseDate.AddDate(sgbTenureYears, 0, 0)
+	lockIn := g.PurchaseDate.AddDate(sgbLockInYears, 0, 0)
+	paid := 0
+	var next *time.Time
+	for k := 1; k <= sgbTenureYears*sgbPayoutsPerYear; k++ {
+		d := g.PurchaseDate.AddDate(0, 12/sgbPayoutsPerYear*k, 0)
+		if !d.After(now) {
+			paid++
+			continue
+		}
+		if next == nil && !d.Before(lockIn) && !d.After(maturity) {
+			next = &d
+		}
+	}
+	perPayout := issueValue * g.InterestRatePct / 100 / sgbPayoutsPerYear
+	return roundAmount(perPayout * float64(paid)), next
+}
+
+// revalueGoldHoldings prices every gold holding in p and refreshes totals.
+func revalueGoldHoldings(p *model.Portfolio, price *model.GoldPrice, now time.Time) {
+	for i := range p.Holdings {
+		if p.Holdings[i].Gold != nil {
+			revalueGold(&p.Holdings[i], price, now)
+		}
+	}
+	recomputeTotals(p)
+}
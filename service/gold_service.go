@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrNoGoldPrice         = errors.New("no gold price loaded")
+	ErrGoldHoldingNotFound = errors.New("gold holding not found")
+)
+
+type GoldService interface {
+	ImportPrices(ctx context.Context, data []byte) (*model.GoldPriceImportResult, error)
+	LatestPrice(ctx context.Context) (*model.GoldPrice, error)
+	AddHolding(ctx context.Context, userID string, req *model.GoldHoldingRequest) (*model.Holding, error)
+	ListHoldings(ctx context.Context, userID string) ([]model.Holding, error)
+	RemoveHolding(ctx context.Context, userID, id string) error
+}
+
+type goldService struct {
+	priceRepo repository.GoldPriceRepo
+	portRepo  repository.PortfolioRepo
+	tx        repository.Transactor
+}
+
+func NewGoldService(gr repository.GoldPriceRepo, pr repository.PortfolioRepo, tx repository.Transactor) GoldService {
+	return &goldService{gr, pr, tx}
+}
+
+// ImportPrices loads daily prices and revalues every portfolio holding gold
+// at the latest one. Each portfolio is re-read and its valuation fields
+// written in one transaction, so trades made meanwhile are kept; one that
+// fails is logged and counted, and the rest are still revalued.
+func (s *goldService) ImportPrices(ctx context.Context, data []byte) (*model.GoldPriceImportResult, error) {
+	var records []struct {
+		Date         string  `json:"date"`
+		PricePerGram float64 `json:"price_per_gram"`
+		Source       string  `json:"source"`
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	res := &model.GoldPriceImportResult{}
+	var prices []model.GoldPrice
+	for i, r := range records {
+		date, err := time.Parse(importDateLayout, r.Date)
+		if err != nil || r.PricePerGram <= 0 {
+			res.Errors = append(res.Errors, fmt.Sprintf("record %d: invalid date or price_per_gram", i+1))
+			continue
+		}
+		prices = append(prices, model.GoldPrice{Date: date, PricePerGram: r.PricePerGram, Source: r.Source})
+	}
+	if err := s.priceRepo.UpsertMany(ctx, prices); err != nil {
+		return nil, err
+	}
+	res.Prices = len(prices)
+
+	latest, err := s.priceRepo.FindLatest(ctx)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return res, nil
+		}
+		return nil, err
+	}
+	res.Latest = latest
+
+	portfolios, err := s.portRepo.FindByAssetType(ctx, model.AssetGold)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, p := range portfolios {
+		if err := s.revalueUser(ctx, p.UserID, latest, now); err != nil {
+			log.Printf("gold prices: user %s: %v", p.UserID.Hex(), err)
+			res.PortfoliosFailed++
+			continue
+		}
+		res.PortfoliosRevalued++
+	}
+	return res, nil
+}
+
+func (s *goldService) revalueUser(ctx context.Context, userID bson.ObjectID, price *model.GoldPrice, now time.Time) error {
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		p, err := s.portRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil
+			}
+			return err
+		}
+		revalueGoldHoldings(p, price, now)
+		return s.portRepo.UpdateValuation(ctx, p)
+	})
+}
+
+func (s *goldService) LatestPrice(ctx context.Context) (*model.GoldPrice, error) {
+	p, err := s.priceRepo.FindLatest(ctx)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoGoldPrice
+		}
+		return nil, err
+	}
+	return p, nil
+}
+
+func (s *goldService) AddHolding(ctx context.Context, userID string, req *model.GoldHoldingRequest) (*model.Holding, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	g := &model.Gold{
+		ID:           bson.NewObjectID(),
+		Kind:         req.Kind,
+		Grams:        req.Grams,
+		PricePerGram: req.PricePerGram,
+		PurchaseDate: req.PurchaseDate,
+		Tranche:      strings.TrimSpace(req.Tranche),
+		Provider:     strings.TrimSpace(req.Provider),
+	}
+	if g.Grams <= 0 || g.PricePerGram <= 0 {
+		return nil, ErrInvalidRequest
+	}
+	name := "Digital gold"
+	switch g.Kind {
+	case model.GoldSGB:
+		// SGBs are issued in whole grams and dated by their tranche.
+		if g.PurchaseDate.IsZero() || g.Grams != math.Trunc(g.Grams) {
+			return nil, ErrInvalidRequest
+		}
+		maturity := g.PurchaseDate.AddDate(sgbTenureYears, 0, 0)
+		g.MaturityDate = &maturity
+		g.InterestRatePct = sgbInterestPct
+		g.Provider = ""
+		name = "Sovereign Gold Bond"
+		if g.Tranche != "" {
+			name += " " + g.Tranche
+		}
+	case model.GoldDigital:
+		if g.PurchaseDate.IsZero() {
+			g.PurchaseDate = time.Now()
+		}
+		g.Grams = math.Round(g.Grams*10000) / 10000
+		g.Tranche = ""
+		if g.Provider != "" {
+			name += " (" + g.Provider + ")"
+		}
+	default:
+		return nil, ErrInvalidRequest
+	}
+
+	price, err := s.priceRepo.FindLatest(ctx)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		p = &model.Portfolio{UserID: oid, Holdings: []model.Holding{}}
+	}
+	p.Holdings = append(p.Holdings, model.Holding{
+		AssetType:  model.AssetGold,
+		AssetID:    g.ID.Hex(),
+		Category:   "gold",
+		SchemeName: name,
+		Gold:       g,
+	})
+	revalueGoldHoldings(p, price, time.Now())
+	if err := s.portRepo.Upsert(ctx, p); err != nil {
+		return nil, err
+	}
+	h := p.Holdings[len(p.Holdings)-1]
+	return &h, nil
+}
+
+func (s *goldService) ListHoldings(ctx context.Context, userID string) ([]model.Holding, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	out := []model.Holding{}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return out, nil
+		}
+		return nil, err
+	}
+	for _, h := range p.Holdings {
+		if h.AssetType == model.AssetGold {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+func (s *goldService) RemoveHolding(ctx context.Context, userID, id string) error {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrUnauthorized
+	}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ErrGoldHoldingNotFound
+		}
+		return err
+	}
+	i := findAsset(p, model.AssetGold, id)
+	if i < 0 {
+		return ErrGoldHoldingNotFound
+	}
+	p.Holdings = append(p.Holdings[:i], p.Holdings[i+1:]...)
+	recomputeTotals(p)
+	return s.portRepo.Upsert(ctx, p)
+}
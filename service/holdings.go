@@ -0,0 +1,100 @@
+package service
+
+import (
+	"math"
+
+	"github.com/banking-superapp/wealth-service/model"
+)
+
+// findHolding returns the index of the scheme's holding in p, or -1.
+func findHolding(p *model.Portfolio, schemeCode string) int {
+	for i := range p.Holdings {
+		if isFund(&p.Holdings[i]) && p.Holdings[i].SchemeCode == schemeCode {
+			return i
+		}
+	}
+	return -1
+}
+
+// findAsset returns the index of the non-fund holding with the given ID, or -1.
+func findAsset(p *model.Portfolio, assetType, id string) int {
+	for i := range p.Holdings {
+		if p.Holdings[i].AssetType == assetType && p.Holdings[i].AssetID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func isFund(h *model.Holding) bool {
+	return h.AssetType == "" || h.AssetType == model.AssetMutualFund
+}
+
+// creditUnits adds units bought for cost to the scheme's holding, opening a
+// new holding when the user has none, and refreshes the portfolio totals.
+func creditUnits(p *model.Portfolio, scheme *model.MFScheme, units, cost float64) {
+	i := findHolding(p, scheme.SchemeCode)
+	if i < 0 {
+		p.Holdings = append(p.Holdings, model.Holding{
+			AssetType:  model.AssetMutualFund,
+			Category:   scheme.Category,
+			SchemeCode: scheme.SchemeCode,
+			SchemeName: scheme.SchemeName,
+		})
+		i = len(p.Holdings) - 1
+	}
+	h := &p.Holdings[i]
+	h.Units = roundUnits(h.Units + units)
+	h.InvestedValue = roundAmount(h.InvestedValue + cost)
+	if scheme.NAV > 0 {
+		h.CurrentNAV = scheme.NAV
+	}
+	revalue(h)
+	recomputeTotals(p)
+}
+
+func revalue(h *model.Holding) {
+	h.CurrentValue = roundAmount(h.Units * h.CurrentNAV)
+	h.GainLoss = roundAmount(h.CurrentValue - h.InvestedValue)
+}
+
+func recomputeTotals(p *model.Portfolio) {
+	var value, invested float64
+	for _, h := range p.Holdings {
+		value += h.CurrentValue
+		invested += h.InvestedValue
+	}
+	p.TotalValue = roundAmount(value)
+	p.TotalReturn = roundAmount(value - invested)
+	p.ReturnPct = 0
+	if invested > 0 {
+		p.ReturnPct = roundAmount((value - invested) / invested * 100)
+	}
+}
+
+// roundUnits rounds to the three decimals registrars allot units in.
+func roundUnits(u float64) float64 { return math.Round(u*1000) / 1000 }
+
+func roundAmount(a float64) float64 { return math.Round(a*100) / 100 }
+
+// npsClasses maps NPS asset classes to allocation classes: equity,
+// corporate bonds, government securities and alternatives.
+var npsClasses = map[string]string{"E": "equity", "C": "debt", "G": "debt", "A": "alternative"}
+
+// classValues splits a holding's current value across allocation classes.
+// Most holdings sit in one class; NPS accounts span several. Holdings
+// without a recorded category count as debt.
+func classValues(h *model.Holding) map[string]float64 {
+	if h.NPS != nil {
+		out := make(map[string]float64, len(h.NPS.Schemes))
+		for _, sc := range h.NPS.Schemes {
+			out[npsClasses[sc.AssetClass]] += sc.Value
+		}
+		return out
+	}
+	c := h.Category
+	if c == "" {
+		c = "debt"
+	}
+	return map[string]float64{c: h.CurrentValue}
+}
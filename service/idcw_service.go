@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var (
+	ErrInvalidRequest       = errors.New("invalid request")
+	ErrDeclarationNotFound  = errors.New("idcw declaration not found")
+	ErrDeclarationProcessed = errors.New("idcw declaration already processed")
+	ErrNotIDCWScheme        = errors.New("scheme is not an IDCW variant")
+	ErrExNAVRequired        = errors.New("ex-NAV required to reinvest")
+	ErrDeclarationNotDue    = errors.New("idcw payment date has not arrived")
+)
+
+type IDCWService interface {
+	Declare(ctx context.Context, req *model.DeclareIDCWRequest) (*model.IDCWDeclaration, error)
+	List(ctx context.Context, status string) ([]model.IDCWDeclaration, error)
+	Process(ctx context.Context, id string) (*model.IDCWDeclaration, error)
+	ProcessDue(ctx context.Context, asOf time.Time) (int, error)
+}
+
+type idcwService struct {
+	mfRepo   repository.MFSchemeRepo
+	portRepo repository.PortfolioRepo
+	txnRepo  repository.TransactionRepo
+	idcwRepo repository.IDCWRepo
+	tx       repository.Transactor
+}
+
+func NewIDCWService(mr repository.MFSchemeRepo, pr repository.PortfolioRepo, tr repository.TransactionRepo, ir repository.IDCWRepo, tx repository.Transactor) IDCWService {
+	return &idcwService{mr, pr, tr, ir, tx}
+}
+
+func (s *idcwService) Declare(ctx context.Context, req *model.DeclareIDCWRequest) (*model.IDCWDeclaration, error) {
+	if req.RatePerUnit <= 0 || req.RecordDate.IsZero() || req.ExNAV < 0 {
+		return nil, ErrInvalidRequest
+	}
+	scheme, err := s.mfRepo.FindByCode(ctx, req.SchemeCode)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrSchemeNotFound
+		}
+		return nil, err
+	}
+	if scheme.Option != model.OptionIDCWPayout && scheme.Option != model.OptionIDCWReinvest {
+		return nil, ErrNotIDCWScheme
+	}
+
+	paymentDate := req.PaymentDate
+	if paymentDate.IsZero() {
+		paymentDate = req.RecordDate
+	}
+	if paymentDate.Before(req.RecordDate) {
+		return nil, ErrInvalidRequest
+	}
+
+	d := &model.IDCWDeclaration{
+		SchemeCode:  req.SchemeCode,
+		RecordDate:  req.RecordDate,
+		PaymentDate: paymentDate,
+		RatePerUnit: req.RatePerUnit,
+		ExNAV:       req.ExNAV,
+		Status:      "pending",
+	}
+	if err := s.idcwRepo.Create(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func (s *idcwService) List(ctx context.Context, status string) ([]model.IDCWDeclaration, error) {
+	return s.idcwRepo.FindByStatus(ctx, status)
+}
+
+func (s *idcwService) Process(ctx context.Context, id string) (*model.IDCWDeclaration, error) {
+	return s.process(ctx, id, time.Now())
+}
+
+// process credits a declaration to everyone who held the scheme at the end
+// of the record date: current units less any ledger movement after it, which
+// includes holders who have since redeemed. Each holder is paid in one
+// transaction that re-reads their portfolio, checks the declaration's
+// reference and writes the ledger row and any reinvested units, so a run
+// that fails part-way can be retried, and one racing another pays nobody
+// twice: the reference is unique per user and scheme, and only one run can
+// move the declaration from pending to processed.
+func (s *idcwService) process(ctx context.Context, id string, asOf time.Time) (*model.IDCWDeclaration, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrDeclarationNotFound
+	}
+	d, err := s.idcwRepo.FindByID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrDeclarationNotFound
+		}
+		return nil, err
+	}
+	if d.Status == "processed" {
+		return nil, ErrDeclarationProcessed
+	}
+	if asOf.Before(d.PaymentDate) {
+		return nil, ErrDeclarationNotDue
+	}
+
+	scheme, err := s.mfRepo.FindByCode(ctx, d.SchemeCode)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrSchemeNotFound
+		}
+		return nil, err
+	}
+	reinvest := scheme.Option == model.OptionIDCWReinvest
+	exNAV := d.ExNAV
+	if reinvest && exNAV == 0 {
+		if scheme.NAVDate.Before(d.RecordDate) {
+			return nil, ErrExNAVRequired
+		}
+		exNAV = scheme.NAV
+	}
+
+	recordEnd := endOfDay(d.RecordDate)
+	holders, err := s.recordDateHolders(ctx, d.SchemeCode, recordEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := "idcw:" + d.ID.Hex()
+	summary := &model.IDCWSummary{}
+	for _, userID := range holders {
+		var txn *model.Transaction
+		err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			txn = nil
+			done, err := s.txnRepo.ExistsByReference(ctx, userID, ref)
+			if err != nil || done {
+				return err
+			}
+			p, err := s.portRepo.FindByUserID(ctx, userID)
+			if err != nil {
+				if !errors.Is(err, mongo.ErrNoDocuments) {
+					return err
+				}
+				p = &model.Portfolio{UserID: userID}
+			}
+			t, err := s.payment(ctx, p, d, scheme, exNAV, recordEnd, ref)
+			if err != nil || t == nil {
+				return err
+			}
+			if t.Type == model.TxnIDCWReinvest {
+				creditUnits(p, scheme, t.Units, t.NetAmount)
+				if err := s.portRepo.Upsert(ctx, p); err != nil {
+					return err
+				}
+			}
+			if err := s.txnRepo.Create(ctx, t); err != nil {
+				return err
+			}
+			txn = t
+			return nil
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			continue // paid by a concurrent run
+		}
+		if err != nil {
+			return nil, err
+		}
+		if txn == nil {
+			continue
+		}
+		summary.Holders++
+		summary.GrossAmount += txn.Amount
+		summary.TDS += txn.TDS
+		summary.NetAmount += txn.NetAmount
+		summary.UnitsReinvested += txn.Units
+	}
+	summary.GrossAmount = roundAmount(summary.GrossAmount)
+	summary.TDS = roundAmount(summary.TDS)
+	summary.NetAmount = roundAmount(summary.NetAmount)
+	summary.UnitsReinvested = roundUnits(summary.UnitsReinvested)
+
+	marked, err := s.idcwRepo.MarkProcessed(ctx, d.ID, summary)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, ErrDeclarationProcessed
+	}
+	return s.idcwRepo.FindByID(ctx, d.ID)
+}
+
+// payment builds the holder's IDCW ledger row from the portfolio as it
+// stands, or returns nil if they held no units at the end of the record
+// date. TDS applies once the year's IDCW from the AMC passes the threshold
+// in force on the payment date.
+func (s *idcwService) payment(ctx context.Context, p *model.Portfolio, d *model.IDCWDeclaration, scheme *model.MFScheme, exNAV float64, recordEnd time.Time, ref string) (*model.Transaction, error) {
+	held := 0.0
+	if h := findHolding(p, d.SchemeCode); h >= 0 {
+		held = p.Holdings[h].Units
+	}
+	moved, err := s.txnRepo.SumUnitsAfter(ctx, p.UserID, d.SchemeCode, recordEnd)
+	if err != nil {
+		return nil, err
+	}
+	eligible := roundUnits(held - moved)
+	if eligible <= 0 {
+		return nil, nil
+	}
+
+	gross := roundAmount(eligible * d.RatePerUnit)
+	fyStart, fyEnd := financialYear(d.PaymentDate)
+	paid, err := s.txnRepo.SumAmount(ctx, p.UserID, scheme.AMC,
+		[]string{model.TxnIDCWPayout, model.TxnIDCWReinvest}, fyStart, fyEnd)
+	if err != nil {
+		return nil, err
+	}
+	tds := 0.0
+	if paid+gross > idcwTDSThresholdOn(d.PaymentDate) {
+		tds = roundAmount(gross * idcwTDSRate)
+	}
+
+	txn := &model.Transaction{
+		UserID:     p.UserID,
+		SchemeCode: scheme.SchemeCode,
+		SchemeName: scheme.SchemeName,
+		AMC:        scheme.AMC,
+		Type:       model.TxnIDCWPayout,
+		Date:       d.PaymentDate,
+		Amount:     gross,
+		TDS:        tds,
+		NetAmount:  gross - tds,
+		Reference:  ref,
+	}
+	if scheme.Option == model.OptionIDCWReinvest {
+		txn.Type = model.TxnIDCWReinvest
+		txn.NAV = exNAV
+		txn.Units = roundUnits(txn.NetAmount / exNAV)
+	}
+	return txn, nil
+}
+
+// recordDateHolders lists current holders and users whose ledger shows
+// movement in the scheme after the record date, so that holders who have
+// since redeemed in full are still paid.
+func (s *idcwService) recordDateHolders(ctx context.Context, schemeCode string, recordEnd time.Time) ([]bson.ObjectID, error) {
+	portfolios, err := s.portRepo.FindHolders(ctx, schemeCode)
+	if err != nil {
+		return nil, err
+	}
+	var out []bson.ObjectID
+	seen := make(map[bson.ObjectID]bool, len(portfolios))
+	for _, p := range portfolios {
+		seen[p.UserID] = true
+		out = append(out, p.UserID)
+	}
+	moved, err := s.txnRepo.UserIDsWithUnitsAfter(ctx, schemeCode, recordEnd)
+	if err != nil {
+		return nil, err
+	}
+	for _, userID := range moved {
+		if !seen[userID] {
+			seen[userID] = true
+			out = append(out, userID)
+		}
+	}
+	return out, nil
+}
+
+// ProcessDue runs every pending declaration whose payment date has passed.
+// A failing declaration is logged and left pending for the next run.
+func (s *idcwService) ProcessDue(ctx context.Context, asOf time.Time) (int, error) {
+	due, err := s.idcwRepo.FindDue(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+	processed := 0
+	for _, d := range due {
+		if _, err := s.process(ctx, d.ID.Hex(), asOf); err != nil {
+			log.Printf("idcw: declaration %s for %s: %v", d.ID.Hex(), d.SchemeCode, err)
+			continue
+		}
+		processed++
+	}
+	return processed, nil
+}
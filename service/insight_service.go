@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var ErrNoDigest = errors.New("no monthly digest yet")
+
+const (
+	// sipSettlementDays is how long after its due date a SIP instalment may
+	// take to be marked as run. Digests wait this long after the month ends
+	// so that late instalments are counted.
+	sipSettlementDays = 5
+	// digestTopHoldings caps the best and worst performer lists.
+	digestTopHoldings = 3
+)
+
+type InsightService interface {
+	GenerateAll(ctx context.Context, now time.Time) (*model.InsightRunResult, error)
+	Latest(ctx context.Context, userID string) (*model.MonthlyDigest, error)
+}
+
+type insightService struct {
+	digestRepo repository.DigestRepo
+	portRepo   repository.PortfolioRepo
+	snapRepo   repository.PortfolioSnapshotRepo
+	txnRepo    repository.TransactionRepo
+	sipRepo    repository.SIPRepo
+	navRepo    repository.NAVRepo
+	notifRepo  repository.NotificationRepo
+	wealthSvc  WealthService
+	tx         repository.Transactor
+}
+
+func NewInsightService(dr repository.DigestRepo, pr repository.PortfolioRepo, sr repository.PortfolioSnapshotRepo, tr repository.TransactionRepo, sipr repository.SIPRepo, nr repository.NAVRepo, ntr repository.NotificationRepo, ws WealthService, tx repository.Transactor) InsightService {
+	return &insightService{dr, pr, sr, tr, sipr, nr, ntr, ws, tx}
+}
+
+// GenerateAll writes the digest of the month before now for every user
+// with a portfolio and notifies them. It is meant to run daily: nothing is
+// done until the month's SIPs have had time to settle, and users who already
+// have the month's digest are skipped, so each is told once. A user whose
+// digest fails is logged and retried on the next run.
+func (s *insightService) GenerateAll(ctx context.Context, now time.Time) (*model.InsightRunResult, error) {
+	y, m, _ := now.Date()
+	from := time.Date(y, m-1, 1, 0, 0, 0, 0, time.UTC)
+	to := endOfDay(from.AddDate(0, 1, -1))
+	res := &model.InsightRunResult{Month: from.Format(monthLayout)}
+	if dateOf(now).Before(dateOf(to).AddDate(0, 0, sipSettlementDays+1)) {
+		return res, nil
+	}
+
+	users, err := s.portRepo.UserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range users {
+		res.Users++
+		if err := s.generate(ctx, id, from, to, now, res); err != nil {
+			log.Printf("insights: user %s: %v", id.Hex(), err)
+			res.Failed++
+		}
+	}
+	return res, nil
+}
+
+// generate writes one user's digest and its notification together, unless
+// the month's digest already exists.
+func (s *insightService) generate(ctx context.Context, userID bson.ObjectID, from, to, now time.Time, res *model.InsightRunResult) error {
+	_, err := s.digestRepo.Find(ctx, userID, res.Month)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	d, err := s.digest(ctx, userID, from, to, now)
+	if err != nil || d == nil {
+		return err
+	}
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := s.digestRepo.Create(ctx, d); err != nil {
+			return err
+		}
+		return s.notify(ctx, d, from)
+	})
+	if err != nil {
+		return err
+	}
+	res.Digests++
+	return nil
+}
+
+func (s *insightService) Latest(ctx context.Context, userID string) (*model.MonthlyDigest, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	d, err := s.digestRepo.FindLatest(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNoDigest
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+// digest builds one user's digest for from to to, or nil if the user no
+// longer has a portfolio.
+func (s *insightService) digest(ctx context.Context, userID bson.ObjectID, from, to, now time.Time) (*model.MonthlyDigest, error) {
+	p, err := s.portRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	d := &model.MonthlyDigest{
+		UserID:       userID,
+		Month:        from.Format(monthLayout),
+		Best:         []model.HoldingPerformance{},
+		Worst:        []model.HoldingPerformance{},
+		SIPsExecuted: []model.SIPInstalment{},
+		SIPsMissed:   []model.SIPInstalment{},
+		Allocation:   []model.ClassDrift{},
+		UpcomingSIPs: []model.UpcomingInstalment{},
+	}
+
+	// Value change, from the last snapshot before the month to the last in
+	// it. A portfolio valued only since the month began opens at zero.
+	snaps, err := s.snapRepo.FindRange(ctx, userID, from.Add(-closingPriceLookback), to)
+	if err != nil {
+		return nil, err
+	}
+	d.ClosingValue = p.TotalValue
+	for _, sn := range snaps {
+		if sn.Date.Before(from) {
+			d.OpeningValue = sn.Value
+		} else {
+			d.ClosingValue = sn.Value
+		}
+	}
+	d.Change = roundAmount(d.ClosingValue - d.OpeningValue)
+	if d.OpeningValue > 0 {
+		pct := roundAmount(d.Change / d.OpeningValue * 100)
+		d.ChangePct = &pct
+	}
+
+	sips, err := s.sipRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	d.SIPsExecuted = runInstalments(sips, from, to)
+	d.SIPsMissed = missedInstalments(sips, from, to)
+	for _, inst := range d.SIPsExecuted {
+		d.NetInvested += inst.Amount
+	}
+	txns, err := s.txnRepo.FindByUserID(ctx, userID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range txns {
+		switch t.Type {
+		case model.TxnPurchase, model.TxnNFOAllotment, model.TxnNPSContribution, model.TxnEquityBuy:
+			d.NetInvested += math.Abs(t.Amount)
+		case model.TxnRedemption, model.TxnEquitySell, model.TxnIDCWPayout:
+			d.NetInvested -= math.Abs(t.Amount)
+		}
+	}
+	d.NetInvested = roundAmount(d.NetInvested)
+	d.MarketGain = roundAmount(d.Change - d.NetInvested)
+
+	if d.Best, d.Worst, err = s.performers(ctx, p, from, to); err != nil {
+		return nil, err
+	}
+
+	today := dateOf(now)
+	for _, sip := range sips {
+		if sip.Status != "active" {
+			continue
+		}
+		for _, due := range sipDatesBetween(sip, today, endOfDay(today.AddDate(0, 1, 0))) {
+			d.UpcomingSIPs = append(d.UpcomingSIPs, model.UpcomingInstalment{
+				SIPID:      sip.ID,
+				SchemeCode: sip.SchemeCode,
+				SchemeName: sip.SchemeName,
+				Date:       due,
+				Amount:     sip.Amount,
+			})
+			d.UpcomingSIPTotal += sip.Amount
+		}
+	}
+	sort.SliceStable(d.UpcomingSIPs, func(i, j int) bool { return d.UpcomingSIPs[i].Date.Before(d.UpcomingSIPs[j].Date) })
+	d.UpcomingSIPTotal = roundAmount(d.UpcomingSIPTotal)
+
+	plan, err := s.wealthSvc.GetRebalancePlan(ctx, userID.Hex())
+	if err != nil {
+		return nil, err
+	}
+	d.RiskCategory, d.Allocation, d.NeedsRebalance = plan.RiskCategory, plan.Classes, plan.NeedsRebalance
+	return d, nil
+}
+
+// performers ranks the funds and shares held by their NAV or price change
+// over the month and returns the best and the worst, without overlap.
+func (s *insightService) performers(ctx context.Context, p *model.Portfolio, from, to time.Time) (best, worst []model.HoldingPerformance, err error) {
+	var perf []model.HoldingPerformance
+	for i := range p.Holdings {
+		h := &p.Holdings[i]
+		code, assetType := h.SchemeCode, model.AssetMutualFund
+		switch {
+		case isFund(h):
+		case h.Equity != nil:
+			code, assetType = h.Equity.ISIN, model.AssetEquity
+		default:
+			continue
+		}
+		if h.CurrentValue <= 0 {
+			continue
+		}
+		points, err := s.navRepo.FindRange(ctx, code, from.Add(-closingPriceLookback), to)
+		if err != nil {
+			return nil, nil, err
+		}
+		var start float64
+		for _, pt := range points {
+			if pt.Date.Before(from) {
+				start = pt.NAV
+			}
+		}
+		if start <= 0 || points[len(points)-1].Date.Before(from) {
+			continue
+		}
+		perf = append(perf, model.HoldingPerformance{
+			SchemeCode:   code,
+			SchemeName:   h.SchemeName,
+			AssetType:    assetType,
+			CurrentValue: h.CurrentValue,
+			ReturnPct:    roundAmount((points[len(points)-1].NAV/start - 1) * 100),
+		})
+	}
+	sort.SliceStable(perf, func(i, j int) bool {
+		if perf[i].ReturnPct != perf[j].ReturnPct {
+			return perf[i].ReturnPct > perf[j].ReturnPct
+		}
+		return perf[i].SchemeName < perf[j].SchemeName
+	})
+	nb := min(digestTopHoldings, (len(perf)+1)/2)
+	nw := min(digestTopHoldings, len(perf)/2)
+	best = append([]model.HoldingPerformance{}, perf[:nb]...)
+	worst = []model.HoldingPerformance{}
+	for i := len(perf) - 1; i >= len(perf)-nw; i-- {
+		worst = append(worst, perf[i])
+	}
+	return best, worst, nil
+}
+
+// runInstalments lists the instalments due from from to to that each SIP's
+// execution state marks as run, in due date order.
+func runInstalments(sips []model.SIP, from, to time.Time) []model.SIPInstalment {
+	out := []model.SIPInstalment{}
+	for _, sip := range sips {
+		for _, due := range sipRunDates(sip, to) {
+			if !due.Before(from) {
+				out = append(out, instalmentOf(sip, due))
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].DueDate.Before(out[j].DueDate) })
+	return out
+}
+
+// missedInstalments lists the instalments due from from to to that active
+// SIPs have not run: those on or after the SIP's next due date. Digests wait
+// sipSettlementDays after the month, so these are overdue, not settling.
+func missedInstalments(sips []model.SIP, from, to time.Time) []model.SIPInstalment {
+	out := []model.SIPInstalment{}
+	for _, sip := range sips {
+		if sip.Status != "active" {
+			continue
+		}
+		for _, due := range sipDatesBetween(sip, from, to) {
+			out = append(out, instalmentOf(sip, due))
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].DueDate.Before(out[j].DueDate) })
+	return out
+}
+
+func instalmentOf(sip model.SIP, due time.Time) model.SIPInstalment {
+	return model.SIPInstalment{
+		SIPID:      sip.ID,
+		SchemeCode: sip.SchemeCode,
+		SchemeName: sip.SchemeName,
+		DueDate:    dateOf(due),
+		Amount:     sip.Amount,
+	}
+}
+
+func (s *insightService) notify(ctx context.Context, d *model.MonthlyDigest, month time.Time) error {
+	direction := "up"
+	if d.Change < 0 {
+		direction = "down"
+	}
+	sipNote := fmt.Sprintf("%d SIP instalments went through", len(d.SIPsExecuted))
+	if len(d.SIPsMissed) > 0 {
+		sipNote += fmt.Sprintf(" and %d were missed", len(d.SIPsMissed))
+	}
+	body := fmt.Sprintf("Your portfolio ended %s at ₹%.2f, %s ₹%.2f. %s.",
+		month.Format("January"), d.ClosingValue, direction, math.Abs(d.Change), sipNote)
+	if d.NeedsRebalance {
+		body += " Your allocation has drifted from your target mix."
+	}
+	return s.notifRepo.Create(ctx, &model.NotificationEvent{
+		UserID: d.UserID,
+		Type:   model.EventMonthlyDigest,
+		Title:  "Your " + month.Format("January 2006") + " portfolio digest",
+		Body:   body,
+		Data: map[string]interface{}{
+			"digest_id":       d.ID.Hex(),
+			"month":           d.Month,
+			"closing_value":   d.ClosingValue,
+			"change":          d.Change,
+			"sips_executed":   len(d.SIPsExecuted),
+			"sips_missed":     len(d.SIPsMissed),
+			"needs_rebalance": d.NeedsRebalance,
+		},
+	})
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const amfiDateLayout = "02-Jan-2006"
+
+type NAVService interface {
+	Import(ctx context.Context, format string, data []byte) (*model.NAVImportResult, error)
+	GetHistory(ctx context.Context, code string, from, to time.Time) ([]model.NAVPoint, error)
+}
+
+type navService struct {
+	mfRepo  repository.MFSchemeRepo
+	navRepo repository.NAVRepo
+	watch   WatchlistService
+}
+
+func NewNAVService(mr repository.MFSchemeRepo, nr repository.NAVRepo, ws WatchlistService) NAVService {
+	return &navService{mr, nr, ws}
+}
+
+// Import loads NAVs into history and moves each scheme's current NAV forward.
+// Rows for schemes outside the catalogue are counted as skipped. Watchlist
+// alerts are evaluated for schemes whose NAV moved; an alert failure is
+// logged rather than failing an import that has already been stored.
+func (s *navService) Import(ctx context.Context, format string, data []byte) (*model.NAVImportResult, error) {
+	var (
+		points []model.NAVPoint
+		errs   []string
+		err    error
+	)
+	switch format {
+	case "amfi":
+		points, errs = parseAMFINAV(data)
+	case "json":
+		points, errs, err = parseNAVJSON(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	schemes, err := s.mfRepo.FindAll(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(schemes))
+	for _, sc := range schemes {
+		known[sc.SchemeCode] = true
+	}
+
+	res := &model.NAVImportResult{Errors: errs}
+	latest := make(map[string]model.NAVPoint)
+	kept := points[:0]
+	for _, p := range points {
+		if !known[p.SchemeCode] {
+			res.Skipped++
+			continue
+		}
+		kept = append(kept, p)
+		if cur, ok := latest[p.SchemeCode]; !ok || p.Date.After(cur.Date) {
+			latest[p.SchemeCode] = p
+		}
+	}
+	if err := s.navRepo.UpsertMany(ctx, kept); err != nil {
+		return nil, err
+	}
+	res.Points = len(kept)
+
+	var moved []string
+	for _, p := range latest {
+		updated, err := s.mfRepo.UpdateNAV(ctx, p.SchemeCode, p.NAV, p.Date)
+		if err != nil {
+			return nil, err
+		}
+		if updated {
+			res.SchemesUpdated++
+			moved = append(moved, p.SchemeCode)
+		}
+	}
+
+	if res.AlertsRaised, err = s.watch.EvaluateAlerts(ctx, moved); err != nil {
+		log.Printf("nav import: watchlist alerts: %v", err)
+	}
+	return res, nil
+}
+
+func (s *navService) GetHistory(ctx context.Context, code string, from, to time.Time) ([]model.NAVPoint, error) {
+	if _, err := s.mfRepo.FindByCode(ctx, code); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrSchemeNotFound
+		}
+		return nil, err
+	}
+	points, err := s.navRepo.FindRange(ctx, code, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if points == nil {
+		points = []model.NAVPoint{}
+	}
+	return points, nil
+}
+
+// parseAMFINAV reads AMFI's NAVAll.txt: semicolon-separated rows of
+// code;ISIN payout/growth;ISIN reinvestment;name;NAV;date, interleaved with
+// AMC and category heading lines that carry no semicolons.
+func parseAMFINAV(data []byte) ([]model.NAVPoint, []string) {
+	var (
+		points []model.NAVPoint
+		errs   []string
+	)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		f := strings.Split(strings.TrimSpace(sc.Text()), ";")
+		if len(f) < 6 {
+			continue
+		}
+		code := strings.TrimSpace(f[0])
+		if _, err := strconv.Atoi(code); err != nil {
+			continue // header row
+		}
+		nav, err := strconv.ParseFloat(strings.TrimSpace(f[4]), 64)
+		if err != nil || nav <= 0 {
+			continue // "N.A." for schemes without a NAV today
+		}
+		date, err := time.Parse(amfiDateLayout, strings.TrimSpace(f[5]))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("line %d: invalid date %q", line, f[5]))
+			continue
+		}
+		points = append(points, model.NAVPoint{SchemeCode: code, Date: date, NAV: nav})
+	}
+	return points, errs
+}
+
+func parseNAVJSON(data []byte) ([]model.NAVPoint, []string, error) {
+	var records []struct {
+		SchemeCode string  `json:"scheme_code"`
+		Date       string  `json:"date"`
+		NAV        float64 `json:"nav"`
+	}
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrUnsupportedFormat, err)
+	}
+	var (
+		points []model.NAVPoint
+		errs   []string
+	)
+	for i, r := range records {
+		date, err := time.Parse(importDateLayout, r.Date)
+		if err != nil || r.SchemeCode == "" || r.NAV <= 0 {
+			errs = append(errs, fmt.Sprintf("record %d: invalid scheme_code, date or nav", i+1))
+			continue
+		}
+		points = append(points, model.NAVPoint{SchemeCode: r.SchemeCode, Date: date, NAV: r.NAV})
+	}
+	return points, errs, nil
+}
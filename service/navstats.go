@@ -0,0 +1,100 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/service/finmath"
+)
+
+const (
+	tradingDaysPerYear = 252
+	riskFreeRate       = 6.5 // % p.a., used for Sharpe ratios
+)
+
+// annualisedReturn is the CAGR between two NAVs in percent. Periods under a
+// year are reported as absolute returns, as AMFI does.
+func annualisedReturn(startNAV, endNAV float64, from, to time.Time) float64 {
+	if startNAV <= 0 || !to.After(from) {
+		return 0
+	}
+	years := to.Sub(from).Hours() / 24 / 365
+	if years < 1 {
+		return (endNAV/startNAV - 1) * 100
+	}
+	return finmath.CAGR(startNAV, endNAV, years)
+}
+
+// riskMetrics derives volatility, Sharpe ratio and maximum drawdown from a
+// date-ordered NAV series.
+func riskMetrics(points []model.NAVPoint) model.RiskMetrics {
+	if len(points) < 2 {
+		return model.RiskMetrics{}
+	}
+	var mean float64
+	rets := make([]float64, 0, len(points)-1)
+	for i := 1; i < len(points); i++ {
+		r := points[i].NAV/points[i-1].NAV - 1
+		rets = append(rets, r)
+		mean += r
+	}
+	mean /= float64(len(rets))
+	var variance float64
+	for _, r := range rets {
+		variance += (r - mean) * (r - mean)
+	}
+	if len(rets) > 1 {
+		variance /= float64(len(rets) - 1)
+	}
+	vol := math.Sqrt(variance) * math.Sqrt(tradingDaysPerYear) * 100
+
+	first, last := points[0], points[len(points)-1]
+	m := model.RiskMetrics{
+		Volatility:  roundAmount(vol),
+		MaxDrawdown: roundAmount(maxDrawdown(points)),
+	}
+	if vol > 0 {
+		ret := annualisedReturn(first.NAV, last.NAV, first.Date, last.Date)
+		m.SharpeRatio = roundAmount((ret - riskFreeRate) / vol)
+	}
+	return m
+}
+
+// maxDrawdown is the largest peak-to-trough fall in percent (<= 0).
+func maxDrawdown(points []model.NAVPoint) float64 {
+	var peak, worst float64
+	for _, p := range points {
+		if p.NAV > peak {
+			peak = p.NAV
+		}
+		if peak > 0 {
+			if dd := (p.NAV/peak - 1) * 100; dd < worst {
+				worst = dd
+			}
+		}
+	}
+	return worst
+}
+
+// periodStart maps a range code (1M, 3M, 6M, 1Y, 3Y, 5Y, ALL) to its start
+// date relative to now. ALL has no start and yields the zero time.
+func periodStart(period string, now time.Time) (time.Time, bool) {
+	switch period {
+	case "1M":
+		return now.AddDate(0, -1, 0), true
+	case "3M":
+		return now.AddDate(0, -3, 0), true
+	case "6M":
+		return now.AddDate(0, -6, 0), true
+	case "1Y":
+		return now.AddDate(-1, 0, 0), true
+	case "3Y":
+		return now.AddDate(-3, 0, 0), true
+	case "5Y":
+		return now.AddDate(-5, 0, 0), true
+	case "ALL":
+		return time.Time{}, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	defaultNetWorthMonths = 12
+	maxNetWorthMonths     = 120
+	monthLayout           = "2006-01"
+)
+
+// netWorthTypes maps each item type to whether it is an asset or a liability.
+var netWorthTypes = map[string]string{
+	"savings":         model.NetWorthAsset,
+	"current":         model.NetWorthAsset,
+	"cash":            model.NetWorthAsset,
+	"property":        model.NetWorthAsset,
+	"epf":             model.NetWorthAsset,
+	"ppf":             model.NetWorthAsset,
+	"vehicle":         model.NetWorthAsset,
+	"other_asset":     model.NetWorthAsset,
+	"home_loan":       model.NetWorthLiability,
+	"personal_loan":   model.NetWorthLiability,
+	"car_loan":        model.NetWorthLiability,
+	"education_loan":  model.NetWorthLiability,
+	"credit_card":     model.NetWorthLiability,
+	"other_liability": model.NetWorthLiability,
+}
+
+var (
+	ErrNetWorthItemNotFound = errors.New("net worth item not found")
+	ErrFeedItemReadOnly     = errors.New("item is kept up to date by the bank and cannot be edited")
+)
+
+type NetWorthService interface {
+	AddItem(ctx context.Context, userID string, req *model.NetWorthItemRequest) (*model.NetWorthItem, error)
+	ListItems(ctx context.Context, userID string) ([]model.NetWorthItem, error)
+	UpdateItem(ctx context.Context, userID, id string, req *model.NetWorthItemRequest) (*model.NetWorthItem, error)
+	RemoveItem(ctx context.Context, userID, id string) error
+	IngestFeed(ctx context.Context, records []model.NetWorthFeedRecord) (*model.NetWorthFeedResult, error)
+	Get(ctx context.Context, userID string) (*model.NetWorth, error)
+	History(ctx context.Context, userID string, months int) ([]model.NetWorthSnapshot, error)
+	SnapshotAll(ctx context.Context, now time.Time) (int, error)
+}
+
+type netWorthService struct {
+	itemRepo repository.NetWorthRepo
+	snapRepo repository.NetWorthSnapshotRepo
+	portRepo repository.PortfolioRepo
+}
+
+func NewNetWorthService(ir repository.NetWorthRepo, sr repository.NetWorthSnapshotRepo, pr repository.PortfolioRepo) NetWorthService {
+	return &netWorthService{ir, sr, pr}
+}
+
+func (s *netWorthService) AddItem(ctx context.Context, userID string, req *model.NetWorthItemRequest) (*model.NetWorthItem, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	item := &model.NetWorthItem{UserID: oid, Source: model.SourceManual}
+	if err := applyItemRequest(item, req); err != nil {
+		return nil, err
+	}
+	if err := s.itemRepo.Create(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *netWorthService) ListItems(ctx context.Context, userID string) ([]model.NetWorthItem, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	items, err := s.itemRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = []model.NetWorthItem{}
+	}
+	return items, nil
+}
+
+func (s *netWorthService) UpdateItem(ctx context.Context, userID, id string, req *model.NetWorthItemRequest) (*model.NetWorthItem, error) {
+	item, err := s.item(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	if item.Source == model.SourceFeed {
+		return nil, ErrFeedItemReadOnly
+	}
+	if err := applyItemRequest(item, req); err != nil {
+		return nil, err
+	}
+	if err := s.itemRepo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *netWorthService) RemoveItem(ctx context.Context, userID, id string) error {
+	item, err := s.item(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if item.Source == model.SourceFeed {
+		return ErrFeedItemReadOnly
+	}
+	_, err = s.itemRepo.Delete(ctx, item.UserID, item.ID)
+	return err
+}
+
+// IngestFeed upserts balances pushed by upstream systems. Invalid records
+// are reported and skipped.
+func (s *netWorthService) IngestFeed(ctx context.Context, records []model.NetWorthFeedRecord) (*model.NetWorthFeedResult, error) {
+	res := &model.NetWorthFeedResult{}
+	for i, rec := range records {
+		oid, err := bson.ObjectIDFromHex(rec.UserID)
+		if err != nil || strings.TrimSpace(rec.ExternalRef) == "" {
+			res.Errors = append(res.Errors, fmt.Sprintf("record %d: invalid user_id or external_ref", i+1))
+			continue
+		}
+		item := &model.NetWorthItem{UserID: oid, Source: model.SourceFeed, ExternalRef: strings.TrimSpace(rec.ExternalRef)}
+		req := &model.NetWorthItemRequest{Type: rec.Type, Name: rec.Name, Institution: rec.Institution, Value: rec.Value, AsOf: rec.AsOf}
+		if err := applyItemRequest(item, req); err != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("record %d: invalid type, name or value", i+1))
+			continue
+		}
+		if err := s.itemRepo.UpsertFeed(ctx, item); err != nil {
+			return nil, err
+		}
+		res.Upserted++
+	}
+	return res, nil
+}
+
+func (s *netWorthService) Get(ctx context.Context, userID string) (*model.NetWorth, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	return s.compute(ctx, oid, time.Now())
+}
+
+func (s *netWorthService) History(ctx context.Context, userID string, months int) ([]model.NetWorthSnapshot, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	if months == 0 {
+		months = defaultNetWorthMonths
+	}
+	if months < 1 || months > maxNetWorthMonths {
+		return nil, ErrInvalidRequest
+	}
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month()-time.Month(months-1), 1, 0, 0, 0, 0, now.Location())
+	snaps, err := s.snapRepo.FindByUserID(ctx, oid, from.Format(monthLayout))
+	if err != nil {
+		return nil, err
+	}
+	if snaps == nil {
+		snaps = []model.NetWorthSnapshot{}
+	}
+	return snaps, nil
+}
+
+// SnapshotAll records the current month's net worth for every user with a
+// portfolio or a net worth item. Run daily, it leaves each past month's
+// snapshot at the value on its last day. A user whose snapshot fails is
+// logged and skipped; the count returned is of snapshots written.
+func (s *netWorthService) SnapshotAll(ctx context.Context, now time.Time) (int, error) {
+	users := make(map[bson.ObjectID]bool)
+	for _, list := range []func(context.Context) ([]bson.ObjectID, error){s.portRepo.UserIDs, s.itemRepo.UserIDs} {
+		ids, err := list(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for _, id := range ids {
+			users[id] = true
+		}
+	}
+	n, failed := 0, 0
+	for id := range users {
+		if err := s.snapshotUser(ctx, id, now); err != nil {
+			log.Printf("net worth snapshots: user %s: %v", id.Hex(), err)
+			failed++
+			continue
+		}
+		n++
+	}
+	if failed > 0 {
+		log.Printf("net worth snapshots: %d written, %d failed", n, failed)
+	}
+	return n, nil
+}
+
+func (s *netWorthService) snapshotUser(ctx context.Context, id bson.ObjectID, now time.Time) error {
+	nw, err := s.compute(ctx, id, now)
+	if err != nil {
+		return err
+	}
+	return s.snapRepo.Upsert(ctx, &model.NetWorthSnapshot{
+		UserID:           id,
+		Month:            now.Format(monthLayout),
+		Assets:           lineValues(nw.Assets),
+		Liabilities:      lineValues(nw.Liabilities),
+		TotalAssets:      nw.TotalAssets,
+		TotalLiabilities: nw.TotalLiabilities,
+		NetWorth:         nw.NetWorth,
+	})
+}
+
+// compute adds the user's portfolio holdings, by asset type, to their net
+// worth items.
+func (s *netWorthService) compute(ctx context.Context, userID bson.ObjectID, now time.Time) (*model.NetWorth, error) {
+	assets := make(map[string]*model.NetWorthLine)
+	liabilities := make(map[string]*model.NetWorthLine)
+	add := func(lines map[string]*model.NetWorthLine, typ string, value float64) {
+		l, ok := lines[typ]
+		if !ok {
+			l = &model.NetWorthLine{Type: typ}
+			lines[typ] = l
+		}
+		l.Value += value
+		l.Items++
+	}
+
+	p, err := s.portRepo.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if p != nil {
+		revalueDeposits(p, now)
+		for _, h := range p.Holdings {
+			typ := h.AssetType
+			if isFund(&h) {
+				typ = model.AssetMutualFund
+			}
+			add(assets, typ, h.CurrentValue)
+		}
+	}
+	items, err := s.itemRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, it := range items {
+		if it.Kind == model.NetWorthLiability {
+			add(liabilities, it.Type, it.Value)
+		} else {
+			add(assets, it.Type, it.Value)
+		}
+	}
+
+	nw := &model.NetWorth{AsOf: now}
+	nw.Assets, nw.TotalAssets = sortedLines(assets)
+	nw.Liabilities, nw.TotalLiabilities = sortedLines(liabilities)
+	nw.NetWorth = roundAmount(nw.TotalAssets - nw.TotalLiabilities)
+	return nw, nil
+}
+
+// sortedLines lists lines largest first and returns their total.
+func sortedLines(lines map[string]*model.NetWorthLine) ([]model.NetWorthLine, float64) {
+	out := make([]model.NetWorthLine, 0, len(lines))
+	total := 0.0
+	for _, l := range lines {
+		l.Value = roundAmount(l.Value)
+		total += l.Value
+		out = append(out, *l)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Value != out[j].Value {
+			return out[i].Value > out[j].Value
+		}
+		return out[i].Type < out[j].Type
+	})
+	return out, roundAmount(total)
+}
+
+func lineValues(lines []model.NetWorthLine) map[string]float64 {
+	out := make(map[string]float64, len(lines))
+	for _, l := range lines {
+		out[l.Type] = l.Value
+	}
+	return out
+}
+
+func applyItemRequest(item *model.NetWorthItem, req *model.NetWorthItemRequest) error {
+	kind, ok := netWorthTypes[req.Type]
+	name := strings.TrimSpace(req.Name)
+	if !ok || name == "" || req.Value < 0 {
+		return ErrInvalidRequest
+	}
+	item.Kind = kind
+	item.Type = req.Type
+	item.Name = name
+	item.Institution = strings.TrimSpace(req.Institution)
+	item.Value = roundAmount(req.Value)
+	item.AsOf = req.AsOf
+	if item.AsOf.IsZero() {
+		item.AsOf = time.Now()
+	}
+	return nil
+}
+
+func (s *netWorthService) item(ctx context.Context, userID, id string) (*model.NetWorthItem, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	iid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNetWorthItemNotFound
+	}
+	item, err := s.itemRepo.FindByID(ctx, oid, iid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNetWorthItemNotFound
+		}
+		return nil, err
+	}
+	return item, nil
+}
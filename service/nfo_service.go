@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const nfoOfferPrice = 10.0
+
+var (
+	ErrNFONotFound      = errors.New("nfo not found")
+	ErrNFONotOpen       = errors.New("nfo is not open for applications")
+	ErrNFOAllotted      = errors.New("nfo already allotted")
+	ErrNFOStillOpen     = errors.New("nfo offer period has not closed")
+	ErrSchemeCodeInUse  = errors.New("scheme code already in use")
+	ErrNFOOrderNotFound = errors.New("pending nfo order not found")
+)
+
+type NFOService interface {
+	Create(ctx context.Context, req *model.NFORequest) (*model.NFO, error)
+	List(ctx context.Context, status string) ([]model.NFO, error)
+	ListOpen(ctx context.Context) ([]model.NFO, error)
+	Apply(ctx context.Context, userID, nfoID string, req *model.NFOOrderRequest) (*model.NFOOrder, error)
+	ListOrders(ctx context.Context, userID string) ([]model.NFOOrder, error)
+	CancelOrder(ctx context.Context, userID, orderID string) error
+	Allot(ctx context.Context, id string) (*model.NFO, error)
+	AllotDue(ctx context.Context, asOf time.Time) (int, error)
+}
+
+type nfoService struct {
+	nfoRepo   repository.NFORepo
+	orderRepo repository.NFOOrderRepo
+	mfRepo    repository.MFSchemeRepo
+	portRepo  repository.PortfolioRepo
+	txnRepo   repository.TransactionRepo
+	tx        repository.Transactor
+}
+
+func NewNFOService(nr repository.NFORepo, or repository.NFOOrderRepo, mr repository.MFSchemeRepo, pr repository.PortfolioRepo, tr repository.TransactionRepo, tx repository.Transactor) NFOService {
+	return &nfoService{nr, or, mr, pr, tr, tx}
+}
+
+func (s *nfoService) Create(ctx context.Context, req *model.NFORequest) (*model.NFO, error) {
+	if req.SchemeCode == "" || req.SchemeName == "" || req.AMC == "" || req.Category == "" ||
+		req.OpenDate.IsZero() || req.CloseDate.Before(req.OpenDate) || req.AllotmentDate.Before(req.CloseDate) ||
+		req.OfferPrice < 0 || req.MinApplication < 0 || req.MinSIP < 0 {
+		return nil, ErrInvalidRequest
+	}
+	if _, err := s.mfRepo.FindByCode(ctx, req.SchemeCode); err == nil {
+		return nil, ErrSchemeCodeInUse
+	} else if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+
+	n := &model.NFO{
+		SchemeCode:     req.SchemeCode,
+		SchemeName:     req.SchemeName,
+		FundCode:       req.FundCode,
+		FundName:       req.FundName,
+		Plan:           req.Plan,
+		Option:         req.Option,
+		ISIN:           req.ISIN,
+		AMC:            req.AMC,
+		Category:       req.Category,
+		SubCategory:    req.SubCategory,
+		Risk:           req.Risk,
+		Description:    req.Description,
+		OfferPrice:     req.OfferPrice,
+		MinApplication: req.MinApplication,
+		MinSIP:         req.MinSIP,
+		OpenDate:       req.OpenDate,
+		CloseDate:      endOfDay(req.CloseDate),
+		AllotmentDate:  req.AllotmentDate,
+		Status:         "pending",
+	}
+	if n.OfferPrice == 0 {
+		n.OfferPrice = nfoOfferPrice
+	}
+	if n.FundCode == "" {
+		n.FundCode = n.SchemeCode
+	}
+	if n.FundName == "" {
+		n.FundName = n.SchemeName
+	}
+	if err := s.nfoRepo.Create(ctx, n); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrSchemeCodeInUse
+		}
+		return nil, err
+	}
+	return n, nil
+}
+
+func (s *nfoService) List(ctx context.Context, status string) ([]model.NFO, error) {
+	return s.nfoRepo.FindByStatus(ctx, status)
+}
+
+func (s *nfoService) ListOpen(ctx context.Context) ([]model.NFO, error) {
+	nfos, err := s.nfoRepo.FindOpen(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if nfos == nil {
+		nfos = []model.NFO{}
+	}
+	return nfos, nil
+}
+
+func (s *nfoService) Apply(ctx context.Context, userID, nfoID string, req *model.NFOOrderRequest) (*model.NFOOrder, error) {
+	uid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	n, err := s.find(ctx, nfoID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if n.Status != "pending" || now.Before(n.OpenDate) || now.After(n.CloseDate) {
+		return nil, ErrNFONotOpen
+	}
+	if req.Amount <= 0 || req.Amount < n.MinApplication {
+		return nil, ErrInvalidRequest
+	}
+
+	o := &model.NFOOrder{
+		UserID:     uid,
+		NFOID:      n.ID,
+		SchemeCode: n.SchemeCode,
+		SchemeName: n.SchemeName,
+		Amount:     roundAmount(req.Amount),
+		Status:     "pending",
+	}
+	if err := s.orderRepo.Create(ctx, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (s *nfoService) ListOrders(ctx context.Context, userID string) ([]model.NFOOrder, error) {
+	uid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	orders, err := s.orderRepo.FindByUserID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if orders == nil {
+		orders = []model.NFOOrder{}
+	}
+	return orders, nil
+}
+
+func (s *nfoService) CancelOrder(ctx context.Context, userID, orderID string) error {
+	uid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrUnauthorized
+	}
+	oid, err := bson.ObjectIDFromHex(orderID)
+	if err != nil {
+		return ErrNFOOrderNotFound
+	}
+	ok, err := s.orderRepo.Cancel(ctx, uid, oid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNFOOrderNotFound
+	}
+	return nil
+}
+
+// Allot lists the NFO as a regular scheme at its offer price and converts
+// every pending order into units. Each order moves from pending to allotted
+// in the same transaction that credits it, so an order cancelled meanwhile
+// is skipped, and a run racing another or retried after failing part-way
+// credits no order twice.
+func (s *nfoService) Allot(ctx context.Context, id string) (*model.NFO, error) {
+	n, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if n.Status == "allotted" {
+		return nil, ErrNFOAllotted
+	}
+	if time.Now().Before(n.CloseDate) {
+		return nil, ErrNFOStillOpen
+	}
+
+	scheme, err := s.listScheme(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := s.orderRepo.FindPending(ctx, n.ID)
+	if err != nil {
+		return nil, err
+	}
+	summary := &model.NFOSummary{}
+	for _, o := range orders {
+		units := roundUnits(o.Amount / n.OfferPrice)
+		ref := "nfo:" + o.ID.Hex()
+		err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+			marked, err := s.orderRepo.MarkAllotted(ctx, o.ID, units, n.OfferPrice)
+			if err != nil {
+				return err
+			}
+			if !marked {
+				return ErrNFOOrderNotFound
+			}
+			done, err := s.txnRepo.ExistsByReference(ctx, o.UserID, ref)
+			if err != nil || done {
+				return err
+			}
+			return s.credit(ctx, o, scheme, units, ref, n.AllotmentDate)
+		})
+		if errors.Is(err, ErrNFOOrderNotFound) {
+			continue // cancelled or allotted since it was listed
+		}
+		if err != nil {
+			return nil, err
+		}
+		summary.Orders++
+		summary.Amount += o.Amount
+		summary.Units += units
+	}
+	summary.Amount = roundAmount(summary.Amount)
+	summary.Units = roundUnits(summary.Units)
+
+	marked, err := s.nfoRepo.MarkAllotted(ctx, n.ID, summary)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, ErrNFOAllotted
+	}
+	return s.nfoRepo.FindByID(ctx, n.ID)
+}
+
+// listScheme creates the catalogue entry for the NFO, or returns it if an
+// earlier allotment attempt already did.
+func (s *nfoService) listScheme(ctx context.Context, n *model.NFO) (*model.MFScheme, error) {
+	sc, err := s.mfRepo.FindByCode(ctx, n.SchemeCode)
+	if err == nil {
+		return sc, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	sc = &model.MFScheme{
+		SchemeCode:  n.SchemeCode,
+		SchemeName:  n.SchemeName,
+		FundCode:    n.FundCode,
+		FundName:    n.FundName,
+		Plan:        n.Plan,
+		Option:      n.Option,
+		ISIN:        n.ISIN,
+		AMC:         n.AMC,
+		Category:    n.Category,
+		SubCategory: n.SubCategory,
+		NAV:         n.OfferPrice,
+		NAVDate:     n.AllotmentDate,
+		Risk:        n.Risk,
+		MinSIP:      n.MinSIP,
+		MinLumpsum:  n.MinApplication,
+		IsActive:    true,
+	}
+	if err := s.mfRepo.Create(ctx, sc); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+// credit adds the allotted units to the holder's portfolio and records the
+// allotment in the ledger. Callers run it inside a transaction.
+func (s *nfoService) credit(ctx context.Context, o model.NFOOrder, scheme *model.MFScheme, units float64, ref string, date time.Time) error {
+	p, err := s.portRepo.FindByUserID(ctx, o.UserID)
+	if err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		p = &model.Portfolio{UserID: o.UserID, Holdings: []model.Holding{}}
+	}
+	creditUnits(p, scheme, units, o.Amount)
+	if err := s.portRepo.Upsert(ctx, p); err != nil {
+		return err
+	}
+
+	txn := &model.Transaction{
+		UserID:     o.UserID,
+		SchemeCode: scheme.SchemeCode,
+		SchemeName: scheme.SchemeName,
+		AMC:        scheme.AMC,
+		Type:       model.TxnNFOAllotment,
+		Date:       date,
+		Units:      units,
+		NAV:        scheme.NAV,
+		Amount:     o.Amount,
+		NetAmount:  o.Amount,
+		Reference:  ref,
+	}
+	return s.txnRepo.Create(ctx, txn)
+}
+
+// AllotDue allots every pending NFO whose allotment date has passed. A
+// failing NFO is logged and left pending for the next run.
+func (s *nfoService) AllotDue(ctx context.Context, asOf time.Time) (int, error) {
+	due, err := s.nfoRepo.FindDue(ctx, asOf)
+	if err != nil {
+		return 0, err
+	}
+	allotted := 0
+	for _, n := range due {
+		if _, err := s.Allot(ctx, n.ID.Hex()); err != nil {
+			log.Printf("nfo: allotment of %s (%s): %v", n.ID.Hex(), n.SchemeCode, err)
+			continue
+		}
+		allotted++
+	}
+	return allotted, nil
+}
+
+func (s *nfoService) find(ctx context.Context, id string) (*model.NFO, error) {
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, ErrNFONotFound
+	}
+	n, err := s.nfoRepo.FindByID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNFONotFound
+		}
+		return nil, err
+	}
+	return n, nil
+}
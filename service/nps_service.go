@@ -0,0 +1,482 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// npsDateLayout is the NPS Trust NAV file's date format.
+const npsDateLayout = "01/02/2006"
+
+const (
+	npsMaxEquityPct      = 75
+	npsMaxAlternativePct = 5
+	npsRetirementAge     = 60
+	npsPartialAfterYears = 3
+)
+
+// npsMinContribution is the smallest contribution each tier accepts.
+var npsMinContribution = map[string]float64{model.NPSTierI: 500, model.NPSTierII: 250}
+
+// npsSchemeName picks the asset class and tier out of names such as
+// "SBI PENSION FUND SCHEME E - TIER I".
+var npsSchemeName = regexp.MustCompile(`(?i)SCHEME\s+([ECGA])\s*-\s*TIER\s*(II|I)\b`)
+
+var (
+	ErrNPSAccountNotFound = errors.New("nps account not found")
+	ErrNPSAccountExists   = errors.New("nps tier already tracked for this PRAN")
+	ErrNPSNAVUnavailable  = errors.New("no NPS NAV on or before the contribution date")
+)
+
+type NPSService interface {
+	ImportNAV(ctx context.Context, data []byte) (*model.NPSNAVImportResult, error)
+	ListSchemes(ctx context.Context) ([]model.NPSScheme, error)
+	OpenAccount(ctx context.Context, userID string, req *model.NPSAccountRequest) (*model.Holding, error)
+	Contribute(ctx context.Context, userID, accountID string, req *model.NPSContributionRequest) (*model.Holding, error)
+	ListAccounts(ctx context.Context, userID string) ([]model.Holding, error)
+}
+
+type npsService struct {
+	npsRepo  repository.NPSSchemeRepo
+	navRepo  repository.NAVRepo
+	portRepo repository.PortfolioRepo
+	txnRepo  repository.TransactionRepo
+	tx       repository.Transactor
+}
+
+func NewNPSService(sr repository.NPSSchemeRepo, nr repository.NAVRepo, pr repository.PortfolioRepo, tr repository.TransactionRepo, tx repository.Transactor) NPSService {
+	return &npsService{sr, nr, pr, tr, tx}
+}
+
+// ImportNAV loads an NPS Trust NAV file into NAV history, moves each
+// scheme's current NAV forward and revalues every NPS account. Each
+// portfolio is re-read and its valuation fields written in one transaction;
+// one that fails is logged and counted, and the rest are still revalued.
+func (s *npsService) ImportNAV(ctx context.Context, data []byte) (*model.NPSNAVImportResult, error) {
+	schemes, points, errs := parseNPSNAVFile(data)
+	res := &model.NPSNAVImportResult{Errors: errs}
+	if err := s.navRepo.UpsertMany(ctx, points); err != nil {
+		return nil, err
+	}
+	res.Points = len(points)
+
+	for _, sc := range schemes {
+		cur, err := s.npsRepo.FindByCode(ctx, sc.SchemeCode)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		if cur != nil && cur.NAVDate.After(sc.NAVDate) {
+			continue
+		}
+		if err := s.npsRepo.Upsert(ctx, sc); err != nil {
+			return nil, err
+		}
+		res.Schemes++
+	}
+
+	navs, err := s.currentNAVs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	portfolios, err := s.portRepo.FindByAssetType(ctx, model.AssetNPS)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, p := range portfolios {
+		if err := s.revalueUser(ctx, p.UserID, navs, now); err != nil {
+			log.Printf("nps nav: user %s: %v", p.UserID.Hex(), err)
+			res.PortfoliosFailed++
+			continue
+		}
+		res.PortfoliosRevalued++
+	}
+	return res, nil
+}
+
+func (s *npsService) revalueUser(ctx context.Context, userID bson.ObjectID, navs map[string]model.NPSScheme, now time.Time) error {
+	return s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		p, err := s.portRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil
+			}
+			return err
+		}
+		for j := range p.Holdings {
+			if p.Holdings[j].NPS != nil {
+				revalueNPS(&p.Holdings[j], navs, now)
+			}
+		}
+		recomputeTotals(p)
+		return s.portRepo.UpdateValuation(ctx, p)
+	})
+}
+
+func (s *npsService) ListSchemes(ctx context.Context) ([]model.NPSScheme, error) {
+	schemes, err := s.npsRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if schemes == nil {
+		schemes = []model.NPSScheme{}
+	}
+	return schemes, nil
+}
+
+func (s *npsService) OpenAccount(ctx context.Context, userID string, req *model.NPSAccountRequest) (*model.Holding, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	pran := strings.TrimSpace(req.PRAN)
+	if len(pran) != 12 || (req.Tier != model.NPSTierI && req.Tier != model.NPSTierII) {
+		return nil, ErrInvalidRequest
+	}
+	if _, err := strconv.ParseUint(pran, 10, 64); err != nil {
+		return nil, ErrInvalidRequest
+	}
+
+	// The allocation must name only classes the manager offers in this
+	// tier, add up to 100 and respect the regulatory caps.
+	available, err := s.npsRepo.FindByPFM(ctx, req.PFMCode, req.Tier)
+	if err != nil {
+		return nil, err
+	}
+	byClass := make(map[string]model.NPSScheme)
+	for _, sc := range available {
+		if sc.AssetClass != "" {
+			byClass[sc.AssetClass] = sc
+		}
+	}
+	if len(byClass) == 0 {
+		return nil, ErrSchemeNotFound
+	}
+	total := 0.0
+	for class, pct := range req.Allocation {
+		if _, ok := byClass[class]; !ok || pct < 0 {
+			return nil, ErrInvalidRequest
+		}
+		total += pct
+	}
+	if math.Abs(total-100) > 0.01 || req.Allocation["E"] > npsMaxEquityPct || req.Allocation["A"] > npsMaxAlternativePct {
+		return nil, ErrInvalidRequest
+	}
+
+	acct := &model.NPSAccount{
+		ID:          bson.NewObjectID(),
+		PRAN:        maskAccount(pran),
+		Tier:        req.Tier,
+		PFMCode:     req.PFMCode,
+		PFMName:     available[0].PFMName,
+		OpenedDate:  req.OpenedDate,
+		DateOfBirth: req.DateOfBirth,
+		Schemes:     []model.NPSSchemeUnits{},
+	}
+	if acct.OpenedDate.IsZero() {
+		acct.OpenedDate = time.Now()
+	}
+	for _, class := range []string{"E", "C", "G", "A"} {
+		if pct := req.Allocation[class]; pct > 0 {
+			sc := byClass[class]
+			acct.Schemes = append(acct.Schemes, model.NPSSchemeUnits{
+				AssetClass:    class,
+				SchemeCode:    sc.SchemeCode,
+				SchemeName:    sc.SchemeName,
+				AllocationPct: pct,
+				NAV:           sc.NAV,
+			})
+		}
+	}
+
+	// The duplicate check and the write share a transaction, so two
+	// concurrent requests cannot both add the same tier of a PRAN.
+	var out model.Holding
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		p, err := s.portRepo.FindByUserID(ctx, oid)
+		if err != nil {
+			if !errors.Is(err, mongo.ErrNoDocuments) {
+				return err
+			}
+			p = &model.Portfolio{UserID: oid, Holdings: []model.Holding{}}
+		}
+		for _, h := range p.Holdings {
+			if h.NPS != nil && h.NPS.PRAN == acct.PRAN && h.NPS.Tier == acct.Tier {
+				return ErrNPSAccountExists
+			}
+		}
+		p.Holdings = append(p.Holdings, model.Holding{
+			AssetType:  model.AssetNPS,
+			AssetID:    acct.ID.Hex(),
+			SchemeName: fmt.Sprintf("NPS Tier %s (%s)", acct.Tier, acct.PFMName),
+			NPS:        acct,
+		})
+		h := &p.Holdings[len(p.Holdings)-1]
+		revalueNPS(h, nil, time.Now())
+		recomputeTotals(p)
+		if err := s.portRepo.Upsert(ctx, p); err != nil {
+			return err
+		}
+		out = *h
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Contribute invests a contribution across the account's schemes by their
+// allocation, at each scheme's NAV on the contribution date (or the last
+// one before it), and records one ledger entry per scheme. Every NAV is
+// resolved before anything is written; the ledger entries and the account
+// are then written in one transaction. The client's reference makes the
+// call safe to repeat: a contribution already recorded under it is not
+// invested again.
+func (s *npsService) Contribute(ctx context.Context, userID, accountID string, req *model.NPSContributionRequest) (*model.Holding, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	if req.Reference == "" {
+		return nil, ErrInvalidRequest
+	}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrNPSAccountNotFound
+		}
+		return nil, err
+	}
+	i := findAsset(p, model.AssetNPS, accountID)
+	if i < 0 {
+		return nil, ErrNPSAccountNotFound
+	}
+	acct := p.Holdings[i].NPS
+	if req.Amount < npsMinContribution[acct.Tier] {
+		return nil, ErrInvalidRequest
+	}
+	date := req.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	prices := make(map[string]float64, len(acct.Schemes))
+	for _, su := range acct.Schemes {
+		points, err := s.navRepo.FindRange(ctx, su.SchemeCode, date.AddDate(0, 0, -10), endOfDay(date))
+		if err != nil {
+			return nil, err
+		}
+		if len(points) == 0 {
+			return nil, ErrNPSNAVUnavailable
+		}
+		prices[su.SchemeCode] = points[len(points)-1].NAV
+	}
+	navs, err := s.currentNAVs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref := "nps:" + acct.ID.Hex() + ":" + req.Reference
+	var out model.Holding
+	err = s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		p, err := s.portRepo.FindByUserID(ctx, oid)
+		if err != nil {
+			return err
+		}
+		i := findAsset(p, model.AssetNPS, accountID)
+		if i < 0 {
+			return ErrNPSAccountNotFound
+		}
+		h := &p.Holdings[i]
+		done, err := s.txnRepo.ExistsByReference(ctx, oid, ref)
+		if err != nil {
+			return err
+		}
+		if done {
+			out = *h
+			return nil
+		}
+
+		acct := h.NPS
+		for j := range acct.Schemes {
+			su := &acct.Schemes[j]
+			nav, ok := prices[su.SchemeCode]
+			if !ok {
+				return ErrNPSNAVUnavailable
+			}
+			amount := roundAmount(req.Amount * su.AllocationPct / 100)
+			units := roundNPSUnits(amount / nav)
+			txn := &model.Transaction{
+				UserID:     oid,
+				SchemeCode: su.SchemeCode,
+				SchemeName: su.SchemeName,
+				AMC:        acct.PFMName,
+				Type:       model.TxnNPSContribution,
+				Date:       date,
+				Units:      units,
+				NAV:        nav,
+				Amount:     amount,
+				NetAmount:  amount,
+				Reference:  ref,
+				Note:       "Tier " + acct.Tier,
+			}
+			if err := s.txnRepo.Create(ctx, txn); err != nil {
+				return err
+			}
+			su.Units = roundNPSUnits(su.Units + units)
+		}
+		acct.Contributed = roundAmount(acct.Contributed + req.Amount)
+		revalueNPS(h, navs, time.Now())
+		recomputeTotals(p)
+		if err := s.portRepo.Upsert(ctx, p); err != nil {
+			return err
+		}
+		out = *h
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (s *npsService) ListAccounts(ctx context.Context, userID string) ([]model.Holding, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	out := []model.Holding{}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return out, nil
+		}
+		return nil, err
+	}
+	for _, h := range p.Holdings {
+		if h.NPS != nil {
+			out = append(out, h)
+		}
+	}
+	return out, nil
+}
+
+func (s *npsService) currentNAVs(ctx context.Context) (map[string]model.NPSScheme, error) {
+	schemes, err := s.npsRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	navs := make(map[string]model.NPSScheme, len(schemes))
+	for _, sc := range schemes {
+		navs[sc.SchemeCode] = sc
+	}
+	return navs, nil
+}
+
+// revalueNPS values an account at the given scheme NAVs, keeping the last
+// known NAV for schemes missing from navs, and refreshes its lock-in.
+func revalueNPS(h *model.Holding, navs map[string]model.NPSScheme, now time.Time) {
+	acct := h.NPS
+	value := 0.0
+	for j := range acct.Schemes {
+		su := &acct.Schemes[j]
+		if sc, ok := navs[su.SchemeCode]; ok && sc.NAV > 0 {
+			su.NAV = sc.NAV
+		}
+		su.Value = roundAmount(su.Units * su.NAV)
+		value += su.Value
+	}
+	h.CurrentValue = roundAmount(value)
+	h.InvestedValue = acct.Contributed
+	h.GainLoss = roundAmount(h.CurrentValue - h.InvestedValue)
+	acct.LockIn = npsLockIn(acct, now)
+}
+
+func npsLockIn(acct *model.NPSAccount, now time.Time) model.NPSLockIn {
+	if acct.Tier == model.NPSTierII {
+		return model.NPSLockIn{Note: "Tier II has no lock-in; withdraw any time"}
+	}
+	partial := acct.OpenedDate.AddDate(npsPartialAfterYears, 0, 0)
+	l := model.NPSLockIn{
+		Locked:                true,
+		PartialWithdrawalFrom: &partial,
+		Note:                  "Tier I is locked until age 60; up to 25% of own contributions may be withdrawn for specified purposes after 3 years",
+	}
+	if acct.DateOfBirth != nil {
+		until := acct.DateOfBirth.AddDate(npsRetirementAge, 0, 0)
+		l.LockedUntil = &until
+		if !now.Before(until) {
+			l.Locked = false
+			l.Note = "Eligible for exit: at least 40% of the corpus must buy an annuity"
+		}
+	}
+	return l
+}
+
+// roundNPSUnits rounds to the four decimals NPS allots units in.
+func roundNPSUnits(u float64) float64 { return math.Round(u*10000) / 10000 }
+
+// parseNPSNAVFile reads the NPS Trust daily NAV file: comma-separated rows of
+// date (MM/DD/YYYY), PFM code, PFM name, scheme code, scheme name and NAV.
+// Header lines are skipped. It returns the latest NAV seen per scheme and
+// every row as a history point.
+func parseNPSNAVFile(data []byte) ([]*model.NPSScheme, []model.NAVPoint, []string) {
+	var (
+		points []model.NAVPoint
+		errs   []string
+		order  []string
+	)
+	latest := make(map[string]*model.NPSScheme)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; sc.Scan(); line++ {
+		f := strings.Split(strings.TrimSpace(sc.Text()), ",")
+		if len(f) < 6 {
+			continue
+		}
+		for i := range f {
+			f[i] = strings.TrimSpace(f[i])
+		}
+		date, err := time.Parse(npsDateLayout, f[0])
+		if err != nil {
+			continue // header row
+		}
+		nav, err := strconv.ParseFloat(f[5], 64)
+		if err != nil || nav <= 0 || f[3] == "" {
+			errs = append(errs, fmt.Sprintf("line %d: invalid scheme code or NAV", line))
+			continue
+		}
+		points = append(points, model.NAVPoint{SchemeCode: f[3], Date: date, NAV: nav})
+
+		if cur, ok := latest[f[3]]; ok && !date.After(cur.NAVDate) {
+			continue
+		}
+		s := &model.NPSScheme{SchemeCode: f[3], PFMCode: f[1], PFMName: f[2], SchemeName: f[4], NAV: nav, NAVDate: date}
+		if m := npsSchemeName.FindStringSubmatch(f[4]); m != nil {
+			s.AssetClass, s.Tier = strings.ToUpper(m[1]), strings.ToUpper(m[2])
+		}
+		if _, ok := latest[f[3]]; !ok {
+			order = append(order, f[3])
+		}
+		latest[f[3]] = s
+	}
+	schemes := make([]*model.NPSScheme, 0, len(order))
+	for _, code := range order {
+		schemes = append(schemes, latest[code])
+	}
+	return schemes, points, errs
+}
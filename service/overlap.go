@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// Concentration thresholds, in percent.
+const (
+	pairOverlapWarnPct = 50
+	stockWarnPct       = 10
+	sectorWarnPct      = 35
+)
+
+func (s *wealthService) GetPortfolioOverlap(ctx context.Context, userID string) (*model.PortfolioOverlap, error) {
+	portfolio, err := s.GetPortfolio(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	type analysed struct {
+		holding model.Holding
+		sheet   *model.Factsheet
+	}
+	var (
+		funds []analysed
+		total float64
+	)
+	res := &model.PortfolioOverlap{
+		Unanalysed:     []string{},
+		Pairs:          []model.SchemeOverlap{},
+		StockExposure:  []model.Exposure{},
+		SectorExposure: []model.Exposure{},
+		Warnings:       []model.Warning{},
+	}
+	for _, h := range portfolio.Holdings {
+		if !isFund(&h) || h.SchemeCode == "" || h.CurrentValue <= 0 {
+			continue
+		}
+		fs, err := s.factRepo.FindLatest(ctx, h.SchemeCode)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		if fs == nil || len(fs.TopHoldings) == 0 {
+			res.Unanalysed = append(res.Unanalysed, h.SchemeCode)
+			continue
+		}
+		funds = append(funds, analysed{h, fs})
+		total += h.CurrentValue
+	}
+	res.AnalysedValue = roundAmount(total)
+	if total == 0 {
+		return res, nil
+	}
+
+	for i := 0; i < len(funds); i++ {
+		for j := i + 1; j < len(funds); j++ {
+			pct, common := overlapBetween(funds[i].sheet.TopHoldings, funds[j].sheet.TopHoldings)
+			res.Pairs = append(res.Pairs, model.SchemeOverlap{
+				SchemeA:      funds[i].holding.SchemeCode,
+				SchemeB:      funds[j].holding.SchemeCode,
+				OverlapPct:   roundAmount(pct),
+				CommonStocks: common,
+			})
+		}
+	}
+	sort.SliceStable(res.Pairs, func(i, j int) bool { return res.Pairs[i].OverlapPct > res.Pairs[j].OverlapPct })
+
+	stocks := make(map[string]*model.Exposure)
+	sectors := make(map[string]*model.Exposure)
+	add := func(m map[string]*model.Exposure, key, name, isin string, share float64) {
+		e, ok := m[key]
+		if !ok {
+			e = &model.Exposure{Name: name, ISIN: isin}
+			m[key] = e
+		}
+		e.Weight += share * 100
+		e.Value += share * total
+	}
+	for _, f := range funds {
+		w := f.holding.CurrentValue / total
+		for _, st := range f.sheet.TopHoldings {
+			add(stocks, stockKey(st), st.Name, st.ISIN, w*st.Weight/100)
+		}
+		alloc := f.sheet.SectorAllocation
+		if len(alloc) == 0 {
+			alloc = make(map[string]float64)
+			for _, st := range f.sheet.TopHoldings {
+				alloc[st.Sector] += st.Weight
+			}
+		}
+		for sector, pct := range alloc {
+			add(sectors, sector, sector, "", w*pct/100)
+		}
+	}
+	res.StockExposure = sortedExposures(stocks)
+	res.SectorExposure = sortedExposures(sectors)
+
+	for _, p := range res.Pairs {
+		if p.OverlapPct >= pairOverlapWarnPct {
+			res.Warnings = append(res.Warnings, model.Warning{
+				Type:    "scheme_overlap",
+				Subject: p.SchemeA + "/" + p.SchemeB,
+				Value:   p.OverlapPct,
+				Message: fmt.Sprintf("%s and %s share %.0f%% of their portfolios", p.SchemeA, p.SchemeB, p.OverlapPct),
+			})
+		}
+	}
+	for _, e := range res.StockExposure {
+		if e.Weight >= stockWarnPct {
+			res.Warnings = append(res.Warnings, model.Warning{
+				Type:    "stock_concentration",
+				Subject: e.Name,
+				Value:   e.Weight,
+				Message: fmt.Sprintf("%.1f%% of your fund investments is in %s", e.Weight, e.Name),
+			})
+		}
+	}
+	for _, e := range res.SectorExposure {
+		if e.Weight >= sectorWarnPct {
+			res.Warnings = append(res.Warnings, model.Warning{
+				Type:    "sector_concentration",
+				Subject: e.Name,
+				Value:   e.Weight,
+				Message: fmt.Sprintf("%.1f%% of your fund investments is in the %s sector", e.Weight, e.Name),
+			})
+		}
+	}
+	return res, nil
+}
+
+// overlapBetween returns the common-weight overlap of two portfolios and the
+// names of the shared stocks, heaviest shared weight first.
+func overlapBetween(a, b []model.FactsheetHolding) (float64, []string) {
+	weights := make(map[string]float64, len(a))
+	for _, h := range a {
+		weights[stockKey(h)] += h.Weight
+	}
+	type shared struct {
+		name string
+		w    float64
+	}
+	var (
+		common []shared
+		total  float64
+	)
+	for _, h := range b {
+		wa, ok := weights[stockKey(h)]
+		if !ok {
+			continue
+		}
+		w := min(wa, h.Weight)
+		total += w
+		common = append(common, shared{h.Name, w})
+	}
+	sort.SliceStable(common, func(i, j int) bool { return common[i].w > common[j].w })
+	names := make([]string, len(common))
+	for i, c := range common {
+		names[i] = c.name
+	}
+	return total, names
+}
+
+// stockKey identifies a stock across factsheets, by ISIN where the AMC
+// discloses one.
+func stockKey(h model.FactsheetHolding) string {
+	if h.ISIN != "" {
+		return h.ISIN
+	}
+	return h.Name
+}
+
+func sortedExposures(m map[string]*model.Exposure) []model.Exposure {
+	out := make([]model.Exposure, 0, len(m))
+	for _, e := range m {
+		e.Weight = roundAmount(e.Weight)
+		e.Value = roundAmount(e.Value)
+		out = append(out, *e)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Weight != out[j].Weight {
+			return out[i].Weight > out[j].Weight
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
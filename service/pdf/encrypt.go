@@ -0,0 +1,130 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rc4"
+	"encoding/binary"
+	"fmt"
+)
+
+// permissions allows printing only (PDF 1.7, table 22: bits 3 and 12).
+const permissions int32 = -3904 | 1<<2 | 1<<11
+
+// passwordPad is the padding string of the standard security handler.
+var passwordPad = []byte{
+	0x28, 0xbf, 0x4e, 0x5e, 0x4e, 0x75, 0x8a, 0x41, 0x64, 0x00, 0x4e, 0x56, 0xff, 0xfa, 0x01, 0x08,
+	0x2e, 0x2e, 0x00, 0xb6, 0xd0, 0x68, 0x3e, 0x80, 0x2f, 0x0c, 0xa9, 0xfe, 0x64, 0x53, 0x69, 0x7a,
+}
+
+// encryption is the standard security handler, revision 4, with AES-128
+// (AESV2) crypt filters. The owner password is random, so the document
+// opens only with the user password and its permissions cannot be lifted.
+type encryption struct {
+	key  []byte
+	o, u []byte
+	id   []byte
+}
+
+func newEncryption(userPassword string) (*encryption, error) {
+	id := make([]byte, 16)
+	owner := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(owner); err != nil {
+		return nil, err
+	}
+	return deriveEncryption(userPassword, owner, id)
+}
+
+// deriveEncryption computes the O and U entries and the file key from the
+// passwords and the document ID.
+func deriveEncryption(userPassword string, owner, id []byte) (*encryption, error) {
+	e := &encryption{id: id}
+	user := pad([]byte(userPassword))
+
+	// Algorithm 3: the O entry.
+	h := md5.Sum(pad(owner))
+	for i := 0; i < 50; i++ {
+		h = md5.Sum(h[:])
+	}
+	o, err := rc4Rounds(h[:], user)
+	if err != nil {
+		return nil, err
+	}
+	e.o = o
+
+	// Algorithm 2: the file key.
+	var p [4]byte
+	perm := permissions
+	binary.LittleEndian.PutUint32(p[:], uint32(perm))
+	h = md5.Sum(bytes.Join([][]byte{user, e.o, p[:], e.id}, nil))
+	for i := 0; i < 50; i++ {
+		h = md5.Sum(h[:])
+	}
+	e.key = append([]byte{}, h[:]...)
+
+	// Algorithm 5: the U entry.
+	h = md5.Sum(append(append([]byte{}, passwordPad...), e.id...))
+	u, err := rc4Rounds(e.key, h[:])
+	if err != nil {
+		return nil, err
+	}
+	e.u = append(u, make([]byte, 16)...)
+	return e, nil
+}
+
+func (e *encryption) dictionary() string {
+	return fmt.Sprintf("<< /Filter /Standard /V 4 /R 4 /Length 128 "+
+		"/CF << /StdCF << /CFM /AESV2 /AuthEvent /DocOpen /Length 16 >> >> /StmF /StdCF /StrF /StdCF "+
+		"/O <%x> /U <%x> /P %d >>", e.o, e.u, permissions)
+}
+
+// encrypt applies algorithm 1 with AES: a per-object key, a random IV
+// prepended to the CBC ciphertext and PKCS#5 padding.
+func (e *encryption) encrypt(num int, data []byte) ([]byte, error) {
+	salt := []byte{byte(num), byte(num >> 8), byte(num >> 16), 0, 0, 's', 'A', 'l', 'T'}
+	key := md5.Sum(append(append([]byte{}, e.key...), salt...))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	n := aes.BlockSize - len(data)%aes.BlockSize
+	plain := append(append([]byte{}, data...), bytes.Repeat([]byte{byte(n)}, n)...)
+	out := make([]byte, aes.BlockSize+len(plain))
+	if _, err := rand.Read(out[:aes.BlockSize]); err != nil {
+		return nil, err
+	}
+	cipher.NewCBCEncrypter(block, out[:aes.BlockSize]).CryptBlocks(out[aes.BlockSize:], plain)
+	return out, nil
+}
+
+// pad truncates or pads a password to 32 bytes.
+func pad(pw []byte) []byte {
+	if len(pw) > 32 {
+		pw = pw[:32]
+	}
+	return append(append([]byte{}, pw...), passwordPad[:32-len(pw)]...)
+}
+
+// rc4Rounds encrypts data with key, then 19 more times with the key's
+// bytes XORed with the round number.
+func rc4Rounds(key, data []byte) ([]byte, error) {
+	out := append([]byte{}, data...)
+	k := make([]byte, len(key))
+	for i := 0; i < 20; i++ {
+		for j := range key {
+			k[j] = key[j] ^ byte(i)
+		}
+		c, err := rc4.NewCipher(k)
+		if err != nil {
+			return nil, err
+		}
+		c.XORKeyStream(out, out)
+	}
+	return out, nil
+}
@@ -0,0 +1,77 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"testing"
+)
+
+// The expected values were computed from ISO 32000-1 algorithms 2, 3 and 5
+// with an independent MD5 and RC4 implementation.
+const (
+	vectorPassword = "ABCDE01011990"
+	vectorO        = "601664d76194d8b3e97220d4bfc4c9e50493f321096e58f9be7538e49cc1f5cd"
+	vectorU        = "8f67ec681b200acb00fe81e9d20a1c4a"
+	vectorKey      = "5a660eb351fefe08cfc877788e424ed1"
+	vectorObject7  = "343270e2a72710ca31485d89baa3c3f6" // algorithm 1 key for object 7, generation 0
+)
+
+func vectorEncryption(t *testing.T) *encryption {
+	t.Helper()
+	owner := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+	id := []byte{16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}
+	e, err := deriveEncryption(vectorPassword, owner, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestEncryptionEntries(t *testing.T) {
+	e := vectorEncryption(t)
+	if permissions != -1852 {
+		t.Errorf("P = %d, want -1852", permissions)
+	}
+	if got := hex.EncodeToString(e.o); got != vectorO {
+		t.Errorf("O = %s, want %s", got, vectorO)
+	}
+	// Only the first 16 bytes of U are significant in revision 4.
+	if len(e.u) != 32 {
+		t.Fatalf("U is %d bytes, want 32", len(e.u))
+	}
+	if got := hex.EncodeToString(e.u[:16]); got != vectorU {
+		t.Errorf("U = %s, want %s", got, vectorU)
+	}
+	if got := hex.EncodeToString(e.key); got != vectorKey {
+		t.Errorf("file key = %s, want %s", got, vectorKey)
+	}
+}
+
+func TestEncryptDecryptsWithObjectKey(t *testing.T) {
+	e := vectorEncryption(t)
+	plain := []byte("BT /F1 10 Tf 50 780 Td (Consolidated statement) Tj ET")
+	out, err := e.encrypt(7, plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) < 2*aes.BlockSize || len(out)%aes.BlockSize != 0 {
+		t.Fatalf("ciphertext is %d bytes", len(out))
+	}
+
+	key, _ := hex.DecodeString(vectorObject7)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make([]byte, len(out)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, out[:aes.BlockSize]).CryptBlocks(got, out[aes.BlockSize:])
+	n := int(got[len(got)-1])
+	if n < 1 || n > aes.BlockSize || !bytes.Equal(got[len(got)-n:], bytes.Repeat([]byte{byte(n)}, n)) {
+		t.Fatalf("bad padding % x", got[len(got)-aes.BlockSize:])
+	}
+	if got = got[:len(got)-n]; !bytes.Equal(got, plain) {
+		t.Errorf("decrypted %q, want %q", got, plain)
+	}
+}
@@ -0,0 +1,302 @@
+// Package pdf writes simple tabular documents as PDF using only the standard
+// library: A4 landscape pages of headings, text lines and fixed-pitch
+// tables in the PDF base fonts, optionally encrypted with a user password.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+)
+
+const (
+	pageWidth  = 842.0 // A4 landscape, in points
+	pageHeight = 595.0
+	margin     = 36.0
+	leading    = 11.0
+	tableSize  = 8.0
+	textSize   = 9.0
+	headSize   = 12.0
+	titleSize  = 16.0
+	// Courier glyphs are 600/1000 em wide, so tables align by character.
+	charWidth = tableSize * 0.6
+)
+
+// MaxChars is the number of table characters that fit across a page:
+// (pageWidth - 2*margin) / charWidth, rounded down.
+const MaxChars = 160
+
+// Font resource names, in the order the font objects are written.
+var fonts = []struct{ name, base string }{
+	{"F1", "Helvetica"},
+	{"F2", "Helvetica-Bold"},
+	{"F3", "Courier"},
+	{"F4", "Courier-Bold"},
+}
+
+// Column is one table column, Width characters wide.
+type Column struct {
+	Title string
+	Width int
+	Right bool // right-align, for numbers
+}
+
+// Document accumulates pages. Content is laid out top to bottom and new
+// pages are started as needed; tables repeat their header on each page.
+type Document struct {
+	title string
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func New(title string) *Document {
+	d := &Document{title: title}
+	d.newPage()
+	return d
+}
+
+func (d *Document) page() *bytes.Buffer { return d.pages[len(d.pages)-1] }
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pageHeight - margin
+}
+
+// ensure starts a new page unless height points remain above the footer.
+func (d *Document) ensure(height float64) bool {
+	if d.y-height < margin+leading {
+		d.newPage()
+		return true
+	}
+	return false
+}
+
+func (d *Document) line(font string, size, x float64, text string) {
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, d.y, escape(text))
+}
+
+// Title writes the document title in large type.
+func (d *Document) Title(text string) {
+	d.ensure(titleSize + leading)
+	d.y -= titleSize
+	d.line("F2", titleSize, margin, text)
+	d.y -= leading
+}
+
+// Heading starts a section.
+func (d *Document) Heading(text string) {
+	d.ensure(headSize + 3*leading)
+	d.y -= leading
+	d.line("F2", headSize, margin, text)
+	d.y -= leading + 2
+}
+
+// Text writes a line of body text.
+func (d *Document) Text(text string) {
+	d.ensure(leading)
+	d.line("F1", textSize, margin, text)
+	d.y -= leading
+}
+
+// Table writes rows under a header row; cells are cut to their column's
+// width.
+func (d *Document) Table(cols []Column, rows [][]string) {
+	header := make([]string, len(cols))
+	for i, c := range cols {
+		header[i] = c.Title
+	}
+	d.ensure(3 * leading)
+	d.tableHeader(cols, header)
+	for _, row := range rows {
+		if d.ensure(leading) {
+			d.tableHeader(cols, header)
+		}
+		d.line("F3", tableSize, margin, formatRow(cols, row))
+		d.y -= leading
+	}
+}
+
+func (d *Document) tableHeader(cols []Column, header []string) {
+	d.line("F4", tableSize, margin, formatRow(cols, header))
+	fmt.Fprintf(d.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", margin, d.y-3, pageWidth-margin, d.y-3)
+	d.y -= leading + 2
+}
+
+func formatRow(cols []Column, cells []string) string {
+	var sb strings.Builder
+	for i, c := range cols {
+		cell := ""
+		if i < len(cells) {
+			cell = cells[i]
+		}
+		r := []rune(cell)
+		if len(r) > c.Width {
+			r = r[:c.Width]
+		}
+		pad := strings.Repeat(" ", c.Width-len(r))
+		if c.Right {
+			sb.WriteString(pad + string(r))
+		} else {
+			sb.WriteString(string(r) + pad)
+		}
+		if i < len(cols)-1 {
+			sb.WriteString("  ")
+		}
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// escape encodes text for a PDF literal string in WinAnsiEncoding. The
+// rupee sign has no WinAnsi glyph and is written as "Rs."; other characters
+// outside the encoding become "?".
+func escape(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '₹':
+			sb.WriteString("Rs.")
+		case r >= 0x20 && r < 0x7f:
+			sb.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&sb, "\\%03o", r)
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// Bytes renders the document. A non-empty password encrypts it (AES-128)
+// so that the password is needed to open it.
+func (d *Document) Bytes(password string) ([]byte, error) {
+	w := &writer{}
+	var err error
+	if password != "" {
+		if w.enc, err = newEncryption(password); err != nil {
+			return nil, err
+		}
+	}
+	w.buf.WriteString("%PDF-1.6\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-2 are the catalog and page tree, then the fonts, the info
+	// dictionary and each page with its content stream.
+	fontObj := 3
+	infoObj := fontObj + len(fonts)
+	firstPage := infoObj + 1
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	w.object(1, "<< /Type /Catalog /Pages 2 0 R >>")
+	w.object(2, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	var res strings.Builder
+	for i, f := range fonts {
+		w.object(fontObj+i, fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", f.base))
+		fmt.Fprintf(&res, "/%s %d 0 R ", f.name, fontObj+i)
+	}
+	title, err := w.string(infoObj, d.title)
+	if err != nil {
+		return nil, err
+	}
+	w.object(infoObj, fmt.Sprintf("<< /Title %s /Producer (wealth-service) >>", title))
+	for i, p := range d.pages {
+		pageObj := firstPage + 2*i
+		w.object(pageObj, fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << %s>> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, res.String(), pageObj+1))
+		content := append([]byte{}, p.Bytes()...)
+		content = fmt.Appendf(content, "BT /F1 %.1f Tf %.2f %.2f Td (Page %d of %d) Tj ET\n",
+			tableSize, pageWidth-margin-60, margin-leading, i+1, len(d.pages))
+		if err := w.stream(pageObj+1, content); err != nil {
+			return nil, err
+		}
+	}
+	trailer := fmt.Sprintf("/Root 1 0 R /Info %d 0 R", infoObj)
+	if w.enc != nil {
+		encObj := firstPage + 2*len(d.pages)
+		w.object(encObj, w.enc.dictionary())
+		trailer += fmt.Sprintf(" /Encrypt %d 0 R /ID [<%x> <%x>]", encObj, w.enc.id, w.enc.id)
+	}
+	w.finish(trailer)
+	return w.buf.Bytes(), nil
+}
+
+// writer lays out numbered objects and the cross-reference table.
+type writer struct {
+	buf     bytes.Buffer
+	offsets map[int]int
+	enc     *encryption
+}
+
+func (w *writer) object(num int, body string) {
+	if w.offsets == nil {
+		w.offsets = map[int]int{}
+	}
+	w.offsets[num] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", num, body)
+}
+
+// stream writes a Flate-compressed stream object, encrypted when the
+// document is.
+func (w *writer) stream(num int, data []byte) error {
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	body := z.Bytes()
+	if w.enc != nil {
+		var err error
+		if body, err = w.enc.encrypt(num, body); err != nil {
+			return err
+		}
+	}
+	if w.offsets == nil {
+		w.offsets = map[int]int{}
+	}
+	w.offsets[num] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< /Length %d /Filter /FlateDecode >>\nstream\n", num, len(body))
+	w.buf.Write(body)
+	w.buf.WriteString("\nendstream\nendobj\n")
+	return nil
+}
+
+// string encodes a text string belonging to object num.
+func (w *writer) string(num int, s string) (string, error) {
+	if w.enc == nil {
+		return "(" + escape(s) + ")", nil
+	}
+	var raw bytes.Buffer
+	for _, r := range s {
+		if r < 0x100 {
+			raw.WriteByte(byte(r))
+		} else {
+			raw.WriteByte('?')
+		}
+	}
+	b, err := w.enc.encrypt(num, raw.Bytes())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("<%x>", b), nil
+}
+
+func (w *writer) finish(trailer string) {
+	n := 0
+	for num := range w.offsets {
+		n = max(n, num)
+	}
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", n+1)
+	for num := 1; num <= n; num++ {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", w.offsets[num])
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d %s >>\nstartxref\n%d\n%%%%EOF\n", n+1, trailer, xref)
+}
@@ -0,0 +1,417 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"github.com/banking-superapp/wealth-service/service/finmath"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	defaultSimulations = 1000
+	maxSimulations     = 10000
+	defaultSeed        = 42
+	minBootstrapMonths = 24
+)
+
+// volatilities are annualised category volatility assumptions, %.
+var volatilities = map[string]float64{
+	"equity": 18,
+	"hybrid": 10,
+	"debt":   3,
+	"liquid": 1,
+	"gold":   14,
+}
+
+// correlations between category returns; unlisted pairs are uncorrelated.
+var correlations = map[[2]string]float64{
+	{"equity", "hybrid"}: 0.85,
+	{"equity", "debt"}:   0.1,
+	{"hybrid", "debt"}:   0.3,
+	{"debt", "liquid"}:   0.5,
+	{"equity", "gold"}:   -0.05,
+}
+
+var ErrNothingToProject = errors.New("no investments to project")
+
+type MonteCarloResult struct {
+	Method      string             `json:"method"`
+	Seed        int64              `json:"seed"`
+	Simulations int                `json:"simulations"`
+	Months      int                `json:"months"`
+	StartValue  float64            `json:"start_value"`
+	MonthlySIP  float64            `json:"monthly_sip"`
+	Allocation  map[string]float64 `json:"allocation"` // category -> starting value
+	Target      float64            `json:"target,omitempty"`
+	finmath.SimulationResult
+}
+
+type ProjectionService interface {
+	MonteCarlo(ctx context.Context, userID string, req *model.MonteCarloRequest) (*MonteCarloResult, error)
+}
+
+type projectionService struct {
+	portRepo repository.PortfolioRepo
+	sipRepo  repository.SIPRepo
+	mfRepo   repository.MFSchemeRepo
+	goalRepo repository.GoalRepo
+	navRepo  repository.NAVRepo
+}
+
+func NewProjectionService(pr repository.PortfolioRepo, sr repository.SIPRepo, mr repository.MFSchemeRepo, gr repository.GoalRepo, nr repository.NAVRepo) ProjectionService {
+	return &projectionService{pr, sr, mr, gr, nr}
+}
+
+// exposure is what is invested in one category: current value, monthly SIP
+// and the schemes behind them (for bootstrapping).
+type exposure struct {
+	value, monthly float64
+	schemes        map[string]bool
+}
+
+func (s *projectionService) MonteCarlo(ctx context.Context, userID string, req *model.MonteCarloRequest) (*MonteCarloResult, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	sims := req.Simulations
+	if sims == 0 {
+		sims = defaultSimulations
+	}
+	seed := int64(defaultSeed)
+	if req.Seed != nil {
+		seed = *req.Seed
+	}
+	method := req.Method
+	if method == "" {
+		method = "assumptions"
+	}
+	if sims < 1 || sims > maxSimulations || (method != "assumptions" && method != "bootstrap") {
+		return nil, ErrInvalidRequest
+	}
+
+	months := req.Years * 12
+	var (
+		target       float64
+		holdingShare map[string]float64     // nil: every holding in full
+		sipFilter    map[bson.ObjectID]bool // nil: every active SIP
+	)
+	if req.GoalID != "" {
+		gid, err := bson.ObjectIDFromHex(req.GoalID)
+		if err != nil {
+			return nil, ErrGoalNotFound
+		}
+		g, err := s.goalRepo.FindByID(ctx, oid, gid)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, ErrGoalNotFound
+			}
+			return nil, err
+		}
+		months = monthsUntil(time.Now(), g.TargetDate)
+		target = finmath.FutureValue(g.TargetAmount, g.InflationPct, months)
+		// A goal without links is projected on the whole portfolio.
+		if len(g.LinkedHoldings) > 0 || len(g.LinkedSIPs) > 0 {
+			holdingShare = make(map[string]float64, len(g.LinkedHoldings))
+			for _, l := range g.LinkedHoldings {
+				holdingShare[l.SchemeCode] = l.AllocationPct / 100
+			}
+			sipFilter = make(map[bson.ObjectID]bool, len(g.LinkedSIPs))
+			for _, id := range g.LinkedSIPs {
+				sipFilter[id] = true
+			}
+		}
+	}
+	if months < 1 || months > maxCalculatorYears*12 {
+		return nil, ErrInvalidRequest
+	}
+
+	exposures, err := s.exposures(ctx, oid, holdingShare, sipFilter)
+	if err != nil {
+		return nil, err
+	}
+	classes := make([]string, 0, len(exposures))
+	for c := range exposures {
+		classes = append(classes, c)
+	}
+	if len(classes) == 0 {
+		return nil, ErrNothingToProject
+	}
+	sort.Strings(classes)
+
+	var sampler finmath.ReturnSampler
+	if method == "bootstrap" {
+		if sampler, err = s.bootstrapSampler(ctx, classes, exposures, req.Assumptions); err != nil {
+			return nil, err
+		}
+	} else {
+		if sampler, err = assumptionSampler(classes, req.Assumptions); err != nil {
+			return nil, err
+		}
+	}
+
+	in := finmath.SimulationInput{
+		Values:      make([]float64, len(classes)),
+		MonthlySIPs: make([]float64, len(classes)),
+		Months:      months,
+		Simulations: sims,
+		Seed:        seed,
+		Target:      target,
+	}
+	res := &MonteCarloResult{
+		Method:      method,
+		Seed:        seed,
+		Simulations: sims,
+		Months:      months,
+		Allocation:  make(map[string]float64, len(classes)),
+		Target:      roundAmount(target),
+	}
+	for i, c := range classes {
+		e := exposures[c]
+		in.Values[i], in.MonthlySIPs[i] = e.value, e.monthly
+		res.StartValue += e.value
+		res.MonthlySIP += e.monthly
+		res.Allocation[c] = roundAmount(e.value)
+	}
+	res.StartValue = roundAmount(res.StartValue)
+	res.MonthlySIP = roundAmount(res.MonthlySIP)
+	res.SimulationResult = finmath.Simulate(in, sampler)
+	return res, nil
+}
+
+// exposures groups holdings and active SIPs by category, restricted to the
+// given holding shares and SIPs when those are non-nil.
+func (s *projectionService) exposures(ctx context.Context, userID bson.ObjectID, holdingShare map[string]float64, sipFilter map[bson.ObjectID]bool) (map[string]*exposure, error) {
+	out := make(map[string]*exposure)
+	addClass := func(c, code string, value, monthly float64) {
+		e, ok := out[c]
+		if !ok {
+			e = &exposure{schemes: map[string]bool{}}
+			out[c] = e
+		}
+		e.value += value
+		e.monthly += monthly
+		if code != "" {
+			e.schemes[code] = true
+		}
+	}
+	add := func(code string, value, monthly float64) error {
+		sc, err := s.mfRepo.FindByCode(ctx, code)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		c := "debt"
+		if sc != nil && sc.Category != "" {
+			c = sc.Category
+		}
+		addClass(c, code, value, monthly)
+		return nil
+	}
+
+	portfolio, err := s.portRepo.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if portfolio != nil {
+		revalueDeposits(portfolio, time.Now())
+		for _, h := range portfolio.Holdings {
+			// Other assets have no NAV history and join their class at
+			// its assumed return; goal projections only cover linked funds.
+			if !isFund(&h) {
+				if holdingShare == nil && h.CurrentValue > 0 {
+					for c, v := range classValues(&h) {
+						addClass(c, "", v, 0)
+					}
+				}
+				continue
+			}
+			share := 1.0
+			if holdingShare != nil {
+				var ok bool
+				if share, ok = holdingShare[h.SchemeCode]; !ok {
+					continue
+				}
+			}
+			if h.SchemeCode == "" || h.CurrentValue <= 0 {
+				continue
+			}
+			if err := add(h.SchemeCode, h.CurrentValue*share, 0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	sips, err := s.sipRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sp := range sips {
+		if sp.Status != "active" || (sipFilter != nil && !sipFilter[sp.ID]) {
+			continue
+		}
+		if err := add(sp.SchemeCode, 0, monthlyEquivalent(sp.Amount, sp.Frequency)); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func assumptionSampler(classes []string, overrides map[string]model.CategoryAssumption) (finmath.ReturnSampler, error) {
+	n := len(classes)
+	rets := make([]float64, n)
+	vols := make([]float64, n)
+	corr := make([][]float64, n)
+	for i, c := range classes {
+		rets[i] = expectedReturn(c)
+		vols[i] = volatilities["debt"]
+		if v, ok := volatilities[c]; ok {
+			vols[i] = v
+		}
+		if o, ok := overrides[c]; ok {
+			if o.VolatilityPct < 0 || !validRate(o.ReturnPct) {
+				return nil, ErrInvalidRequest
+			}
+			rets[i], vols[i] = o.ReturnPct, o.VolatilityPct
+		}
+		corr[i] = make([]float64, n)
+		for j, d := range classes {
+			switch v, ok := correlations[[2]string{c, d}]; {
+			case i == j:
+				corr[i][j] = 1
+			case ok:
+				corr[i][j] = v
+			default:
+				corr[i][j] = correlations[[2]string{d, c}]
+			}
+		}
+	}
+	return finmath.NewNormalSampler(rets, vols, corr)
+}
+
+// bootstrapSampler resamples NAV history for the classes with funds behind
+// them. Classes with none (deposits, gold, shares) have no history and grow
+// at their assumed return instead.
+func (s *projectionService) bootstrapSampler(ctx context.Context, classes []string, exposures map[string]*exposure, overrides map[string]model.CategoryAssumption) (finmath.ReturnSampler, error) {
+	var histClasses []string
+	sampler := &mixedSampler{fixed: make([]float64, len(classes))}
+	for i, c := range classes {
+		if len(exposures[c].schemes) > 0 {
+			histClasses = append(histClasses, c)
+			sampler.cols = append(sampler.cols, i)
+			continue
+		}
+		r := expectedReturn(c)
+		if o, ok := overrides[c]; ok {
+			if !validRate(o.ReturnPct) {
+				return nil, ErrInvalidRequest
+			}
+			r = o.ReturnPct
+		}
+		sampler.fixed[i] = math.Pow(1+r/100, 1.0/12) - 1
+	}
+	if len(histClasses) > 0 {
+		hist, err := s.historicalMonths(ctx, histClasses, exposures)
+		if err != nil {
+			return nil, err
+		}
+		sampler.hist = finmath.NewBootstrapSampler(hist)
+		sampler.row = make([]float64, len(histClasses))
+	}
+	return sampler, nil
+}
+
+// mixedSampler fills the classes in cols from a historical sampler and gives
+// every other class its fixed monthly return.
+type mixedSampler struct {
+	hist  finmath.ReturnSampler // nil when no class has history
+	cols  []int
+	fixed []float64
+	row   []float64
+}
+
+func (s *mixedSampler) Sample(rng *rand.Rand, out []float64) {
+	copy(out, s.fixed)
+	if s.hist == nil {
+		return
+	}
+	s.hist.Sample(rng, s.row)
+	for k, i := range s.cols {
+		out[i] = s.row[k]
+	}
+}
+
+// historicalMonths builds joint monthly return vectors, one per calendar
+// month in which every category has NAV history. A category's return is the
+// equal-weighted average of its schemes' month-end to month-end returns.
+func (s *projectionService) historicalMonths(ctx context.Context, classes []string, exposures map[string]*exposure) ([][]float64, error) {
+	perClass := make([]map[int]float64, len(classes))
+	for i, c := range classes {
+		sums := make(map[int]float64)
+		counts := make(map[int]int)
+		for code := range exposures[c].schemes {
+			points, err := s.navRepo.FindRange(ctx, code, time.Time{}, time.Time{})
+			if err != nil {
+				return nil, err
+			}
+			for month, r := range monthlyReturns(points) {
+				sums[month] += r
+				counts[month]++
+			}
+		}
+		perClass[i] = make(map[int]float64, len(sums))
+		for month, sum := range sums {
+			perClass[i][month] = sum / float64(counts[month])
+		}
+	}
+
+	var months []int
+	for month := range perClass[0] {
+		inAll := true
+		for _, m := range perClass[1:] {
+			if _, ok := m[month]; !ok {
+				inAll = false
+				break
+			}
+		}
+		if inAll {
+			months = append(months, month)
+		}
+	}
+	if len(months) < minBootstrapMonths {
+		return nil, ErrInsufficientHistory
+	}
+	sort.Ints(months)
+	out := make([][]float64, len(months))
+	for k, month := range months {
+		row := make([]float64, len(classes))
+		for i := range classes {
+			row[i] = perClass[i][month]
+		}
+		out[k] = row
+	}
+	return out, nil
+}
+
+// monthlyReturns maps year*12+month to the return from the previous
+// month-end NAV, for consecutive months only.
+func monthlyReturns(points []model.NAVPoint) map[int]float64 {
+	monthEnd := make(map[int]float64)
+	for _, p := range points {
+		monthEnd[p.Date.Year()*12+int(p.Date.Month())-1] = p.NAV // points are date-ordered
+	}
+	out := make(map[int]float64, len(monthEnd))
+	for month, nav := range monthEnd {
+		if prev, ok := monthEnd[month-1]; ok && prev > 0 {
+			out[month] = nav/prev - 1
+		}
+	}
+	return out
+}
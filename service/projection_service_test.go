@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// The fakes embed the repository interfaces so that only the methods a
+// projection calls need implementing.
+type fakePortfolioRepo struct {
+	repository.PortfolioRepo
+	p *model.Portfolio
+}
+
+func (r fakePortfolioRepo) FindByUserID(context.Context, bson.ObjectID) (*model.Portfolio, error) {
+	return r.p, nil
+}
+
+type fakeSIPRepo struct{ repository.SIPRepo }
+
+func (fakeSIPRepo) FindByUserID(context.Context, bson.ObjectID) ([]model.SIP, error) {
+	return nil, nil
+}
+
+type fakeMFSchemeRepo struct {
+	repository.MFSchemeRepo
+	schemes map[string]*model.MFScheme
+}
+
+func (r fakeMFSchemeRepo) FindByCode(_ context.Context, code string) (*model.MFScheme, error) {
+	if sc, ok := r.schemes[code]; ok {
+		return sc, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+type fakeNAVRepo struct {
+	repository.NAVRepo
+	points map[string][]model.NAVPoint
+}
+
+func (r fakeNAVRepo) FindRange(_ context.Context, code string, _, _ time.Time) ([]model.NAVPoint, error) {
+	return r.points[code], nil
+}
+
+// flatNAVs is month-end history with no growth, so bootstrapped returns are
+// all zero.
+func flatNAVs(code string, months int) []model.NAVPoint {
+	points := make([]model.NAVPoint, months)
+	for i := range points {
+		points[i] = model.NAVPoint{SchemeCode: code, Date: time.Date(2020, time.Month(i+2), 0, 0, 0, 0, 0, time.UTC), NAV: 100}
+	}
+	return points
+}
+
+func TestMonteCarloBootstrapWithDeposit(t *testing.T) {
+	fd := &model.FixedDeposit{
+		Principal:    100000,
+		RatePct:      7,
+		Compounding:  "quarterly",
+		Payout:       model.FDPayoutCumulative,
+		StartDate:    time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		MaturityDate: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	portfolio := &model.Portfolio{Holdings: []model.Holding{
+		{AssetType: model.AssetFixedDeposit, AssetID: "fd1", Category: "debt", FD: fd},
+		{SchemeCode: "EQ1", Units: 500, CurrentNAV: 100, CurrentValue: 50000},
+	}}
+	svc := NewProjectionService(
+		fakePortfolioRepo{p: portfolio},
+		fakeSIPRepo{},
+		fakeMFSchemeRepo{schemes: map[string]*model.MFScheme{"EQ1": {SchemeCode: "EQ1", Category: "equity"}}},
+		nil,
+		fakeNAVRepo{points: map[string][]model.NAVPoint{"EQ1": flatNAVs("EQ1", 36)}},
+	)
+	userID := bson.NewObjectID().Hex()
+
+	res, err := svc.MonteCarlo(context.Background(), userID, &model.MonteCarloRequest{Years: 5, Simulations: 50, Method: "bootstrap"})
+	if err != nil {
+		t.Fatalf("bootstrap with a deposit: %v", err)
+	}
+	debt, equity := res.Allocation["debt"], res.Allocation["equity"]
+	if debt <= fd.Principal || equity != 50000 {
+		t.Fatalf("allocation = %v, want the deposit's value under debt and 50000 under equity", res.Allocation)
+	}
+	// Equity history is flat and the deposit has none, so it grows at the
+	// assumed debt return on every path.
+	want := equity + debt*math.Pow(1+expectedReturn("debt")/100, 5)
+	if f := res.Final; math.Abs(f.P10-want) > 0.05 || math.Abs(f.P90-want) > 0.05 {
+		t.Errorf("final = %+v, want every path at %.2f", f, want)
+	}
+
+	// Classes that do have funds still need enough history.
+	svc.(*projectionService).navRepo = fakeNAVRepo{points: map[string][]model.NAVPoint{"EQ1": flatNAVs("EQ1", 12)}}
+	_, err = svc.MonteCarlo(context.Background(), userID, &model.MonteCarloRequest{Years: 5, Simulations: 50, Method: "bootstrap"})
+	if !errors.Is(err, ErrInsufficientHistory) {
+		t.Errorf("short equity history: err = %v, want ErrInsufficientHistory", err)
+	}
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// rebalanceBandPct is how far a class may drift from its target, in
+// percentage points, before a trade is suggested.
+const rebalanceBandPct = 5.0
+
+// GetRebalancePlan compares current allocation by class with the user's
+// recommended mix, or the default mix if no profile has been assessed.
+// Classes the mix does not cover, such as gold, are held as they are: the
+// mix applies to the rest of the portfolio, and percentages are of the whole.
+func (s *wealthService) GetRebalancePlan(ctx context.Context, userID string) (*model.RebalancePlan, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	portfolio, err := s.GetPortfolio(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fillCategories(ctx, portfolio); err != nil {
+		return nil, err
+	}
+
+	plan := &model.RebalancePlan{BandPct: rebalanceBandPct, Classes: []model.ClassDrift{}}
+	mix := defaultMix
+	rp, err := s.riskRepo.FindByUserID(ctx, oid)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if rp != nil && len(rp.RecommendedMix) > 0 {
+		mix, plan.RiskCategory = rp.RecommendedMix, rp.RiskCategory
+	}
+
+	values := make(map[string]float64)
+	var planned float64
+	for i := range portfolio.Holdings {
+		h := &portfolio.Holdings[i]
+		for c, v := range classValues(h) {
+			values[c] += v
+			if _, ok := mix[c]; ok {
+				planned += v
+			}
+		}
+		plan.TotalValue += h.CurrentValue
+	}
+	plan.TotalValue = roundAmount(plan.TotalValue)
+
+	classes := make([]string, 0, len(mix)+len(values))
+	for c := range mix {
+		classes = append(classes, c)
+	}
+	for c := range values {
+		if _, ok := mix[c]; !ok {
+			classes = append(classes, c)
+		}
+	}
+	sort.Strings(classes)
+
+	for _, c := range classes {
+		d := model.ClassDrift{
+			Class:        c,
+			CurrentValue: roundAmount(values[c]),
+			Action:       "hold",
+		}
+		target, inMix := mix[c]
+		if plan.TotalValue > 0 {
+			d.CurrentPct = roundAmount(values[c] / plan.TotalValue * 100)
+		}
+		if !inMix {
+			plan.Classes = append(plan.Classes, d)
+			continue
+		}
+		targetValue := planned * float64(target) / 100
+		if plan.TotalValue > 0 {
+			d.TargetPct = roundAmount(targetValue / plan.TotalValue * 100)
+		}
+		d.DriftPct = roundAmount(d.CurrentPct - d.TargetPct)
+		if plan.TotalValue > 0 && math.Abs(d.DriftPct) > rebalanceBandPct {
+			d.Amount = roundAmount(math.Abs(targetValue - values[c]))
+			d.Action = "buy"
+			if d.DriftPct > 0 {
+				d.Action = "sell"
+			}
+			plan.NeedsRebalance = true
+		}
+		plan.Classes = append(plan.Classes, d)
+	}
+	return plan, nil
+}
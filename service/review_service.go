@@ -0,0 +1,500 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const (
+	reviewQuarters    = 4 // quarter ends kept in each review
+	reviewConsecutive = 2 // lagging quarters in a row before a scheme is flagged
+	minMedianPeers    = 3 // fewer peers with a return give no category median
+	maxAlternatives   = 3
+	quarterEndGrace   = 7 * 24 * time.Hour // a quarter-end NAV may be this old, for holidays
+)
+
+// reviewWindows are the trailing windows reviewed, in years.
+var reviewWindows = []int{1, 3}
+
+type ReviewService interface {
+	ReviewAll(ctx context.Context, now time.Time) (*model.ReviewRunResult, error)
+	PortfolioReview(ctx context.Context, userID string) (*model.PortfolioReview, error)
+}
+
+type reviewService struct {
+	reviewRepo repository.SchemeReviewRepo
+	mfRepo     repository.MFSchemeRepo
+	navRepo    repository.NAVRepo
+	benchRepo  repository.BenchmarkRepo
+	factRepo   repository.FactsheetRepo
+	portRepo   repository.PortfolioRepo
+	notifRepo  repository.NotificationRepo
+}
+
+func NewReviewService(rr repository.SchemeReviewRepo, mr repository.MFSchemeRepo, nr repository.NAVRepo, br repository.BenchmarkRepo, fr repository.FactsheetRepo, pr repository.PortfolioRepo, ntr repository.NotificationRepo) ReviewService {
+	return &reviewService{rr, mr, nr, br, fr, pr, ntr}
+}
+
+// ReviewAll reviews every peer group (sub-category and plan) that contains
+// a held fund, as of the last completed quarter. A scheme is flagged when
+// its 1Y or 3Y return has trailed the category median or its benchmark at
+// reviewConsecutive quarter ends in a row; holders of a flagged scheme are
+// notified once per quarter. A scheme is only marked notified once every
+// holder's notification is written, so a run that fails part-way notifies
+// its holders again on the next run. It is safe to re-run.
+func (s *reviewService) ReviewAll(ctx context.Context, now time.Time) (*model.ReviewRunResult, error) {
+	ends := quarterEnds(now, reviewQuarters)
+	res := &model.ReviewRunResult{QuarterEnd: ends[len(ends)-1]}
+
+	schemes, err := s.mfRepo.FindAll(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	growth := make(map[string]*model.MFScheme) // fund code and plan -> growth variant
+	groups := make(map[string][]*model.MFScheme)
+	byCode := make(map[string]*model.MFScheme, len(schemes))
+	for i := range schemes {
+		sc := &schemes[i]
+		byCode[sc.SchemeCode] = sc
+		if sc.Option == model.OptionGrowth {
+			growth[sc.FundCode+"|"+sc.Plan] = sc
+			groups[sc.SubCategory+"|"+sc.Plan] = append(groups[sc.SubCategory+"|"+sc.Plan], sc)
+		}
+	}
+
+	held, err := s.portRepo.HeldSchemeCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	heldBy := make(map[string][]string) // reviewed growth scheme -> held codes it stands for
+	reviewGroups := make(map[string]bool)
+	for _, code := range held {
+		sc, ok := byCode[code]
+		if !ok {
+			continue
+		}
+		g, ok := growth[sc.FundCode+"|"+sc.Plan]
+		if !ok {
+			continue
+		}
+		heldBy[g.SchemeCode] = append(heldBy[g.SchemeCode], code)
+		reviewGroups[g.SubCategory+"|"+g.Plan] = true
+	}
+
+	known, err := benchmarksByCode(ctx, s.benchRepo)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(reviewGroups))
+	for k := range reviewGroups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		peers := groups[k]
+		reviews, err := s.reviewGroup(ctx, peers, ends, known, now)
+		if err != nil {
+			return res, err
+		}
+		codes := make([]string, len(peers))
+		for i, sc := range peers {
+			codes[i] = sc.SchemeCode
+		}
+		previous, err := s.reviewRepo.FindByCodes(ctx, codes)
+		if err != nil {
+			return res, err
+		}
+		prev := make(map[string]model.SchemeReview, len(previous))
+		for _, p := range previous {
+			prev[p.SchemeCode] = p
+		}
+		for _, rv := range reviews {
+			rv.NotifiedAsOf = prev[rv.SchemeCode].NotifiedAsOf
+			if err := s.reviewRepo.Upsert(ctx, rv); err != nil {
+				return res, err
+			}
+			res.Schemes++
+			if !rv.Flagged {
+				continue
+			}
+			res.Flagged++
+			if rv.NotifiedAsOf.Equal(rv.AsOf) {
+				continue // holders already told this quarter
+			}
+			n, err := s.notifyHolders(ctx, rv, heldBy[rv.SchemeCode])
+			res.Notifications += n
+			if err != nil {
+				return res, err
+			}
+			if err := s.reviewRepo.MarkNotified(ctx, rv.SchemeCode, rv.AsOf); err != nil {
+				return res, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// reviewGroup computes the reviews of one peer group.
+func (s *reviewService) reviewGroup(ctx context.Context, peers []*model.MFScheme, ends []time.Time, known map[string]*model.Benchmark, now time.Time) ([]*model.SchemeReview, error) {
+	from := ends[0].AddDate(-reviewWindows[len(reviewWindows)-1], 0, 0).Add(-quarterEndGrace)
+	to := ends[len(ends)-1]
+	benchSeries := make(map[string][]model.NAVPoint)
+	reviews := make([]*model.SchemeReview, len(peers))
+	for i, sc := range peers {
+		navs, err := s.navRepo.FindRange(ctx, sc.SchemeCode, from, to)
+		if err != nil {
+			return nil, err
+		}
+		b, err := resolveBenchmark(ctx, s.factRepo, sc, known)
+		if err != nil && !errors.Is(err, ErrNoSchemeBenchmark) {
+			return nil, err
+		}
+		var values []model.NAVPoint
+		if b != nil {
+			var ok bool
+			if values, ok = benchSeries[b.Code]; !ok {
+				if values, err = s.navRepo.FindRange(ctx, b.Code, from, to); err != nil {
+					return nil, err
+				}
+				benchSeries[b.Code] = values
+			}
+		}
+		rv := &model.SchemeReview{
+			SchemeCode:  sc.SchemeCode,
+			SchemeName:  sc.SchemeName,
+			SubCategory: sc.SubCategory,
+			Plan:        sc.Plan,
+			AsOf:        to,
+			Quarters:    make([]model.QuarterReview, len(ends)),
+			Ranks:       []model.PeerRank{},
+			ReviewedAt:  now,
+		}
+		if b != nil {
+			rv.BenchmarkCode = b.Code
+		}
+		for q, end := range ends {
+			rv.Quarters[q].QuarterEnd = end
+			for _, years := range reviewWindows {
+				ret, _, bench := windowFields(&rv.Quarters[q], years)
+				*ret = quarterReturn(navs, end, years)
+				if b != nil {
+					*bench = quarterReturn(values, end, years)
+				}
+			}
+		}
+		reviews[i] = rv
+	}
+
+	for q := range ends {
+		for _, years := range reviewWindows {
+			var rets []float64
+			for _, rv := range reviews {
+				if ret, _, _ := windowFields(&rv.Quarters[q], years); *ret != nil {
+					rets = append(rets, **ret)
+				}
+			}
+			if len(rets) < minMedianPeers {
+				continue
+			}
+			m := roundAmount(median(rets))
+			for _, rv := range reviews {
+				_, med, _ := windowFields(&rv.Quarters[q], years)
+				*med = &m
+			}
+		}
+	}
+	for _, rv := range reviews {
+		flagReview(rv, known)
+	}
+	rankPeers(reviews, peers)
+	return reviews, nil
+}
+
+// flagReview sets the reasons a scheme is lagging, if any.
+func flagReview(rv *model.SchemeReview, known map[string]*model.Benchmark) {
+	rv.Reasons = nil
+	for _, years := range reviewWindows {
+		behindMedian := lagRun(rv.Quarters, years, func(ret, med, _ *float64) bool { return med != nil && *ret < *med })
+		if behindMedian >= reviewConsecutive {
+			rv.Reasons = append(rv.Reasons, fmt.Sprintf("%dY return behind the category median for %d consecutive quarters", years, behindMedian))
+		}
+		behindBench := lagRun(rv.Quarters, years, func(ret, _, bench *float64) bool { return bench != nil && *ret < *bench })
+		if behindBench >= reviewConsecutive {
+			rv.Reasons = append(rv.Reasons, fmt.Sprintf("%dY return behind %s for %d consecutive quarters", years, known[rv.BenchmarkCode].Name, behindBench))
+		}
+	}
+	rv.Flagged = len(rv.Reasons) > 0
+}
+
+// lagRun counts the quarters, latest first, for which lagging holds.
+func lagRun(quarters []model.QuarterReview, years int, lagging func(ret, med, bench *float64) bool) int {
+	n := 0
+	for q := len(quarters) - 1; q >= 0; q-- {
+		ret, med, bench := windowFields(&quarters[q], years)
+		if *ret == nil || !lagging(*ret, *med, *bench) {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// rankPeers ranks the group by each window's return at the latest quarter
+// end and lists better-ranked, unflagged peers as alternatives to each
+// flagged scheme, by 3Y rank where the scheme has one.
+func rankPeers(reviews []*model.SchemeReview, peers []*model.MFScheme) {
+	last := len(reviews[0].Quarters) - 1
+	ranked := make(map[int][]int) // window -> review indexes, best first
+	for _, years := range reviewWindows {
+		var idx []int
+		for i, rv := range reviews {
+			if ret, _, _ := windowFields(&rv.Quarters[last], years); *ret != nil {
+				idx = append(idx, i)
+			}
+		}
+		ret := func(i int) float64 {
+			r, _, _ := windowFields(&reviews[i].Quarters[last], years)
+			return **r
+		}
+		sort.SliceStable(idx, func(a, b int) bool { return ret(idx[a]) > ret(idx[b]) })
+		for pos, i := range idx {
+			reviews[i].Ranks = append(reviews[i].Ranks, model.PeerRank{Window: fmt.Sprintf("%dY", years), Rank: pos + 1, Of: len(idx)})
+		}
+		ranked[years] = idx
+	}
+
+	for i, rv := range reviews {
+		rv.Alternatives = nil
+		if !rv.Flagged {
+			continue
+		}
+		for k := len(reviewWindows) - 1; k >= 0; k-- {
+			years := reviewWindows[k]
+			own := -1
+			for pos, j := range ranked[years] {
+				if j == i {
+					own = pos
+				}
+			}
+			if own < 0 {
+				continue
+			}
+			for pos, j := range ranked[years][:own] {
+				if reviews[j].Flagged {
+					continue
+				}
+				r, _, _ := windowFields(&reviews[j].Quarters[last], years)
+				rv.Alternatives = append(rv.Alternatives, model.ReviewAlternative{
+					SchemeCode: peers[j].SchemeCode,
+					SchemeName: peers[j].SchemeName,
+					AMC:        peers[j].AMC,
+					Window:     fmt.Sprintf("%dY", years),
+					Rank:       pos + 1,
+					Return:     **r,
+				})
+				if len(rv.Alternatives) == maxAlternatives {
+					break
+				}
+			}
+			break
+		}
+	}
+}
+
+func (s *reviewService) notifyHolders(ctx context.Context, rv *model.SchemeReview, heldCodes []string) (int, error) {
+	body := rv.Reasons[0] + "."
+	alts := make([]string, len(rv.Alternatives))
+	names := make([]string, len(rv.Alternatives))
+	for i, a := range rv.Alternatives {
+		alts[i], names[i] = a.SchemeCode, a.SchemeName
+	}
+	if len(names) > 0 {
+		body += " Better-ranked funds in the same category include " + strings.Join(names, ", ") + "."
+	}
+	n := 0
+	for _, code := range heldCodes {
+		portfolios, err := s.portRepo.FindHolders(ctx, code)
+		if err != nil {
+			return n, err
+		}
+		for _, p := range portfolios {
+			i := findHolding(&p, code)
+			if i < 0 {
+				continue
+			}
+			err := s.notifRepo.Create(ctx, &model.NotificationEvent{
+				UserID: p.UserID,
+				Type:   model.EventUnderperformer,
+				Title:  p.Holdings[i].SchemeName + " is lagging",
+				Body:   body,
+				Data: map[string]interface{}{
+					"scheme_code":  code,
+					"quarter_end":  rv.AsOf,
+					"reasons":      rv.Reasons,
+					"alternatives": alts,
+				},
+			})
+			if err != nil {
+				return n, err
+			}
+			n++
+		}
+	}
+	return n, nil
+}
+
+// PortfolioReview returns the latest review of each fund the user holds.
+func (s *reviewService) PortfolioReview(ctx context.Context, userID string) (*model.PortfolioReview, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	out := &model.PortfolioReview{Holdings: []model.HoldingReview{}}
+	p, err := s.portRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return out, nil
+		}
+		return nil, err
+	}
+
+	reviewCode := make(map[string]string)
+	var codes []string
+	for _, h := range p.Holdings {
+		if !isFund(&h) {
+			continue
+		}
+		code, err := s.growthCode(ctx, h.SchemeCode)
+		if err != nil {
+			return nil, err
+		}
+		if code != "" {
+			reviewCode[h.SchemeCode] = code
+			codes = append(codes, code)
+		}
+	}
+	reviews := make(map[string]model.SchemeReview)
+	if len(codes) > 0 {
+		list, err := s.reviewRepo.FindByCodes(ctx, codes)
+		if err != nil {
+			return nil, err
+		}
+		for _, rv := range list {
+			reviews[rv.SchemeCode] = rv
+		}
+	}
+
+	for _, h := range p.Holdings {
+		if !isFund(&h) {
+			continue
+		}
+		rv, ok := reviews[reviewCode[h.SchemeCode]]
+		if !ok {
+			out.NotReviewed = append(out.NotReviewed, h.SchemeName)
+			continue
+		}
+		out.Holdings = append(out.Holdings, model.HoldingReview{
+			SchemeCode:   h.SchemeCode,
+			SchemeName:   h.SchemeName,
+			CurrentValue: h.CurrentValue,
+			AsOf:         rv.AsOf,
+			Flagged:      rv.Flagged,
+			Reasons:      rv.Reasons,
+			Ranks:        rv.Ranks,
+			Alternatives: rv.Alternatives,
+		})
+		if rv.Flagged {
+			out.Flagged++
+		}
+	}
+	sort.SliceStable(out.Holdings, func(i, j int) bool {
+		a, b := out.Holdings[i], out.Holdings[j]
+		if a.Flagged != b.Flagged {
+			return a.Flagged
+		}
+		return a.CurrentValue > b.CurrentValue
+	})
+	return out, nil
+}
+
+// growthCode is the scheme reviewed on behalf of code: itself for a growth
+// scheme, else the growth variant of the same fund and plan. It is empty
+// when there is none.
+func (s *reviewService) growthCode(ctx context.Context, code string) (string, error) {
+	sc, err := s.mfRepo.FindByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return "", nil
+		}
+		return "", err
+	}
+	if sc.Option == model.OptionGrowth {
+		return sc.SchemeCode, nil
+	}
+	variants, err := s.mfRepo.FindByFundCode(ctx, sc.FundCode)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range variants {
+		if v.Plan == sc.Plan && v.Option == model.OptionGrowth {
+			return v.SchemeCode, nil
+		}
+	}
+	return "", nil
+}
+
+// quarterEnds returns the last n completed quarter ends before now, oldest
+// first.
+func quarterEnds(now time.Time, n int) []time.Time {
+	y, m, _ := now.Date()
+	start := time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, time.UTC) // of the current quarter
+	ends := make([]time.Time, n)
+	for i := 0; i < n; i++ {
+		ends[n-1-i] = start.AddDate(0, -3*i, -1)
+	}
+	return ends
+}
+
+// quarterReturn is the trailing return over years to end, or nil when the
+// series lacks a value within quarterEndGrace of either end.
+func quarterReturn(points []model.NAVPoint, end time.Time, years int) *float64 {
+	last, ok := valueOn(points, end)
+	if !ok || end.Sub(last.Date) > quarterEndGrace {
+		return nil
+	}
+	start := end.AddDate(-years, 0, 0)
+	first, ok := valueOn(points, start)
+	if !ok || start.Sub(first.Date) > quarterEndGrace {
+		return nil
+	}
+	r := roundAmount(annualisedReturn(first.NAV, last.NAV, start, end))
+	return &r
+}
+
+// windowFields points at the quarter's return, category median and
+// benchmark return for a window.
+func windowFields(q *model.QuarterReview, years int) (ret, med, bench **float64) {
+	if years == 3 {
+		return &q.Return3Y, &q.Median3Y, &q.Benchmark3Y
+	}
+	return &q.Return1Y, &q.Median1Y, &q.Benchmark1Y
+}
+
+func median(values []float64) float64 {
+	v := append([]float64(nil), values...)
+	sort.Float64s(v)
+	n := len(v)
+	if n%2 == 1 {
+		return v[n/2]
+	}
+	return (v[n/2-1] + v[n/2]) / 2
+}
@@ -0,0 +1,381 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"github.com/banking-superapp/wealth-service/service/pdf"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var ErrNoInvestorProfile = errors.New("PAN and date of birth are not on file")
+
+var panPattern = regexp.MustCompile(`^[A-Z]{5}[0-9]{4}[A-Z]$`)
+
+// closingPriceLookback bounds the search for the last NAV or close on or
+// before a statement's end date.
+const closingPriceLookback = 10 * 24 * time.Hour
+
+type StatementService interface {
+	IngestProfiles(ctx context.Context, records []model.InvestorProfileRecord) (*model.InvestorProfileFeedResult, error)
+	Generate(ctx context.Context, userID string, req *model.StatementRequest) (*model.Statement, error)
+}
+
+type statementService struct {
+	profileRepo repository.InvestorProfileRepo
+	txnRepo     repository.TransactionRepo
+	portRepo    repository.PortfolioRepo
+	navRepo     repository.NAVRepo
+	equitySvc   EquityService
+}
+
+func NewStatementService(ipr repository.InvestorProfileRepo, tr repository.TransactionRepo, pr repository.PortfolioRepo, nr repository.NAVRepo, es EquityService) StatementService {
+	return &statementService{ipr, tr, pr, nr, es}
+}
+
+// IngestProfiles upserts investor KYC details pushed by the profile system.
+// Invalid records are reported and skipped.
+func (s *statementService) IngestProfiles(ctx context.Context, records []model.InvestorProfileRecord) (*model.InvestorProfileFeedResult, error) {
+	res := &model.InvestorProfileFeedResult{}
+	for i, rec := range records {
+		oid, err := bson.ObjectIDFromHex(rec.UserID)
+		pan := strings.ToUpper(strings.TrimSpace(rec.PAN))
+		dob, dobErr := time.Parse(importDateLayout, rec.DateOfBirth)
+		if err != nil || !panPattern.MatchString(pan) || dobErr != nil {
+			res.Errors = append(res.Errors, fmt.Sprintf("record %d: invalid user_id, pan or date_of_birth", i+1))
+			continue
+		}
+		p := &model.InvestorProfile{UserID: oid, Name: strings.TrimSpace(rec.Name), PAN: pan, DateOfBirth: dob}
+		if err := s.profileRepo.Upsert(ctx, p); err != nil {
+			return nil, err
+		}
+		res.Upserted++
+	}
+	return res, nil
+}
+
+// statementSection is one titled block of a statement, rendered as a PDF
+// heading and table or as a run of CSV rows.
+type statementSection struct {
+	title string
+	notes []string
+	cols  []pdf.Column
+	rows  [][]string
+}
+
+// Generate renders a transaction or capital gains statement. Only PDFs can
+// be protected; their password is statementPassword.
+func (s *statementService) Generate(ctx context.Context, userID string, req *model.StatementRequest) (*model.Statement, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	if req.Format == "" {
+		req.Format = "pdf"
+	}
+	if req.Type == "" {
+		req.Type = model.StatementTransactions
+	}
+	if (req.Format != "pdf" && req.Format != "csv") || (req.Protect && req.Format != "pdf") {
+		return nil, ErrInvalidRequest
+	}
+	profile, err := s.profileRepo.FindByUserID(ctx, oid)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if req.Protect && profile == nil {
+		return nil, ErrNoInvestorProfile
+	}
+
+	var (
+		title, name string
+		header      []string
+		sections    []statementSection
+	)
+	switch req.Type {
+	case model.StatementTransactions:
+		from, to := req.From, req.To
+		if from.IsZero() {
+			from, _ = financialYear(time.Now())
+		}
+		if to.IsZero() {
+			to = endOfDay(time.Now())
+		}
+		if to.Before(from) {
+			return nil, ErrInvalidRequest
+		}
+		if sections, err = s.transactionSections(ctx, oid, from, to); err != nil {
+			return nil, err
+		}
+		title = "Statement of account"
+		header = append(header, fmt.Sprintf("Period: %s to %s", from.Format(displayDateLayout), to.Format(displayDateLayout)))
+		name = fmt.Sprintf("statement_%s_%s", from.Format(importDateLayout), to.Format(importDateLayout))
+	case model.StatementCapitalGains:
+		report, err := s.equitySvc.CapitalGains(ctx, userID, req.FY)
+		if err != nil {
+			return nil, err
+		}
+		sections = capitalGainsSections(report)
+		title = "Capital gains statement"
+		header = append(header, "Financial year: "+report.FinancialYear)
+		name = "capital_gains_" + report.FinancialYear
+	default:
+		return nil, ErrInvalidRequest
+	}
+	if profile != nil {
+		header = append([]string{fmt.Sprintf("Investor: %s  PAN: %s", profile.Name, maskPAN(profile.PAN))}, header...)
+	}
+	header = append(header, "Generated: "+time.Now().Format(displayDateLayout+" 15:04"))
+
+	if req.Format == "csv" {
+		data, err := renderCSV(title, header, sections)
+		if err != nil {
+			return nil, err
+		}
+		return &model.Statement{FileName: name + ".csv", ContentType: "text/csv", Data: data}, nil
+	}
+	doc := pdf.New(title)
+	doc.Title(title)
+	for _, h := range header {
+		doc.Text(h)
+	}
+	for _, sec := range sections {
+		doc.Heading(sec.title)
+		for _, n := range sec.notes {
+			doc.Text(n)
+		}
+		if len(sec.cols) > 0 {
+			doc.Table(sec.cols, sec.rows)
+		}
+	}
+	password := ""
+	if req.Protect {
+		password = statementPassword(profile)
+	}
+	data, err := doc.Bytes(password)
+	if err != nil {
+		return nil, err
+	}
+	return &model.Statement{FileName: name + ".pdf", ContentType: "application/pdf", Data: data}, nil
+}
+
+const displayDateLayout = "02 Jan 2006"
+
+// unitSummary is one scheme's or security's units across a statement period.
+type unitSummary struct {
+	code, name       string
+	opening, in, out float64
+	closing, afterTo float64
+	traded           bool
+}
+
+// ledgerGapNote warns that unit movements come from a ledger that does not
+// yet record mutual fund purchases, SIP instalments or redemptions.
+const ledgerGapNote = "Mutual fund purchases, SIP instalments and redemptions are not yet recorded in the transaction ledger. " +
+	"They are missing from the transactions, and from units in and out, so opening units may not match your records."
+
+// fundGainsNote warns that the capital gains statement leaves out mutual
+// funds, whose redemptions the ledger does not yet record.
+const fundGainsNote = "Mutual fund redemptions are not yet recorded in the transaction ledger, so gains on mutual funds are not included. " +
+	"Use your fund house or registrar capital gains statement for them."
+
+// transactionSections builds the holdings summary and the transaction list.
+// Closing units are current units less movements after the period, so
+// holdings that predate the ledger still reconcile; opening units are
+// closing less the period's movements. Fund purchases and redemptions are
+// not yet posted to the ledger, so both sections say so.
+func (s *statementService) transactionSections(ctx context.Context, userID bson.ObjectID, from, to time.Time) ([]statementSection, error) {
+	p, err := s.portRepo.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	txns, err := s.txnRepo.FindByUserID(ctx, userID, from, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	units := make(map[string]*unitSummary)
+	var order []string
+	get := func(code, name string) *unitSummary {
+		u, ok := units[code]
+		if !ok {
+			u = &unitSummary{code: code, name: name}
+			units[code] = u
+			order = append(order, code)
+		}
+		return u
+	}
+	if p != nil {
+		for _, h := range p.Holdings {
+			switch {
+			case isFund(&h):
+				get(h.SchemeCode, h.SchemeName).closing = h.Units
+			case h.Equity != nil:
+				get(h.Equity.ISIN, h.SchemeName).closing = h.Equity.Quantity
+			}
+		}
+	}
+
+	var rows [][]string
+	for _, t := range txns {
+		if t.Type == model.TxnNPSContribution {
+			continue // NPS units sit inside the account, not per scheme code
+		}
+		u := get(t.SchemeCode, t.SchemeName)
+		if t.Date.After(to) {
+			u.afterTo += t.Units
+			continue
+		}
+		u.traded = true
+		if t.Units >= 0 {
+			u.in += t.Units
+		} else {
+			u.out -= t.Units
+		}
+		rows = append(rows, []string{
+			t.Date.Format(displayDateLayout), t.SchemeName, t.SchemeCode, t.Type,
+			formatUnits(t.Units), formatAmount(t.NAV), formatAmount(t.Amount), formatAmount(t.TDS), formatAmount(t.NetAmount),
+		})
+	}
+
+	var summary [][]string
+	total := 0.0
+	for _, code := range order {
+		u := units[code]
+		u.closing = roundUnits(u.closing - u.afterTo)
+		u.opening = roundUnits(u.closing - u.in + u.out)
+		if u.opening == 0 && u.closing == 0 && !u.traded {
+			continue
+		}
+		points, err := s.navRepo.FindRange(ctx, code, to.Add(-closingPriceLookback), to)
+		if err != nil {
+			return nil, err
+		}
+		price, value := "", ""
+		if n := len(points); n > 0 {
+			v := roundAmount(u.closing * points[n-1].NAV)
+			total += v
+			price, value = formatAmount(points[n-1].NAV), formatAmount(v)
+		}
+		summary = append(summary, []string{
+			u.name, code, formatUnits(u.opening), formatUnits(u.in), formatUnits(u.out), formatUnits(u.closing), price, value,
+		})
+	}
+	sort.SliceStable(summary, func(i, j int) bool { return summary[i][0] < summary[j][0] })
+
+	return []statementSection{
+		{
+			title: "Holdings summary",
+			notes: []string{
+				"Units of mutual funds, shares and ETFs, valued at the last NAV or close on or before the period end. Total value: " + formatAmount(roundAmount(total)),
+				ledgerGapNote,
+			},
+			cols: []pdf.Column{
+				{Title: "Scheme / security", Width: 44}, {Title: "Code", Width: 12},
+				{Title: "Opening units", Width: 14, Right: true}, {Title: "Units in", Width: 14, Right: true},
+				{Title: "Units out", Width: 14, Right: true}, {Title: "Closing units", Width: 14, Right: true},
+				{Title: "NAV / price", Width: 12, Right: true}, {Title: "Value", Width: 16, Right: true},
+			},
+			rows: summary,
+		},
+		{
+			title: "Transactions",
+			notes: []string{fmt.Sprintf("%d transactions in the period.", len(rows)), ledgerGapNote},
+			cols: []pdf.Column{
+				{Title: "Date", Width: 11}, {Title: "Scheme / security", Width: 40}, {Title: "Code", Width: 12},
+				{Title: "Type", Width: 18}, {Title: "Units", Width: 12, Right: true}, {Title: "NAV / price", Width: 10, Right: true},
+				{Title: "Amount", Width: 12, Right: true}, {Title: "TDS", Width: 9, Right: true}, {Title: "Net amount", Width: 12, Right: true},
+			},
+			rows: rows,
+		},
+	}, nil
+}
+
+func capitalGainsSections(r *model.CapitalGainsReport) []statementSection {
+	rows := make([][]string, 0, len(r.Entries))
+	for _, g := range r.Entries {
+		rows = append(rows, []string{
+			g.Name, g.ISIN, formatUnits(g.Quantity), g.BuyDate.Format(displayDateLayout), g.SellDate.Format(displayDateLayout),
+			formatAmount(g.Cost), formatAmount(g.Proceeds), formatAmount(g.Gain), g.Term,
+		})
+	}
+	summary := []string{
+		"Short-term gain: " + formatAmount(r.ShortTermGain),
+		"Long-term gain: " + formatAmount(r.LongTermGain),
+		"LTCG exemption used: " + formatAmount(r.LTCGExemption),
+		"Taxable short-term gain: " + formatAmount(r.TaxableShortTerm),
+		"Taxable long-term gain: " + formatAmount(r.TaxableLongTerm),
+		"Short-term loss carried forward: " + formatAmount(r.ShortTermLossCF),
+		"Long-term loss carried forward: " + formatAmount(r.LongTermLossCF),
+		"Estimated tax (before surcharge and cess): " + formatAmount(r.EstimatedTax),
+	}
+	summary = append(summary, r.UnmatchedSales...)
+	return []statementSection{
+		{
+			title: "Listed shares and ETFs",
+			notes: []string{"Sales matched to purchases first in first out, at actual cost.", fundGainsNote},
+			cols: []pdf.Column{
+				{Title: "Security", Width: 40}, {Title: "ISIN", Width: 12}, {Title: "Quantity", Width: 12, Right: true},
+				{Title: "Bought", Width: 11}, {Title: "Sold", Width: 11}, {Title: "Cost", Width: 14, Right: true},
+				{Title: "Proceeds", Width: 14, Right: true}, {Title: "Gain", Width: 14, Right: true}, {Title: "Term", Width: 5},
+			},
+			rows: rows,
+		},
+		{title: "Summary", notes: summary},
+	}
+}
+
+func renderCSV(title string, header []string, sections []statementSection) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{title})
+	for _, h := range header {
+		w.Write([]string{h})
+	}
+	for _, sec := range sections {
+		w.Write(nil)
+		w.Write([]string{sec.title})
+		for _, n := range sec.notes {
+			w.Write([]string{n})
+		}
+		if len(sec.cols) == 0 {
+			continue
+		}
+		titles := make([]string, len(sec.cols))
+		for i, c := range sec.cols {
+			titles[i] = c.Title
+		}
+		w.Write(titles)
+		w.WriteAll(sec.rows)
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// statementPassword is the first five characters of the PAN in capitals
+// followed by the date of birth as DDMMYYYY, e.g. ABCDE01011990.
+func statementPassword(p *model.InvestorProfile) string {
+	return strings.ToUpper(p.PAN[:5]) + p.DateOfBirth.Format("02012006")
+}
+
+func maskPAN(pan string) string {
+	if len(pan) != 10 {
+		return pan
+	}
+	return pan[:2] + "XXXXXX" + pan[8:]
+}
+
+func formatAmount(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+
+func formatUnits(v float64) string { return strconv.FormatFloat(v, 'f', 3, 64) }
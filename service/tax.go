@@ -0,0 +1,64 @@
+package service
+
+import "time"
+
+// Section 194K: TDS on IDCW paid to a resident once the year's IDCW from
+// one mutual fund exceeds the threshold, which rose for payments from
+// 1 April 2025.
+const (
+	idcwTDSThresholdOld = 5000.0
+	idcwTDSThreshold    = 10000.0
+	idcwTDSRate         = 0.10
+)
+
+var idcwThresholdChange = time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+
+// idcwTDSThresholdOn is the section 194K threshold for a payment on t.
+func idcwTDSThresholdOn(t time.Time) float64 {
+	if t.Before(idcwThresholdChange) {
+		return idcwTDSThresholdOld
+	}
+	return idcwTDSThreshold
+}
+
+// financialYear returns the bounds of the Indian financial year (April to
+// March) containing t.
+func financialYear(t time.Time) (start, end time.Time) {
+	y := t.Year()
+	if t.Month() < time.April {
+		y--
+	}
+	start = time.Date(y, time.April, 1, 0, 0, 0, 0, t.Location())
+	end = start.AddDate(1, 0, 0).Add(-time.Nanosecond)
+	return start, end
+}
+
+func endOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 23, 59, 59, int(time.Second-time.Nanosecond), t.Location())
+}
+
+// Listed equity and equity ETFs (sections 111A and 112A). Transfers from
+// 23 July 2024 pay the higher rates; the LTCG exemption rose for the whole
+// of FY 2024-25. Gains are long term after twelve months.
+const (
+	equitySTCGRateOld      = 0.15
+	equitySTCGRate         = 0.20
+	equityLTCGRateOld      = 0.10
+	equityLTCGRate         = 0.125
+	equityLTCGExemptionOld = 100000.0
+	equityLTCGExemption    = 125000.0
+	equityLongTermMonths   = 12
+)
+
+var equityRateChange = time.Date(2024, time.July, 23, 0, 0, 0, 0, time.UTC)
+
+// equityExemptionFrom is the first financial year of the higher exemption.
+const equityExemptionFrom = 2024
+
+// Section 80C: the deduction for ELSS and other listed investments, under
+// the old regime. ELSS units are locked in for three years.
+const (
+	section80CLimit = 150000.0
+	elssLockInYears = 3
+)
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var taxSavingTypes = map[string]bool{
+	"epf":                 true,
+	"ppf":                 true,
+	"life_insurance":      true,
+	"nsc":                 true,
+	"tax_saver_fd":        true,
+	"home_loan_principal": true,
+	"tuition_fees":        true,
+	"sukanya_samriddhi":   true,
+	"ulip":                true,
+	"elss":                true, // lump sums in ELSS not yet in our ledger, or bought elsewhere
+	"other":               true,
+}
+
+// elssNote tells the reader which ELSS investments the tracker can see
+// while lump-sum orders are settled outside this service.
+const elssNote = "ELSS lump-sum purchases are not yet recorded in the ledger. " +
+	"ELSS invested counts NFO allotments and SIP instalments already run; declare other ELSS purchases under type elss."
+
+type TaxSavingService interface {
+	Tracker(ctx context.Context, userID, fy string) (*model.Section80CTracker, error)
+	SaveDeclaration(ctx context.Context, userID, fy string, req *model.TaxSavingDeclarationRequest) (*model.TaxSavingDeclaration, error)
+}
+
+type taxSavingService struct {
+	declRepo repository.TaxSavingRepo
+	txnRepo  repository.TransactionRepo
+	sipRepo  repository.SIPRepo
+	mfRepo   repository.MFSchemeRepo
+}
+
+func NewTaxSavingService(dr repository.TaxSavingRepo, tr repository.TransactionRepo, sr repository.SIPRepo, mr repository.MFSchemeRepo) TaxSavingService {
+	return &taxSavingService{dr, tr, sr, mr}
+}
+
+func (s *taxSavingService) SaveDeclaration(ctx context.Context, userID, fy string, req *model.TaxSavingDeclarationRequest) (*model.TaxSavingDeclaration, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	_, _, label, err := parseFinancialYear(fy, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	d := &model.TaxSavingDeclaration{UserID: oid, FinancialYear: label, Items: []model.TaxSavingItem{}}
+	for _, it := range req.Items {
+		if !taxSavingTypes[it.Type] || it.Amount <= 0 {
+			return nil, ErrInvalidRequest
+		}
+		it.Description = strings.TrimSpace(it.Description)
+		it.Amount = roundAmount(it.Amount)
+		d.Items = append(d.Items, it)
+	}
+	if err := s.declRepo.Upsert(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Tracker adds the year's ELSS investments (ledger purchases and the
+// instalments ELSS SIPs have run) to the user's declared 80C investments,
+// projects active ELSS SIPs to 31 March and suggests a lumpsum for
+// whatever headroom the SIPs leave.
+func (s *taxSavingService) Tracker(ctx context.Context, userID, fy string) (*model.Section80CTracker, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	now := time.Now()
+	from, to, label, err := parseFinancialYear(fy, now)
+	if err != nil {
+		return nil, err
+	}
+	t := &model.Section80CTracker{
+		FinancialYear:    label,
+		From:             from,
+		To:               to,
+		Limit:            section80CLimit,
+		ELSSInvestments:  []model.ELSSInvestment{},
+		OtherInvestments: []model.TaxSavingItem{},
+		UpcomingSIPs:     []model.UpcomingInstalment{},
+		Note:             elssNote,
+	}
+
+	elss := make(map[string]*model.MFScheme) // scheme code -> scheme, nil when not ELSS
+	isELSSCode := func(code string) (*model.MFScheme, error) {
+		if sc, ok := elss[code]; ok {
+			return sc, nil
+		}
+		sc, err := s.mfRepo.FindByCode(ctx, code)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		if sc != nil && !isELSS(sc) {
+			sc = nil
+		}
+		elss[code] = sc
+		return sc, nil
+	}
+
+	txns, err := s.txnRepo.FindByUserID(ctx, oid, from, to)
+	if err != nil {
+		return nil, err
+	}
+	bySchemeAmount := make(map[string]float64)
+	addELSS := func(inv model.ELSSInvestment) {
+		inv.LockedUntil = inv.Date.AddDate(elssLockInYears, 0, 0)
+		t.ELSSInvestments = append(t.ELSSInvestments, inv)
+		t.ELSSInvested += inv.Amount
+		bySchemeAmount[inv.SchemeCode] += inv.Amount
+	}
+	for _, txn := range txns {
+		// SIP instalments come from the SIPs below.
+		if txn.Type != model.TxnPurchase && txn.Type != model.TxnNFOAllotment {
+			continue
+		}
+		sc, err := isELSSCode(txn.SchemeCode)
+		if err != nil {
+			return nil, err
+		}
+		if sc == nil {
+			continue
+		}
+		addELSS(model.ELSSInvestment{Date: txn.Date, SchemeCode: txn.SchemeCode, SchemeName: txn.SchemeName, Type: txn.Type, Amount: txn.Amount})
+	}
+
+	sips, err := s.sipRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+	for _, sip := range sips {
+		sc, err := isELSSCode(sip.SchemeCode)
+		if err != nil {
+			return nil, err
+		}
+		if sc == nil {
+			continue
+		}
+		for _, d := range sipRunDates(sip, to) {
+			if d.Before(from) {
+				continue
+			}
+			addELSS(model.ELSSInvestment{Date: d, SchemeCode: sip.SchemeCode, SchemeName: sip.SchemeName, Type: model.TxnSIP, Amount: sip.Amount})
+		}
+	}
+	sort.SliceStable(t.ELSSInvestments, func(i, j int) bool { return t.ELSSInvestments[i].Date.Before(t.ELSSInvestments[j].Date) })
+	t.ELSSInvested = roundAmount(t.ELSSInvested)
+
+	decl, err := s.declRepo.Find(ctx, oid, label)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if decl != nil {
+		t.OtherInvestments = decl.Items
+		for _, it := range decl.Items {
+			t.OtherInvested += it.Amount
+		}
+		t.OtherInvested = roundAmount(t.OtherInvested)
+	}
+	t.Used = roundAmount(math.Min(t.ELSSInvested+t.OtherInvested, section80CLimit))
+	t.Headroom = roundAmount(section80CLimit - t.Used)
+
+	today := dateOf(now)
+	for _, sip := range sips {
+		if sip.Status != "active" {
+			continue
+		}
+		sc, err := isELSSCode(sip.SchemeCode)
+		if err != nil {
+			return nil, err
+		}
+		if sc == nil {
+			continue
+		}
+		for _, d := range sipDatesBetween(sip, today, to) {
+			t.UpcomingSIPs = append(t.UpcomingSIPs, model.UpcomingInstalment{
+				SIPID:      sip.ID,
+				SchemeCode: sip.SchemeCode,
+				SchemeName: sip.SchemeName,
+				Date:       d,
+				Amount:     sip.Amount,
+			})
+			t.UpcomingSIPTotal += sip.Amount
+			bySchemeAmount[sip.SchemeCode] += sip.Amount
+		}
+	}
+	sort.SliceStable(t.UpcomingSIPs, func(i, j int) bool { return t.UpcomingSIPs[i].Date.Before(t.UpcomingSIPs[j].Date) })
+	t.UpcomingSIPTotal = roundAmount(t.UpcomingSIPTotal)
+	t.HeadroomAfterSIPs = roundAmount(math.Max(t.Headroom-t.UpcomingSIPTotal, 0))
+
+	if t.HeadroomAfterSIPs > 0 && now.Before(to) {
+		t.TopUp = &model.TopUpSuggestion{Amount: math.Ceil(t.HeadroomAfterSIPs), By: dateOf(to)}
+		best := ""
+		for code, amt := range bySchemeAmount {
+			if best == "" || amt > bySchemeAmount[best] || amt == bySchemeAmount[best] && code < best {
+				best = code
+			}
+		}
+		if sc := elss[best]; sc != nil {
+			t.TopUp.SchemeCode, t.TopUp.SchemeName = sc.SchemeCode, sc.SchemeName
+			t.TopUp.Amount = math.Max(t.TopUp.Amount, sc.MinLumpsum)
+		}
+	}
+	return t, nil
+}
+
+// isELSS reports whether the scheme is an equity-linked savings scheme,
+// the only mutual funds eligible under 80C.
+func isELSS(sc *model.MFScheme) bool {
+	sub := strings.ToLower(sc.SubCategory)
+	return strings.Contains(sub, "elss") || strings.Contains(sub, "tax saver")
+}
+
+// sipDatesBetween lists the SIP's instalment dates from from to to
+// inclusive, counting from its next due date.
+func sipDatesBetween(sip model.SIP, from, to time.Time) []time.Time {
+	if next := dateOf(sip.NextSIPDate); from.Before(next) {
+		from = next
+	}
+	return sipSchedule(sip, from, to)
+}
+
+// sipRunDates lists the SIP's instalments up to to that its execution
+// state marks as run: every scheduled date before its next due date.
+func sipRunDates(sip model.SIP, to time.Time) []time.Time {
+	if last := dateOf(sip.NextSIPDate).Add(-time.Nanosecond); last.Before(to) {
+		to = last
+	}
+	return sipSchedule(sip, time.Time{}, to)
+}
+
+// sipSchedule lists the dates the SIP's schedule falls on from from to to
+// inclusive, due or not. Monthly instalments keep the start date's day,
+// clamped to short months.
+func sipSchedule(sip model.SIP, from, to time.Time) []time.Time {
+	var out []time.Time
+	if sip.StartDate.IsZero() {
+		return out
+	}
+	for k := 0; ; k++ {
+		var d time.Time
+		if sip.Frequency == "weekly" {
+			d = sip.StartDate.AddDate(0, 0, 7*k)
+		} else {
+			d = addMonthsClamped(sip.StartDate, k)
+		}
+		if d.After(to) {
+			return out
+		}
+		if !d.Before(from) {
+			out = append(out, d)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// historyMonths are the chart ranges offered, in months back from today;
+// ALL has no lower bound.
+var historyMonths = map[string]int{"1M": 1, "6M": 6, "1Y": 12}
+
+type ValuationService interface {
+	RevalueAll(ctx context.Context, now time.Time) (*model.PortfolioValuationResult, error)
+	History(ctx context.Context, userID, rng string) (*model.PortfolioHistory, error)
+}
+
+type valuationService struct {
+	portRepo      repository.PortfolioRepo
+	snapRepo      repository.PortfolioSnapshotRepo
+	mfRepo        repository.MFSchemeRepo
+	goldPriceRepo repository.GoldPriceRepo
+	npsRepo       repository.NPSSchemeRepo
+	secRepo       repository.SecurityRepo
+	tx            repository.Transactor
+}
+
+func NewValuationService(pr repository.PortfolioRepo, sr repository.PortfolioSnapshotRepo, mr repository.MFSchemeRepo, gr repository.GoldPriceRepo, nr repository.NPSSchemeRepo, secr repository.SecurityRepo, tx repository.Transactor) ValuationService {
+	return &valuationService{pr, sr, mr, gr, nr, secr, tx}
+}
+
+// priceBook caches the latest price of everything a portfolio can hold for
+// one valuation run. Fund schemes and securities are loaded on first use.
+type priceBook struct {
+	schemes    map[string]*model.MFScheme
+	securities map[string]*model.Security
+	gold       *model.GoldPrice
+	nps        map[string]model.NPSScheme
+}
+
+// RevalueAll brings every portfolio up to the latest NAVs and prices and
+// records the day's snapshot for each. It is meant to run nightly after NAV
+// ingestion and is safe to re-run: the day's snapshot is replaced. Only the
+// valuation fields are written back, from a portfolio read in the same
+// transaction, so trades made during the run are kept.
+// A portfolio that fails is logged and counted, and the run moves on.
+func (s *valuationService) RevalueAll(ctx context.Context, now time.Time) (*model.PortfolioValuationResult, error) {
+	book := &priceBook{schemes: map[string]*model.MFScheme{}, securities: map[string]*model.Security{}}
+	gold, err := s.goldPriceRepo.FindLatest(ctx)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	book.gold = gold
+	npsSchemes, err := s.npsRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	book.nps = make(map[string]model.NPSScheme, len(npsSchemes))
+	for _, sc := range npsSchemes {
+		book.nps[sc.SchemeCode] = sc
+	}
+
+	users, err := s.portRepo.UserIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := &model.PortfolioValuationResult{}
+	day := dateOf(now)
+	for _, id := range users {
+		if err := s.revalueUser(ctx, id, book, now, day, res); err != nil {
+			log.Printf("valuation: user %s: %v", id.Hex(), err)
+			res.Failed++
+		}
+	}
+	return res, nil
+}
+
+func (s *valuationService) revalueUser(ctx context.Context, id bson.ObjectID, book *priceBook, now, day time.Time, res *model.PortfolioValuationResult) error {
+	var p *model.Portfolio
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if p, err = s.portRepo.FindByUserID(ctx, id); err != nil {
+			return err
+		}
+		if err := s.revalue(ctx, p, book, now); err != nil {
+			return err
+		}
+		return s.portRepo.UpdateValuation(ctx, p)
+	})
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil
+		}
+		return err
+	}
+	res.Portfolios++
+	if err := s.snapRepo.Upsert(ctx, snapshotOf(p, day)); err != nil {
+		return err
+	}
+	res.Snapshots++
+	return nil
+}
+
+// revalue values each holding by its type: funds at the scheme NAV, shares
+// at the last close, gold at the latest price, NPS at scheme NAVs and
+// deposits by accrual to now. Holdings with no price keep their last value.
+func (s *valuationService) revalue(ctx context.Context, p *model.Portfolio, book *priceBook, now time.Time) error {
+	for i := range p.Holdings {
+		h := &p.Holdings[i]
+		switch {
+		case isFund(h):
+			sc, err := book.scheme(ctx, s.mfRepo, h.SchemeCode)
+			if err != nil {
+				return err
+			}
+			if sc != nil && sc.NAV > 0 {
+				h.CurrentNAV = sc.NAV
+				revalue(h)
+			}
+		case h.Equity != nil:
+			sec, err := book.security(ctx, s.secRepo, h.AssetID)
+			if err != nil {
+				return err
+			}
+			if sec != nil {
+				revalueEquity(h, sec)
+			}
+		case h.Gold != nil:
+			revalueGold(h, book.gold, now)
+		case h.NPS != nil:
+			revalueNPS(h, book.nps, now)
+		}
+	}
+	revalueDeposits(p, now) // also refreshes the totals
+	return nil
+}
+
+func (b *priceBook) scheme(ctx context.Context, repo repository.MFSchemeRepo, code string) (*model.MFScheme, error) {
+	if sc, ok := b.schemes[code]; ok {
+		return sc, nil
+	}
+	sc, err := repo.FindByCode(ctx, code)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	b.schemes[code] = sc
+	return sc, nil
+}
+
+func (b *priceBook) security(ctx context.Context, repo repository.SecurityRepo, isin string) (*model.Security, error) {
+	if sec, ok := b.securities[isin]; ok {
+		return sec, nil
+	}
+	sec, err := repo.FindByISIN(ctx, isin)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	b.securities[isin] = sec
+	return sec, nil
+}
+
+func snapshotOf(p *model.Portfolio, day time.Time) *model.PortfolioSnapshot {
+	snap := &model.PortfolioSnapshot{UserID: p.UserID, Date: day, Value: p.TotalValue, ByAsset: map[string]float64{}}
+	invested := 0.0
+	for _, h := range p.Holdings {
+		invested += h.InvestedValue
+		typ := h.AssetType
+		if isFund(&h) {
+			typ = model.AssetMutualFund
+		}
+		snap.ByAsset[typ] = roundAmount(snap.ByAsset[typ] + h.CurrentValue)
+	}
+	snap.Invested = roundAmount(invested)
+	snap.GainLoss = roundAmount(snap.Value - snap.Invested)
+	return snap
+}
+
+// History returns the snapshots for a chart range: 1M, 6M, 1Y (the
+// default) or ALL.
+func (s *valuationService) History(ctx context.Context, userID, rng string) (*model.PortfolioHistory, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	if rng == "" {
+		rng = "1Y"
+	}
+	now := time.Now()
+	h := &model.PortfolioHistory{Range: rng, To: dateOf(now)}
+	if months, ok := historyMonths[rng]; ok {
+		h.From = h.To.AddDate(0, -months, 0)
+	} else if rng != "ALL" {
+		return nil, ErrInvalidRequest
+	}
+	points, err := s.snapRepo.FindRange(ctx, oid, h.From, endOfDay(h.To))
+	if err != nil {
+		return nil, err
+	}
+	if rng == "ALL" {
+		points = weeklyPoints(points)
+		if len(points) > 0 {
+			h.From = points[0].Date
+		}
+	}
+	h.Points = points
+	if h.Points == nil {
+		h.Points = []model.PortfolioSnapshot{}
+	}
+	if n := len(points); n > 1 {
+		first, last := points[0].Value, points[n-1].Value
+		h.Change = roundAmount(last - first)
+		if first > 0 {
+			h.ChangePct = roundAmount((last - first) / first * 100)
+		}
+	}
+	return h, nil
+}
+
+// weeklyPoints keeps the last snapshot of each ISO week.
+func weeklyPoints(points []model.PortfolioSnapshot) []model.PortfolioSnapshot {
+	var out []model.PortfolioSnapshot
+	for i, p := range points {
+		if i+1 < len(points) {
+			y1, w1 := p.Date.ISOWeek()
+			y2, w2 := points[i+1].Date.ISOWeek()
+			if y1 == y2 && w1 == w2 {
+				continue
+			}
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// dateOf is the calendar date of t as midnight UTC, the form snapshot and
+// NAV dates are stored in.
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/banking-superapp/wealth-service/model"
+	"github.com/banking-superapp/wealth-service/repository"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+var ErrNotWatching = errors.New("scheme not in watchlist")
+
+type WatchlistService interface {
+	Add(ctx context.Context, userID string, req *model.WatchlistRequest) (*model.WatchlistItem, error)
+	List(ctx context.Context, userID string) ([]model.WatchlistEntry, error)
+	Remove(ctx context.Context, userID, schemeCode string) error
+	// EvaluateAlerts checks every watcher of the given schemes against their
+	// current NAV and returns the number of notification events raised.
+	EvaluateAlerts(ctx context.Context, schemeCodes []string) (int, error)
+}
+
+type watchlistService struct {
+	watchRepo repository.WatchlistRepo
+	mfRepo    repository.MFSchemeRepo
+	navRepo   repository.NAVRepo
+	notifRepo repository.NotificationRepo
+	tx        repository.Transactor
+}
+
+func NewWatchlistService(wr repository.WatchlistRepo, mr repository.MFSchemeRepo, nr repository.NAVRepo, ntr repository.NotificationRepo, tx repository.Transactor) WatchlistService {
+	return &watchlistService{wr, mr, nr, ntr, tx}
+}
+
+func (s *watchlistService) Add(ctx context.Context, userID string, req *model.WatchlistRequest) (*model.WatchlistItem, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	if req.Alerts.DropPct < 0 || req.Alerts.DropPct >= 100 {
+		return nil, ErrInvalidRequest
+	}
+	sc, err := s.mfRepo.FindByCode(ctx, req.SchemeCode)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrSchemeNotFound
+		}
+		return nil, err
+	}
+
+	item := &model.WatchlistItem{
+		UserID:     oid,
+		SchemeCode: sc.SchemeCode,
+		SchemeName: sc.SchemeName,
+		AddedAt:    time.Now(),
+		NAVAtAdd:   sc.NAV,
+		Alerts:     req.Alerts,
+	}
+	if err := s.watchRepo.Upsert(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (s *watchlistService) List(ctx context.Context, userID string) ([]model.WatchlistEntry, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	items, err := s.watchRepo.FindByUserID(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]model.WatchlistEntry, 0, len(items))
+	for _, item := range items {
+		e := model.WatchlistEntry{WatchlistItem: item}
+		sc, err := s.mfRepo.FindByCode(ctx, item.SchemeCode)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, err
+		}
+		if sc != nil {
+			e.Category = sc.Category
+			e.NAV, e.NAVDate = sc.NAV, sc.NAVDate
+			e.Returns1Y, e.Returns3Y, e.Returns5Y = sc.Returns1Y, sc.Returns3Y, sc.Returns5Y
+			if item.NAVAtAdd > 0 {
+				e.ChangeSinceAddPct = roundAmount((sc.NAV/item.NAVAtAdd - 1) * 100)
+			}
+			prev, err := s.previousNAV(ctx, sc)
+			if err != nil {
+				return nil, err
+			}
+			if prev > 0 {
+				e.DayChange = roundUnits(sc.NAV - prev)
+				e.DayChangePct = roundAmount((sc.NAV/prev - 1) * 100)
+			}
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+func (s *watchlistService) Remove(ctx context.Context, userID, schemeCode string) error {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return ErrUnauthorized
+	}
+	deleted, err := s.watchRepo.Delete(ctx, oid, schemeCode)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrNotWatching
+	}
+	return nil
+}
+
+// previousNAV is the last published NAV before the scheme's current one, or
+// zero if history does not reach back that far.
+func (s *watchlistService) previousNAV(ctx context.Context, sc *model.MFScheme) (float64, error) {
+	if sc.NAVDate.IsZero() {
+		return 0, nil
+	}
+	points, err := s.navRepo.FindRange(ctx, sc.SchemeCode, sc.NAVDate.AddDate(0, 0, -14), sc.NAVDate.Add(-time.Nanosecond))
+	if err != nil || len(points) == 0 {
+		return 0, err
+	}
+	return points[len(points)-1].NAV, nil
+}
+
+// EvaluateAlerts logs a scheme or watchlist entry that fails and carries on
+// with the rest, so one bad entry does not silence everyone else's alerts.
+func (s *watchlistService) EvaluateAlerts(ctx context.Context, schemeCodes []string) (int, error) {
+	raised := 0
+	for _, code := range schemeCodes {
+		n, err := s.evaluateScheme(ctx, code)
+		raised += n
+		if err != nil {
+			log.Printf("watchlist: scheme %s: %v", code, err)
+		}
+	}
+	return raised, nil
+}
+
+func (s *watchlistService) evaluateScheme(ctx context.Context, code string) (int, error) {
+	items, err := s.watchRepo.FindByScheme(ctx, code)
+	if err != nil || len(items) == 0 {
+		return 0, err
+	}
+	sc, err := s.mfRepo.FindByCode(ctx, code)
+	if err != nil {
+		return 0, err
+	}
+	isLow, err := s.at52WeekLow(ctx, sc)
+	if err != nil {
+		return 0, err
+	}
+
+	raised := 0
+	for _, item := range items {
+		n, err := s.evaluateItem(ctx, sc, isLow, item)
+		if err != nil {
+			log.Printf("watchlist: item %s: %v", item.ID.Hex(), err)
+			continue
+		}
+		raised += n
+	}
+	return raised, nil
+}
+
+// evaluateItem raises the entry's due alerts and records its new alert
+// state in one transaction, so an alert is neither lost nor sent twice.
+func (s *watchlistService) evaluateItem(ctx context.Context, sc *model.MFScheme, isLow bool, item model.WatchlistItem) (int, error) {
+	type alert struct {
+		event, title, body string
+		data               map[string]interface{}
+	}
+	var alerts []alert
+	dropAlerted, lowAlertedFor := item.DropAlerted, item.LowAlertedFor
+	if item.Alerts.DropPct > 0 && item.NAVAtAdd > 0 {
+		change := (sc.NAV/item.NAVAtAdd - 1) * 100
+		switch {
+		case change <= -item.Alerts.DropPct && !dropAlerted:
+			alerts = append(alerts, alert{model.EventWatchNAVDrop, "NAV drop alert",
+				fmt.Sprintf("%s is down %.2f%% since you added it to your watchlist.", sc.SchemeName, -change),
+				map[string]interface{}{"nav": sc.NAV, "nav_date": sc.NAVDate, "change_pct": roundAmount(change)}})
+			dropAlerted = true
+		case change > -item.Alerts.DropPct:
+			dropAlerted = false
+		}
+	}
+	if item.Alerts.FiftyTwoWeekLow && isLow && !lowAlertedFor.Equal(sc.NAVDate) {
+		alerts = append(alerts, alert{model.EventWatch52WeekLow, "52-week low",
+			fmt.Sprintf("%s hit a 52-week low NAV of %.4f.", sc.SchemeName, sc.NAV),
+			map[string]interface{}{"nav": sc.NAV, "nav_date": sc.NAVDate}})
+		lowAlertedFor = sc.NAVDate
+	}
+	if dropAlerted == item.DropAlerted && lowAlertedFor.Equal(item.LowAlertedFor) {
+		return 0, nil
+	}
+	err := s.tx.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, a := range alerts {
+			if err := s.notify(ctx, item, a.event, a.title, a.body, a.data); err != nil {
+				return err
+			}
+		}
+		return s.watchRepo.SetAlertState(ctx, item.ID, dropAlerted, lowAlertedFor)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(alerts), nil
+}
+
+// at52WeekLow reports whether the current NAV is at or below every NAV of
+// the preceding year. It needs a full year of history to say yes.
+func (s *watchlistService) at52WeekLow(ctx context.Context, sc *model.MFScheme) (bool, error) {
+	yearAgo := sc.NAVDate.AddDate(-1, 0, 0)
+	points, err := s.navRepo.FindRange(ctx, sc.SchemeCode, yearAgo, sc.NAVDate.Add(-time.Nanosecond))
+	if err != nil {
+		return false, err
+	}
+	if len(points) == 0 || points[0].Date.Sub(yearAgo) > 7*24*time.Hour {
+		return false, nil
+	}
+	for _, p := range points {
+		if p.NAV < sc.NAV {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (s *watchlistService) notify(ctx context.Context, item model.WatchlistItem, eventType, title, body string, data map[string]interface{}) error {
+	data["scheme_code"] = item.SchemeCode
+	return s.notifRepo.Create(ctx, &model.NotificationEvent{
+		UserID: item.UserID,
+		Type:   eventType,
+		Title:  title,
+		Body:   body,
+		Data:   data,
+	})
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/banking-superapp/wealth-service/model"
@@ -12,32 +13,132 @@ import (
 )
 
 var (
-	ErrSchemeNotFound = errors.New("scheme not found")
-	ErrUnauthorized   = errors.New("unauthorized")
+	ErrSchemeNotFound    = errors.New("scheme not found")
+	ErrFundNotFound      = errors.New("fund not found")
+	ErrUnauthorized      = errors.New("unauthorized")
+	ErrUnsupportedFormat = errors.New("unsupported import format")
 )
 
 type WealthService interface {
-	GetCatalogue(ctx context.Context, category string) ([]model.MFScheme, error)
+	GetCatalogue(ctx context.Context, category, plan, option string) ([]model.Fund, error)
+	GetFund(ctx context.Context, fundCode string) (*model.Fund, error)
+	GetSchemeDetail(ctx context.Context, code string) (*model.SchemeDetail, error)
+	ImportFactsheets(ctx context.Context, format string, data []byte) (*model.FactsheetImportResult, error)
 	CreateSIP(ctx context.Context, userID string, req *model.CreateSIPRequest) (*model.SIP, error)
 	GetPortfolio(ctx context.Context, userID string) (*model.Portfolio, error)
 	GetPortfolioAnalytics(ctx context.Context, userID string) (*model.PortfolioAnalytics, error)
+	GetPortfolioOverlap(ctx context.Context, userID string) (*model.PortfolioOverlap, error)
+	GetRebalancePlan(ctx context.Context, userID string) (*model.RebalancePlan, error)
+	GetTransactions(ctx context.Context, userID string, from, to time.Time) ([]model.Transaction, error)
 	AssessRiskProfile(ctx context.Context, userID string, req *model.RiskProfileRequest) (*model.RiskProfile, error)
 	GetRiskProfile(ctx context.Context, userID string) (*model.RiskProfile, error)
 }
 
 type wealthService struct {
-	mfRepo      repository.MFSchemeRepo
-	sipRepo     repository.SIPRepo
-	portRepo    repository.PortfolioRepo
-	riskRepo    repository.RiskProfileRepo
+	mfRepo   repository.MFSchemeRepo
+	sipRepo  repository.SIPRepo
+	portRepo repository.PortfolioRepo
+	riskRepo repository.RiskProfileRepo
+	factRepo repository.FactsheetRepo
+	txnRepo  repository.TransactionRepo
 }
 
-func NewWealthService(mr repository.MFSchemeRepo, sr repository.SIPRepo, pr repository.PortfolioRepo, rr repository.RiskProfileRepo) WealthService {
-	return &wealthService{mr, sr, pr, rr}
+func NewWealthService(mr repository.MFSchemeRepo, sr repository.SIPRepo, pr repository.PortfolioRepo, rr repository.RiskProfileRepo, fr repository.FactsheetRepo, tr repository.TransactionRepo) WealthService {
+	return &wealthService{mr, sr, pr, rr, fr, tr}
 }
 
-func (s *wealthService) GetCatalogue(ctx context.Context, category string) ([]model.MFScheme, error) {
-	return s.mfRepo.FindAll(ctx, category)
+func (s *wealthService) GetCatalogue(ctx context.Context, category, plan, option string) ([]model.Fund, error) {
+	schemes, err := s.mfRepo.FindAll(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+	filtered := schemes[:0]
+	for _, sc := range schemes {
+		if plan != "" && sc.Plan != plan {
+			continue
+		}
+		if option != "" && sc.Option != option {
+			continue
+		}
+		filtered = append(filtered, sc)
+	}
+	return groupFunds(filtered), nil
+}
+
+func (s *wealthService) GetFund(ctx context.Context, fundCode string) (*model.Fund, error) {
+	schemes, err := s.mfRepo.FindByFundCode(ctx, fundCode)
+	if err != nil {
+		return nil, err
+	}
+	if len(schemes) == 0 {
+		sc, err := s.mfRepo.FindByCode(ctx, fundCode)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, ErrFundNotFound
+			}
+			return nil, err
+		}
+		if sc.FundCode != "" || !sc.IsActive {
+			return nil, ErrFundNotFound
+		}
+		schemes = []model.MFScheme{*sc}
+	}
+	return &groupFunds(schemes)[0], nil
+}
+
+func (s *wealthService) GetSchemeDetail(ctx context.Context, code string) (*model.SchemeDetail, error) {
+	scheme, err := s.mfRepo.FindByCode(ctx, code)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrSchemeNotFound
+		}
+		return nil, err
+	}
+
+	detail := &model.SchemeDetail{Scheme: scheme}
+	fs, err := s.factRepo.FindLatest(ctx, code)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	detail.Factsheet = fs
+	return detail, nil
+}
+
+func (s *wealthService) ImportFactsheets(ctx context.Context, format string, data []byte) (*model.FactsheetImportResult, error) {
+	var (
+		sheets []model.Factsheet
+		errs   []string
+		err    error
+	)
+	switch format {
+	case "json":
+		sheets, errs, err = parseFactsheetJSON(data)
+	case "csv":
+		sheets, errs, err = parseFactsheetCSV(data)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res := &model.FactsheetImportResult{Failed: len(errs), Errors: errs}
+	for i := range sheets {
+		fs := &sheets[i]
+		if _, err := s.mfRepo.FindByCode(ctx, fs.SchemeCode); err != nil {
+			if !errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, err
+			}
+			res.Failed++
+			res.Errors = append(res.Errors, fmt.Sprintf("%s: %v", fs.SchemeCode, ErrSchemeNotFound))
+			continue
+		}
+		if err := s.factRepo.Upsert(ctx, fs); err != nil {
+			return nil, err
+		}
+		res.Imported++
+	}
+	return res, nil
 }
 
 func (s *wealthService) CreateSIP(ctx context.Context, userID string, req *model.CreateSIPRequest) (*model.SIP, error) {
@@ -95,6 +196,7 @@ func (s *wealthService) GetPortfolio(ctx context.Context, userID string) (*model
 		}
 		return nil, err
 	}
+	revalueDeposits(portfolio, time.Now())
 	return portfolio, nil
 }
 
@@ -106,11 +208,33 @@ func (s *wealthService) GetPortfolioAnalytics(ctx context.Context, userID string
 
 	var totalInvested, currentValue, gainLoss float64
 	categoryBreakdown := make(map[string]float64)
+	assetBreakdown := make(map[string]float64)
 
-	for _, h := range portfolio.Holdings {
+	if err := s.fillCategories(ctx, portfolio); err != nil {
+		return nil, err
+	}
+	for i := range portfolio.Holdings {
+		h := &portfolio.Holdings[i]
 		totalInvested += h.InvestedValue
 		currentValue += h.CurrentValue
 		gainLoss += h.GainLoss
+
+		assetType := h.AssetType
+		if assetType == "" {
+			assetType = model.AssetMutualFund
+		}
+		for c, v := range classValues(h) {
+			categoryBreakdown[c] += v
+		}
+		assetBreakdown[assetType] += h.CurrentValue
+	}
+	for _, m := range []map[string]float64{categoryBreakdown, assetBreakdown} {
+		for k, v := range m {
+			m[k] = 0
+			if currentValue > 0 {
+				m[k] = roundAmount(v / currentValue * 100)
+			}
+		}
 	}
 
 	retPct := 0.0
@@ -129,10 +253,45 @@ func (s *wealthService) GetPortfolioAnalytics(ctx context.Context, userID string
 		TotalGainLoss:     gainLoss,
 		ReturnPct:         retPct,
 		CategoryBreakdown: categoryBreakdown,
+		AssetBreakdown:    assetBreakdown,
 		TopHoldings:       topHoldings,
 	}, nil
 }
 
+// fillCategories gives fund holdings written before categories were
+// recorded on the holding their scheme's category.
+func (s *wealthService) fillCategories(ctx context.Context, p *model.Portfolio) error {
+	for i := range p.Holdings {
+		h := &p.Holdings[i]
+		if !isFund(h) || h.Category != "" {
+			continue
+		}
+		sc, err := s.mfRepo.FindByCode(ctx, h.SchemeCode)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		if sc != nil {
+			h.Category = sc.Category
+		}
+	}
+	return nil
+}
+
+func (s *wealthService) GetTransactions(ctx context.Context, userID string, from, to time.Time) ([]model.Transaction, error) {
+	oid, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+	txns, err := s.txnRepo.FindByUserID(ctx, oid, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if txns == nil {
+		txns = []model.Transaction{}
+	}
+	return txns, nil
+}
+
 func (s *wealthService) AssessRiskProfile(ctx context.Context, userID string, req *model.RiskProfileRequest) (*model.RiskProfile, error) {
 	oid, err := bson.ObjectIDFromHex(userID)
 	if err != nil {